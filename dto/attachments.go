@@ -0,0 +1,61 @@
+package dto
+
+import (
+	"io"
+	"time"
+	"todo-app/entity"
+)
+
+type AttachmentResponse struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+	Data    any    `json:"data"`
+}
+
+// UploadAttachment is a file of a multipart upload, Size is the size the
+// client declared for it.
+type UploadAttachment struct {
+	Filename string
+	Size     int64
+	File     io.Reader
+}
+
+type DownloadQuery struct {
+	Expires   int64  `query:"expires"`
+	Signature string `query:"signature"`
+}
+
+// AttachmentDownload streams the file of an attachment, Body has to be
+// closed by the caller.
+type AttachmentDownload struct {
+	Filename    string
+	ContentType string
+	Size        int64
+	Body        io.ReadCloser
+}
+
+type Attachment struct {
+	Id           uint      `json:"id"`
+	TodoId       uint      `json:"todo_id"`
+	UserId       uint      `json:"user_id"`
+	Filename     string    `json:"filename"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	Checksum     string    `json:"checksum"`
+	URL          string    `json:"url"`
+	URLExpiresAt time.Time `json:"url_expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func EntityToAttachment(a *entity.Attachment) *Attachment {
+	return &Attachment{
+		Id:          a.ID,
+		TodoId:      a.TodoID,
+		UserId:      a.UserID,
+		Filename:    a.Filename,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Checksum:    a.Checksum,
+		CreatedAt:   a.CreatedAt,
+	}
+}
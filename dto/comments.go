@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"time"
+	"todo-app/entity"
+)
+
+type CommentResponse struct {
+	Status     int    `json:"status"`
+	Message    string `json:"message"`
+	Data       any    `json:"data"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+type AddComment struct {
+	Body string `json:"body" valid:"required~ Body can't be empty" example:"@friend@mail.com can you pick these up?"`
+}
+
+type ModifyComment struct {
+	Body string `json:"body" valid:"required~ Body can't be empty" example:"done, thanks!"`
+}
+
+type CommentQuery struct {
+	Cursor string `query:"cursor"`
+	Limit  int    `query:"limit"`
+}
+
+type Comment struct {
+	Id        uint       `json:"id"`
+	TodoId    uint       `json:"todo_id"`
+	UserId    uint       `json:"user_id"`
+	Name      string     `json:"name"`
+	Body      string     `json:"body"`
+	Mentions  []uint     `json:"mentions"`
+	CreatedAt time.Time  `json:"created_at"`
+	EditedAt  *time.Time `json:"edited_at"`
+}
+
+func EntityToComment(c *entity.Comment) *Comment {
+	return &Comment{
+		Id:        c.ID,
+		TodoId:    c.TodoID,
+		UserId:    c.UserID,
+		Name:      c.User.Name,
+		Body:      c.Body,
+		Mentions:  c.MentionedUserIds(),
+		CreatedAt: c.CreatedAt,
+		EditedAt:  c.EditedAt,
+	}
+}
@@ -0,0 +1,68 @@
+package dto
+
+import (
+	"time"
+	"todo-app/entity"
+)
+
+type ProjectResponse struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+	Data    any    `json:"data"`
+}
+
+type AddProject struct {
+	Name  string `json:"name" valid:"required~ Name can't be empty"`
+	Color string `json:"color" valid:"hexcolor~ Color must be a hex color" example:"#ff0000"`
+}
+
+func (a *AddProject) AddProjectToEntity() *entity.Project {
+	return &entity.Project{
+		Name:  a.Name,
+		Color: a.Color,
+	}
+}
+
+type ModifyProject struct {
+	Name     string `json:"name" valid:"required~ Name can't be empty"`
+	Color    string `json:"color" valid:"hexcolor~ Color must be a hex color" example:"#ff0000"`
+	Archived bool   `json:"archived"`
+}
+
+func (m *ModifyProject) ModifyProjectToEntity() *entity.Project {
+	return &entity.Project{
+		Name:     m.Name,
+		Color:    m.Color,
+		Archived: m.Archived,
+	}
+}
+
+type ProjectQuery struct {
+	Archived bool `query:"archived"`
+}
+
+type DeleteProjectQuery struct {
+	Mode string `query:"mode"`
+}
+
+type Project struct {
+	Id          uint      `json:"id"`
+	Name        string    `json:"name"`
+	Color       string    `json:"color"`
+	Archived    bool      `json:"archived"`
+	WorkspaceId *uint     `json:"workspace_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func EntityToProject(p *entity.Project) *Project {
+	return &Project{
+		Id:          p.ID,
+		Name:        p.Name,
+		Color:       p.Color,
+		Archived:    p.Archived,
+		WorkspaceId: p.WorkspaceID,
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+	}
+}
@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"time"
+	"todo-app/entity"
+)
+
+type ShareResponse struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+	Data    any    `json:"data"`
+}
+
+type AddShare struct {
+	Email string `json:"email" valid:"required~ Email can't be empty, email" example:"friend@mail.com"`
+	Role  string `json:"role" valid:"required~ Role can't be empty" example:"editor"`
+}
+
+type ModifyShare struct {
+	Role string `json:"role" valid:"required~ Role can't be empty" example:"viewer"`
+}
+
+type Share struct {
+	Id         uint       `json:"id"`
+	TodoId     *uint      `json:"todo_id"`
+	ProjectId  *uint      `json:"project_id"`
+	UserId     uint       `json:"user_id"`
+	Name       string     `json:"name"`
+	Email      string     `json:"email"`
+	Role       string     `json:"role"`
+	InvitedBy  uint       `json:"invited_by"`
+	AcceptedAt *time.Time `json:"accepted_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func EntityToShare(s *entity.Share) *Share {
+	return &Share{
+		Id:         s.ID,
+		TodoId:     s.TodoID,
+		ProjectId:  s.ProjectID,
+		UserId:     s.UserID,
+		Name:       s.User.Name,
+		Email:      s.User.Email,
+		Role:       s.Role,
+		InvitedBy:  s.InvitedBy,
+		AcceptedAt: s.AcceptedAt,
+		CreatedAt:  s.CreatedAt,
+	}
+}
@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"time"
+	"todo-app/entity"
+)
+
+type SubtaskResponse struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+	Data    any    `json:"data"`
+}
+
+type AddSubtask struct {
+	Title string `json:"title" valid:"required~ Title can't be empty"`
+}
+
+type ModifySubtask struct {
+	Title string `json:"title" valid:"required~ Title can't be empty"`
+	Done  bool   `json:"done"`
+}
+
+func (m *ModifySubtask) ModifySubtaskToEntity() *entity.Subtask {
+	return &entity.Subtask{
+		Title: m.Title,
+		Done:  m.Done,
+	}
+}
+
+type ReorderSubtasks struct {
+	SubtaskIds []uint `json:"subtask_ids"`
+}
+
+type Subtask struct {
+	Id        uint      `json:"id"`
+	Title     string    `json:"title"`
+	Done      bool      `json:"done"`
+	Position  int       `json:"position"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func EntityToSubtask(s *entity.Subtask) *Subtask {
+	return &Subtask{
+		Id:        s.ID,
+		Title:     s.Title,
+		Done:      s.Done,
+		Position:  s.Position,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
+
+type Progress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
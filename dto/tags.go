@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"time"
+	"todo-app/entity"
+)
+
+type TagResponse struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+	Data    any    `json:"data"`
+}
+
+type AddTag struct {
+	Name  string `json:"name" valid:"required~ Name can't be empty"`
+	Color string `json:"color" valid:"hexcolor~ Color must be a hex color" example:"#ff0000"`
+}
+
+func (a *AddTag) AddTagToEntity() *entity.Tag {
+	return &entity.Tag{
+		Name:  a.Name,
+		Color: a.Color,
+	}
+}
+
+type ModifyTag struct {
+	Name  string `json:"name" valid:"required~ Name can't be empty"`
+	Color string `json:"color" valid:"hexcolor~ Color must be a hex color" example:"#ff0000"`
+}
+
+func (m *ModifyTag) ModifyTagToEntity() *entity.Tag {
+	return &entity.Tag{
+		Name:  m.Name,
+		Color: m.Color,
+	}
+}
+
+type Tag struct {
+	Id        uint      `json:"id"`
+	Name      string    `json:"name"`
+	Color     string    `json:"color"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func EntityToTag(t *entity.Tag) *Tag {
+	return &Tag{
+		Id:        t.ID,
+		Name:      t.Name,
+		Color:     t.Color,
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
@@ -6,18 +6,38 @@ import (
 )
 
 type TodoResponse struct {
-	Status  int    `json:"status"`
-	Message string `json:"message"`
-	Data    any    `json:"data"`
+	Status     int     `json:"status"`
+	Message    string  `json:"message"`
+	Data       any     `json:"data"`
+	NextCursor *string `json:"next_cursor,omitempty"`
 }
 
 type AddTodo struct {
-	Todos string `json:"todos" valid:"required~ Todos can't be empty"`
+	Todos     string `json:"todos" valid:"required~ Todos can't be empty"`
+	DueDate   string `json:"due_date" example:"2024-01-31"`
+	DueTime   string `json:"due_time" example:"17:00"`
+	TimeZone  string `json:"timezone" example:"Asia/Jakarta"`
+	Priority  string `json:"priority" example:"high"`
+	TagIds    []uint `json:"tag_ids"`
+	ProjectId *uint  `json:"project_id"`
+
+	Recurrence           string `json:"recurrence" example:"FREQ=WEEKLY;BYDAY=MO,TH"`
+	RepeatFromCompletion bool   `json:"repeat_from_completion"`
 }
 
 type ModifyTodo struct {
-	Todos  string `json:"todos" valid:"required~ Todos can't be empty"`
-	Status bool   `json:"status"`
+	Todos            string `json:"todos" valid:"required~ Todos can't be empty"`
+	Status           bool    `json:"status"`
+	DueDate          *string `json:"due_date" example:"2024-01-31"`
+	DueTime          string  `json:"due_time" example:"17:00"`
+	TimeZone         string  `json:"timezone" example:"Asia/Jakarta"`
+	Priority         string  `json:"priority" example:"high"`
+	TagIds           []uint  `json:"tag_ids"`
+	ProjectId        *uint   `json:"project_id"`
+	CompleteSubtasks bool    `json:"complete_subtasks"`
+
+	Recurrence           *string `json:"recurrence" example:"FREQ=MONTHLY;BYMONTHDAY=1"`
+	RepeatFromCompletion bool    `json:"repeat_from_completion"`
 }
 
 func (m *ModifyTodo) ModifyTodoToEntity() *entity.Todo {
@@ -27,10 +47,139 @@ func (m *ModifyTodo) ModifyTodoToEntity() *entity.Todo {
 	}
 }
 
+type ModifyStatus struct {
+	Status           bool `json:"status"`
+	CompleteSubtasks bool `json:"complete_subtasks"`
+}
+
+type AssignTodo struct {
+	UserId uint `json:"user_id" valid:"required~ User id can't be empty"`
+}
+
+type ReorderTodos struct {
+	TodoIds []uint `json:"todo_ids"`
+}
+
+type BulkTodos struct {
+	TodoIds      []uint `json:"todo_ids"`
+	Action       string `json:"action" valid:"required~ Action can't be empty" example:"complete"`
+	ProjectId    *uint  `json:"project_id"`
+	TagId        uint   `json:"tag_id"`
+	AllOrNothing bool   `json:"all_or_nothing"`
+}
+
+type BulkResult struct {
+	Id      uint   `json:"id"`
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+type TodoQuery struct {
+	Due         string `query:"due"`
+	TimeZone    string `query:"tz"`
+	Tags        []uint `query:"tags"`
+	TagMode     string `query:"tag_mode"`
+	Search      string `query:"q"`
+	Status      string `query:"status"`
+	Assignee    string `query:"assignee"`
+	CreatedFrom string `query:"created_from"`
+	CreatedTo   string `query:"created_to"`
+	UpdatedFrom string `query:"updated_from"`
+	UpdatedTo   string `query:"updated_to"`
+	Sort        string `query:"sort"`
+	Order       string `query:"order"`
+	Cursor      string `query:"cursor"`
+	Limit       int    `query:"limit"`
+}
+
+type SearchQuery struct {
+	Q     string `query:"q"`
+	Limit int    `query:"limit"`
+}
+
+type TodoMatch struct {
+	Todo    *Todo   `json:"todo"`
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"`
+}
+
 type Todo struct {
-	Id        uint      `json:"id"`
-	Todos     string    `json:"todos"`
-	Status    bool      `json:"status"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	Id        uint       `json:"id"`
+	Todos     string     `json:"todos"`
+	Status    bool       `json:"status"`
+	DueAt     *time.Time `json:"due_at"`
+	DueAllDay bool       `json:"due_all_day"`
+	TimeZone  string     `json:"timezone"`
+	Overdue   bool       `json:"overdue"`
+	Priority  string     `json:"priority"`
+	Position  int        `json:"position"`
+	Tags      []*Tag     `json:"tags"`
+	ProjectId *uint      `json:"project_id"`
+	Progress  Progress   `json:"progress"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+
+	Recurrence           string     `json:"recurrence"`
+	RepeatFromCompletion bool       `json:"repeat_from_completion"`
+	Occurrence           int        `json:"occurrence"`
+	NextDueAt            *time.Time `json:"next_due_at"`
+	DeletedAt            *time.Time `json:"deleted_at,omitempty"`
+
+	WorkspaceId *uint `json:"workspace_id"`
+	AssigneeId  *uint `json:"assignee_id"`
+
+	CommentCount int `json:"comment_count"`
+
+	// Shared is set on todos owned by someone else that the user sees
+	// through a share
+	Shared bool `json:"shared"`
+}
+
+func EntityToTodo(t *entity.Todo) *Todo {
+	tags := []*Tag{}
+
+	for i := range t.Tags {
+		tags = append(tags, EntityToTag(&t.Tags[i]))
+	}
+
+	progress := Progress{Total: len(t.Subtasks)}
+
+	for _, eachSubtask := range t.Subtasks {
+		if eachSubtask.Done {
+			progress.Done++
+		}
+	}
+
+	todo := &Todo{
+		Id:        t.ID,
+		Todos:     t.Todos,
+		Status:    t.Status,
+		DueAt:     t.DueAt,
+		DueAllDay: t.DueAllDay,
+		TimeZone:  t.TimeZone,
+		Overdue:   t.IsOverdue(time.Now()),
+		Priority:  t.Priority.String(),
+		Position:  t.Position,
+		Tags:      tags,
+		ProjectId: t.ProjectID,
+		Progress:  progress,
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+
+		Recurrence:           t.Recurrence,
+		RepeatFromCompletion: t.RepeatFromCompletion,
+		Occurrence:           t.Occurrence,
+		NextDueAt:            t.NextDue(time.Now()),
+
+		WorkspaceId: t.WorkspaceID,
+		AssigneeId:  t.AssigneeID,
+
+		CommentCount: t.CommentCount,
+	}
+
+	if t.DeletedAt.Valid {
+		todo.DeletedAt = &t.DeletedAt.Time
+	}
+
+	return todo
 }
@@ -1,6 +1,9 @@
 package dto
 
-import "todo-app/entity"
+import (
+	"time"
+	"todo-app/entity"
+)
 
 type UserResponse struct {
 	Status  int    `json:"status"`
@@ -9,7 +12,13 @@ type UserResponse struct {
 }
 
 type Token struct {
-	TokenString string `json:"token"`
+	TokenString  string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+type RefreshToken struct {
+	RefreshToken string `json:"refresh_token" valid:"required~ Refresh token can't be empty"`
 }
 
 type Register struct {
@@ -27,24 +36,145 @@ func (r *Register) RegisterToEntity() *entity.User {
 }
 
 type Login struct {
-	Email    string `json:"email" valid:"required~ Email can't be empty, email"`
-	Password string `json:"password" valid:"required~ Password can't be empty"`
+	Email      string `json:"email" valid:"required~ Email can't be empty, email"`
+	Password   string `json:"password" valid:"required~ Password can't be empty"`
+	DeviceName string `json:"device_name" example:"Jihan's phone"`
+
+	// IP and UserAgent are read from the request by the handler
+	IP        string `json:"-"`
+	UserAgent string `json:"-"`
 }
 
 type Modify struct {
-	Name  string `json:"name" valid:"required~ Name can't be empty"`
-	Email string `json:"email" valid:"required~ Email can't be empty, email"`
+	Name           string `json:"name" valid:"required~ Name can't be empty"`
+	Email          string `json:"email" valid:"required~ Email can't be empty, email"`
+	SearchLanguage string `json:"search_language" example:"english"`
 }
 
 func (m *Modify) ModifyToEntity() *entity.User {
 	return &entity.User{
-		Name:  m.Name,
-		Email: m.Email,
+		Name:           m.Name,
+		Email:          m.Email,
+		SearchLanguage: m.SearchLanguage,
 	}
 }
 
+type ChangePassword struct {
+	CurrentPassword string `json:"current_password" valid:"required~ Current password can't be empty"`
+	NewPassword     string `json:"new_password" valid:"required~ New password can't be empty"`
+}
+
+type ForgotPassword struct {
+	Email string `json:"email" valid:"required~ Email can't be empty, email"`
+}
+
+type ResetPassword struct {
+	Token       string `json:"token" valid:"required~ Token can't be empty"`
+	NewPassword string `json:"new_password" valid:"required~ New password can't be empty"`
+}
+
+type VerifyEmail struct {
+	Token string `json:"token" valid:"required~ Token can't be empty"`
+}
+
+type ResendVerification struct {
+	Email string `json:"email" valid:"required~ Email can't be empty, email"`
+}
+
+type MFAChallenge struct {
+	MFARequired bool   `json:"mfa_required"`
+	MFAToken    string `json:"mfa_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+type LoginMFA struct {
+	MFAToken     string `json:"mfa_token" valid:"required~ MFA token can't be empty"`
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recovery_code"`
+	DeviceName   string `json:"device_name" example:"Jihan's phone"`
+
+	// IP and UserAgent are read from the request by the handler
+	IP        string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+type TOTPEnrolment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+type ConfirmTOTP struct {
+	Code string `json:"code" valid:"required~ Code can't be empty"`
+}
+
+type DisableTOTP struct {
+	Password string `json:"password" valid:"required~ Password can't be empty"`
+}
+
+type RecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
 type Profile struct {
-	Id    uint   `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
+	Id             uint   `json:"id"`
+	Name           string `json:"name"`
+	Email          string `json:"email"`
+	SearchLanguage string `json:"search_language"`
+	EmailVerified  bool   `json:"email_verified"`
+	PendingEmail   string `json:"pending_email"`
+	TOTPEnabled    bool   `json:"totp_enabled"`
+}
+
+type Session struct {
+	Id         uint      `json:"id"`
+	DeviceName string    `json:"device_name"`
+	IP         string    `json:"ip"`
+	UserAgent  string    `json:"user_agent"`
+	Current    bool      `json:"current"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+}
+
+func EntityToSession(s *entity.Session, currentJti string) *Session {
+	return &Session{
+		Id:         s.ID,
+		DeviceName: s.DeviceName,
+		IP:         s.IP,
+		UserAgent:  s.UserAgent,
+		Current:    s.Jti == currentJti,
+		CreatedAt:  s.CreatedAt,
+		LastSeenAt: s.LastSeenAt,
+	}
+}
+
+type AddPersonalToken struct {
+	Name          string   `json:"name" valid:"required~ Name can't be empty"`
+	Scopes        []string `json:"scopes" example:"todos:read,todos:write"`
+	ExpiresInDays int      `json:"expires_in_days" example:"30"`
+}
+
+type PersonalToken struct {
+	Id         uint       `json:"id"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// NewPersonalToken carries the raw token, which is only shown once.
+type NewPersonalToken struct {
+	*PersonalToken
+	Token string `json:"token"`
+}
+
+func EntityToPersonalToken(pt *entity.PersonalToken) *PersonalToken {
+	return &PersonalToken{
+		Id:         pt.ID,
+		Name:       pt.Name,
+		Scopes:     pt.ScopeList(),
+		ExpiresAt:  pt.ExpiresAt,
+		LastUsedAt: pt.LastUsedAt,
+		CreatedAt:  pt.CreatedAt,
+	}
 }
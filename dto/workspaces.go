@@ -0,0 +1,80 @@
+package dto
+
+import (
+	"time"
+	"todo-app/entity"
+)
+
+type WorkspaceResponse struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+	Data    any    `json:"data"`
+}
+
+type AddWorkspace struct {
+	Name string `json:"name" valid:"required~ Name can't be empty" example:"Marketing"`
+}
+
+type ModifyWorkspace struct {
+	Name string `json:"name" valid:"required~ Name can't be empty" example:"Marketing"`
+}
+
+type InviteMember struct {
+	Email string `json:"email" valid:"required~ Email can't be empty, email" example:"friend@mail.com"`
+	Role  string `json:"role" valid:"required~ Role can't be empty" example:"member"`
+}
+
+type ModifyMember struct {
+	Role string `json:"role" valid:"required~ Role can't be empty" example:"admin"`
+}
+
+type TransferWorkspace struct {
+	UserId uint `json:"user_id" valid:"required~ User id can't be empty"`
+}
+
+type Workspace struct {
+	Id        uint      `json:"id"`
+	Name      string    `json:"name"`
+	OwnerId   uint      `json:"owner_id"`
+	Role      string    `json:"role,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func EntityToWorkspace(w *entity.Workspace) *Workspace {
+	return &Workspace{
+		Id:        w.ID,
+		Name:      w.Name,
+		OwnerId:   w.OwnerID,
+		CreatedAt: w.CreatedAt,
+		UpdatedAt: w.UpdatedAt,
+	}
+}
+
+type WorkspaceMember struct {
+	Id            uint       `json:"id"`
+	WorkspaceId   uint       `json:"workspace_id"`
+	WorkspaceName string     `json:"workspace_name"`
+	UserId        uint       `json:"user_id"`
+	Name          string     `json:"name"`
+	Email         string     `json:"email"`
+	Role          string     `json:"role"`
+	InvitedBy     uint       `json:"invited_by"`
+	AcceptedAt    *time.Time `json:"accepted_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+func EntityToWorkspaceMember(m *entity.WorkspaceMember) *WorkspaceMember {
+	return &WorkspaceMember{
+		Id:            m.ID,
+		WorkspaceId:   m.WorkspaceID,
+		WorkspaceName: m.Workspace.Name,
+		UserId:        m.UserID,
+		Name:          m.User.Name,
+		Email:         m.User.Email,
+		Role:          m.Role,
+		InvitedBy:     m.InvitedBy,
+		AcceptedAt:    m.AcceptedAt,
+		CreatedAt:     m.CreatedAt,
+	}
+}
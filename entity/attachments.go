@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Attachment is the metadata of a file attached to a todo, the file itself
+// is kept in the storage under StorageKey. Checksum is the hex encoded
+// SHA-256 of the file.
+type Attachment struct {
+	gorm.Model
+	TodoID      uint `gorm:"index"`
+	UserID      uint
+	Filename    string
+	ContentType string
+	Size        int64
+	Checksum    string
+	StorageKey  string `gorm:"uniqueIndex"`
+}
+
+// AttachmentKey builds the storage key of a new attachment, name is random so
+// keys can't be guessed from the todo.
+func AttachmentKey(todoId uint, name string) string {
+	return fmt.Sprintf("todos/%d/%s", todoId, name)
+}
@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Comment is a message in the thread of a todo, EditedAt is set once its
+// author changes the body.
+type Comment struct {
+	gorm.Model
+	TodoID   uint `gorm:"index"`
+	UserID   uint
+	Body     string
+	EditedAt *time.Time
+	User     User `gorm:"foreignKey:UserID"`
+	Mentions []CommentMention
+}
+
+// CommentMention records a user mentioned in a comment, so they can be
+// notified about it.
+type CommentMention struct {
+	ID        uint `gorm:"primarykey"`
+	CommentID uint `gorm:"uniqueIndex:idx_comment_mentions_user"`
+	UserID    uint `gorm:"index;uniqueIndex:idx_comment_mentions_user"`
+	CreatedAt time.Time
+}
+
+// mentionPattern matches users mentioned by their email, like
+// @zee@weeekly.com
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w.%+-])@([\w.%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,})`)
+
+// ParseMentions returns the emails mentioned in a comment body, each email
+// once in the order it first appears.
+func ParseMentions(body string) []string {
+
+	emails := []string{}
+	seen := map[string]bool{}
+
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		email := match[1]
+
+		if !seen[strings.ToLower(email)] {
+			seen[strings.ToLower(email)] = true
+			emails = append(emails, email)
+		}
+	}
+
+	return emails
+}
+
+func (c *Comment) MentionedUserIds() []uint {
+
+	ids := []uint{}
+
+	for _, eachMention := range c.Mentions {
+		ids = append(ids, eachMention.UserID)
+	}
+
+	return ids
+}
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"time"
+	"todo-app/infra/config"
+	"todo-app/pkg/errs"
+
+	"gorm.io/gorm"
+)
+
+// EmailVerification is a single use token mailed to an address to confirm
+// the user owns it, either on registration or on an email change.
+type EmailVerification struct {
+	gorm.Model
+	UserID    uint `gorm:"index"`
+	Email     string
+	TokenHash string `gorm:"uniqueIndex"`
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+}
+
+func NewEmailVerification(userId uint, email string) (*EmailVerification, string, errs.Error) {
+
+	raw, err := RandomToken(32)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	ttl := time.Duration(config.AppConfig().EmailVerificationTTLHours) * time.Hour
+
+	return &EmailVerification{
+		UserID:    userId,
+		Email:     email,
+		TokenHash: HashToken(raw),
+		ExpiresAt: time.Now().Add(ttl),
+	}, raw, nil
+}
+
+func (ev *EmailVerification) IsValid(now time.Time) bool {
+	return ev.UsedAt == nil && now.Before(ev.ExpiresAt)
+}
@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"time"
+	"todo-app/infra/config"
+)
+
+// LoginAttempt tracks the failed logins of a key, either an email or an IP.
+type LoginAttempt struct {
+	Key          string `gorm:"primaryKey"`
+	Failures     int
+	LastFailedAt time.Time
+	BlockedUntil time.Time
+}
+
+// maxBackoffShift doubles the backoff at most 20 times, over 12 days with a
+// one second backoff.
+const maxBackoffShift = 20
+
+// LoginLockout is how long a key is locked out once it reaches its maximum
+// failures, it is also how long failures are remembered.
+func LoginLockout() time.Duration {
+	return time.Duration(config.AppConfig().LoginLockoutMinutes) * time.Minute
+}
+
+// Fail registers a failed login. Each failure blocks the key for twice as
+// long as the previous one until maxFailures locks it out.
+func (a *LoginAttempt) Fail(now time.Time, maxFailures int) {
+
+	lockout := LoginLockout()
+
+	if now.Sub(a.LastFailedAt) >= lockout {
+		a.Failures = 0
+	}
+
+	a.Failures++
+	a.LastFailedAt = now
+
+	if a.Failures >= maxFailures {
+		a.BlockedUntil = now.Add(lockout)
+		return
+	}
+
+	// the exponent is capped so keys allowed many failures, like IPs, can't
+	// overflow the shift, the backoff is capped by the lockout anyway
+	backoff := time.Duration(config.AppConfig().LoginBackoffSeconds) * time.Second << min(a.Failures-1, maxBackoffShift)
+
+	a.BlockedUntil = now.Add(min(backoff, lockout))
+}
+
+// RetryAfter returns how long the key stays blocked, zero when it isn't.
+func (a *LoginAttempt) RetryAfter(now time.Time) time.Duration {
+	return max(a.BlockedUntil.Sub(now), 0)
+}
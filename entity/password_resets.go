@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"time"
+	"todo-app/infra/config"
+	"todo-app/pkg/errs"
+
+	"gorm.io/gorm"
+)
+
+// PasswordReset is a single use token mailed to a user who forgot their
+// password, only its hash is stored.
+type PasswordReset struct {
+	gorm.Model
+	UserID    uint   `gorm:"index"`
+	TokenHash string `gorm:"uniqueIndex"`
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+}
+
+func NewPasswordReset(userId uint) (*PasswordReset, string, errs.Error) {
+
+	raw, err := RandomToken(32)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	ttl := time.Duration(config.AppConfig().PasswordResetTTLMinutes) * time.Minute
+
+	return &PasswordReset{
+		UserID:    userId,
+		TokenHash: HashToken(raw),
+		ExpiresAt: time.Now().Add(ttl),
+	}, raw, nil
+}
+
+func (pr *PasswordReset) IsValid(now time.Time) bool {
+	return pr.UsedAt == nil && now.Before(pr.ExpiresAt)
+}
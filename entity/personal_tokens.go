@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"strings"
+	"time"
+	"todo-app/pkg/errs"
+
+	"gorm.io/gorm"
+)
+
+const (
+	ScopeTodosRead   = "todos:read"
+	ScopeTodosWrite  = "todos:write"
+	ScopeProfileRead = "profile:read"
+)
+
+var Scopes = []string{ScopeTodosRead, ScopeTodosWrite, ScopeProfileRead}
+
+// PersonalTokenPrefix tells personal tokens apart from JWTs in the
+// Authorization header.
+const PersonalTokenPrefix = "tdk_"
+
+// PersonalToken is a named long lived token scripts authenticate with
+// instead of a password, only its hash is stored.
+type PersonalToken struct {
+	gorm.Model
+	UserID     uint `gorm:"index"`
+	Name       string
+	TokenHash  string `gorm:"uniqueIndex"`
+	Scopes     string
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+func IsScope(name string) bool {
+
+	for _, eachScope := range Scopes {
+		if eachScope == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewPersonalToken issues a personal token, expiresAt is nil for a token
+// that never expires.
+func NewPersonalToken(userId uint, name string, scopes []string, expiresAt *time.Time) (*PersonalToken, string, errs.Error) {
+
+	raw, err := RandomToken(32)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	raw = PersonalTokenPrefix + raw
+
+	return &PersonalToken{
+		UserID:    userId,
+		Name:      name,
+		TokenHash: HashToken(raw),
+		Scopes:    strings.Join(scopes, ","),
+		ExpiresAt: expiresAt,
+	}, raw, nil
+}
+
+func (pt *PersonalToken) ScopeList() []string {
+
+	if pt.Scopes == "" {
+		return []string{}
+	}
+
+	return strings.Split(pt.Scopes, ",")
+}
+
+func (pt *PersonalToken) IsActive(now time.Time) bool {
+	return pt.RevokedAt == nil && (pt.ExpiresAt == nil || now.Before(*pt.ExpiresAt))
+}
@@ -0,0 +1,15 @@
+package entity
+
+import "gorm.io/gorm"
+
+type Project struct {
+	gorm.Model
+	Name     string
+	Color    string
+	Archived bool
+	UserID   uint
+	Todos    []Todo
+
+	// WorkspaceID is nil for projects in the personal space of their user
+	WorkspaceID *uint `gorm:"index"`
+}
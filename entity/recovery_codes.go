@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"strings"
+	"time"
+	"todo-app/pkg/errs"
+
+	"gorm.io/gorm"
+)
+
+// RecoveryCode replaces a TOTP code once when the user lost their
+// authenticator, only its hash is stored.
+type RecoveryCode struct {
+	gorm.Model
+	UserID   uint   `gorm:"index"`
+	CodeHash string `gorm:"index"`
+	UsedAt   *time.Time
+}
+
+const recoveryCodeCount = 10
+
+// NewRecoveryCodes returns a fresh set of recovery codes with their raw
+// values formatted as xxxx-xxxx-xxxx-xxxx.
+func NewRecoveryCodes(userId uint) ([]*RecoveryCode, []string, errs.Error) {
+
+	codes, raws := []*RecoveryCode{}, []string{}
+
+	for i := 0; i < recoveryCodeCount; i++ {
+		buf := make([]byte, 10)
+
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, errs.NewInternalServerError("something went wrong")
+		}
+
+		raw := strings.ToLower(base32.StdEncoding.EncodeToString(buf))
+		raw = raw[0:4] + "-" + raw[4:8] + "-" + raw[8:12] + "-" + raw[12:16]
+
+		codes = append(codes, &RecoveryCode{UserID: userId, CodeHash: HashRecoveryCode(raw)})
+		raws = append(raws, raw)
+	}
+
+	return codes, raws, nil
+}
+
+// HashRecoveryCode hashes a code ignoring its case, dashes and spaces.
+func HashRecoveryCode(code string) string {
+	code = strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(code))
+	return HashToken(code)
+}
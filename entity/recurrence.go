@@ -0,0 +1,261 @@
+package entity
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"todo-app/pkg/errs"
+)
+
+// Recurrence is the subset of RFC 5545 RRULE supported by todos: FREQ
+// (DAILY, WEEKLY or MONTHLY), INTERVAL, BYDAY for weekly rules, BYMONTHDAY
+// for monthly rules and either UNTIL or COUNT as end condition.
+type Recurrence struct {
+	Freq       string
+	Interval   int
+	ByDay      []time.Weekday
+	ByMonthDay []int
+	Until      *time.Time
+	UntilDate  bool
+	Count      int
+}
+
+var weekdayCodes = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+func ParseRecurrence(rule string) (*Recurrence, errs.Error) {
+
+	r := &Recurrence{Interval: 1}
+
+	rule = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(rule)), "RRULE:")
+
+	for _, part := range strings.Split(rule, ";") {
+		if part == "" {
+			continue
+		}
+
+		key, value, found := strings.Cut(part, "=")
+
+		if !found || value == "" {
+			return nil, errs.NewBadRequestError("invalid recurrence rule")
+		}
+
+		switch key {
+		case "FREQ":
+			if value != "DAILY" && value != "WEEKLY" && value != "MONTHLY" {
+				return nil, errs.NewBadRequestError("recurrence FREQ must be one of DAILY, WEEKLY or MONTHLY")
+			}
+			r.Freq = value
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+
+			if err != nil || interval < 1 {
+				return nil, errs.NewBadRequestError("recurrence INTERVAL must be a positive number")
+			}
+			r.Interval = interval
+		case "BYDAY":
+			for _, code := range strings.Split(value, ",") {
+				day := indexOf(weekdayCodes, code)
+
+				if day < 0 {
+					return nil, errs.NewBadRequestError("recurrence BYDAY must be a list of SU, MO, TU, WE, TH, FR or SA")
+				}
+				r.ByDay = append(r.ByDay, time.Weekday(day))
+			}
+		case "BYMONTHDAY":
+			for _, number := range strings.Split(value, ",") {
+				day, err := strconv.Atoi(number)
+
+				if err != nil || day == 0 || day < -31 || day > 31 {
+					return nil, errs.NewBadRequestError("recurrence BYMONTHDAY must be a list of days between 1 and 31 or -31 and -1")
+				}
+				r.ByMonthDay = append(r.ByMonthDay, day)
+			}
+		case "UNTIL":
+			until, err := time.Parse("20060102", value)
+			r.UntilDate = err == nil
+
+			if err != nil {
+				until, err = time.Parse("20060102T150405Z", value)
+			}
+
+			if err != nil {
+				return nil, errs.NewBadRequestError("recurrence UNTIL must be in 20060102 or 20060102T150405Z format")
+			}
+			r.Until = &until
+		case "COUNT":
+			count, err := strconv.Atoi(value)
+
+			if err != nil || count < 1 {
+				return nil, errs.NewBadRequestError("recurrence COUNT must be a positive number")
+			}
+			r.Count = count
+		default:
+			return nil, errs.NewBadRequestError("recurrence rule part " + key + " is not supported")
+		}
+	}
+
+	if r.Freq == "" {
+		return nil, errs.NewBadRequestError("recurrence FREQ can't be empty")
+	}
+
+	if len(r.ByDay) > 0 && r.Freq != "WEEKLY" {
+		return nil, errs.NewBadRequestError("recurrence BYDAY is only supported with FREQ=WEEKLY")
+	}
+
+	if len(r.ByMonthDay) > 0 && r.Freq != "MONTHLY" {
+		return nil, errs.NewBadRequestError("recurrence BYMONTHDAY is only supported with FREQ=MONTHLY")
+	}
+
+	if r.Until != nil && r.Count > 0 {
+		return nil, errs.NewBadRequestError("recurrence UNTIL and COUNT can't be combined")
+	}
+
+	return r, nil
+}
+
+// String returns the rule in its normalized RRULE form.
+func (r *Recurrence) String() string {
+
+	parts := []string{"FREQ=" + r.Freq}
+
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+
+	if len(r.ByDay) > 0 {
+		codes := []string{}
+
+		for _, day := range r.ByDay {
+			codes = append(codes, weekdayCodes[day])
+		}
+
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+
+	if len(r.ByMonthDay) > 0 {
+		days := []string{}
+
+		for _, day := range r.ByMonthDay {
+			days = append(days, strconv.Itoa(day))
+		}
+
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+
+	if r.Until != nil && r.UntilDate {
+		parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+	}
+
+	if r.Until != nil && !r.UntilDate {
+		parts = append(parts, "UNTIL="+r.Until.Format("20060102T150405Z"))
+	}
+
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// Next returns the first occurrence after from, keeping its wall clock time.
+// Weeks start on monday and monthly days past the end of a month fall on its
+// last day.
+func (r *Recurrence) Next(from time.Time) time.Time {
+
+	switch r.Freq {
+	case "WEEKLY":
+		if len(r.ByDay) == 0 {
+			return from.AddDate(0, 0, 7*r.Interval)
+		}
+
+		offsets := []int{}
+
+		for _, day := range r.ByDay {
+			offsets = append(offsets, mondayOffset(day))
+		}
+
+		sort.Ints(offsets)
+
+		current := mondayOffset(from.Weekday())
+
+		for _, offset := range offsets {
+			if offset > current {
+				return from.AddDate(0, 0, offset-current)
+			}
+		}
+
+		return from.AddDate(0, 0, 7*r.Interval-current+offsets[0])
+	case "MONTHLY":
+		days := r.ByMonthDay
+
+		if len(days) == 0 {
+			days = []int{from.Day()}
+		}
+
+		for _, day := range monthDays(from, 0, days) {
+			if day > from.Day() {
+				return time.Date(from.Year(), from.Month(), day, from.Hour(), from.Minute(), from.Second(), 0, from.Location())
+			}
+		}
+
+		day := monthDays(from, r.Interval, days)[0]
+
+		return time.Date(from.Year(), from.Month()+time.Month(r.Interval), day, from.Hour(), from.Minute(), from.Second(), 0, from.Location())
+	}
+
+	return from.AddDate(0, 0, r.Interval)
+}
+
+// Ended reports whether an occurrence falls after the UNTIL end condition.
+func (r *Recurrence) Ended(occurrence time.Time) bool {
+
+	if r.Until == nil {
+		return false
+	}
+
+	if r.UntilDate {
+		date := time.Date(occurrence.Year(), occurrence.Month(), occurrence.Day(), 0, 0, 0, 0, time.UTC)
+		return date.After(*r.Until)
+	}
+
+	return occurrence.After(*r.Until)
+}
+
+func mondayOffset(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
+
+// monthDays resolves the days of the month that is months after from,
+// sorted and clamped to the length of that month.
+func monthDays(from time.Time, months int, days []int) []int {
+
+	length := time.Date(from.Year(), from.Month()+time.Month(months)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+
+	resolved := []int{}
+
+	for _, day := range days {
+		if day < 0 {
+			day = length + day + 1
+		}
+
+		day = max(1, min(day, length))
+
+		resolved = append(resolved, day)
+	}
+
+	sort.Ints(resolved)
+
+	return resolved
+}
+
+func indexOf(values []string, value string) int {
+
+	for i, eachValue := range values {
+		if eachValue == value {
+			return i
+		}
+	}
+
+	return -1
+}
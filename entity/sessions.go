@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Session is a login of a user on a device. Its Jti is embedded in every
+// access token issued for the login and is the family of its refresh tokens,
+// so revoking the session logs the device out.
+type Session struct {
+	gorm.Model
+	UserID     uint   `gorm:"index"`
+	Jti        string `gorm:"uniqueIndex"`
+	DeviceName string
+	IP         string
+	UserAgent  string
+	LastSeenAt time.Time
+	RevokedAt  *time.Time
+}
+
+func (s *Session) IsRevoked() bool {
+	return s.RevokedAt != nil
+}
@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	ShareViewer = "viewer"
+	ShareEditor = "editor"
+	ShareOwner  = "owner"
+)
+
+var ShareRoles = []string{ShareViewer, ShareEditor, ShareOwner}
+
+// shareRanks orders the roles, a role is allowed everything the roles below
+// it are allowed.
+var shareRanks = map[string]int{
+	ShareViewer: 1,
+	ShareEditor: 2,
+	ShareOwner:  3,
+}
+
+// Share gives a user access to a todo, or to every todo of a project, owned
+// by someone else. It stays an invitation until the user accepts it.
+type Share struct {
+	gorm.Model
+	TodoID     *uint `gorm:"uniqueIndex:idx_shares_todo_user"`
+	ProjectID  *uint `gorm:"uniqueIndex:idx_shares_project_user"`
+	UserID     uint  `gorm:"index;uniqueIndex:idx_shares_todo_user;uniqueIndex:idx_shares_project_user"`
+	InvitedBy  uint
+	Role       string
+	AcceptedAt *time.Time
+	User       User `gorm:"foreignKey:UserID"`
+}
+
+func IsShareRole(role string) bool {
+	_, ok := shareRanks[role]
+	return ok
+}
+
+// RoleAllows reports whether role grants at least the required role, an
+// empty role grants nothing.
+func RoleAllows(role string, required string) bool {
+	return role != "" && shareRanks[role] >= shareRanks[required]
+}
+
+// HigherRole returns the role granting more of a and b.
+func HigherRole(a string, b string) string {
+
+	if shareRanks[b] > shareRanks[a] {
+		return b
+	}
+
+	return a
+}
+
+func (s *Share) IsAccepted() bool {
+	return s.AcceptedAt != nil
+}
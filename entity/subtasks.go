@@ -0,0 +1,11 @@
+package entity
+
+import "gorm.io/gorm"
+
+type Subtask struct {
+	gorm.Model
+	Title    string
+	Done     bool
+	Position int  `gorm:"not null;default:0"`
+	TodoID   uint `gorm:"index"`
+}
@@ -0,0 +1,10 @@
+package entity
+
+import "gorm.io/gorm"
+
+type Tag struct {
+	gorm.Model
+	Name   string `gorm:"uniqueIndex:idx_tags_user_name,where:deleted_at IS NULL"`
+	Color  string
+	UserID uint `gorm:"uniqueIndex:idx_tags_user_name,where:deleted_at IS NULL"`
+}
@@ -1,10 +1,260 @@
 package entity
 
-import "gorm.io/gorm"
+import (
+	"time"
+	"todo-app/pkg/errs"
+
+	"gorm.io/gorm"
+)
+
+type Priority int
+
+const (
+	PriorityNone Priority = iota
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+	PriorityUrgent
+)
+
+var priorityNames = []string{"none", "low", "medium", "high", "urgent"}
+
+func (p Priority) String() string {
+
+	if p < PriorityNone || p > PriorityUrgent {
+		return priorityNames[PriorityNone]
+	}
+
+	return priorityNames[p]
+}
+
+func ParsePriority(name string) (Priority, errs.Error) {
+
+	for i, eachName := range priorityNames {
+		if eachName == name {
+			return Priority(i), nil
+		}
+	}
+
+	return PriorityNone, errs.NewBadRequestError("priority must be one of none, low, medium, high or urgent")
+}
 
 type Todo struct {
 	gorm.Model
-	Todos  string
-	Status bool
-	UserID uint
+	Todos     string
+	Status    bool
+	DueAt     *time.Time `gorm:"index"`
+	DueAllDay bool
+	TimeZone  string
+	Priority  Priority `gorm:"not null;default:0"`
+	Position  int      `gorm:"not null;default:0"`
+	UserID    uint
+	ProjectID *uint `gorm:"index"`
+	Tags      []Tag `gorm:"many2many:todo_tags;"`
+	Subtasks  []Subtask
+
+	// WorkspaceID is nil for todos in the personal space of their user
+	WorkspaceID *uint `gorm:"index"`
+
+	// AssigneeID is the user the todo is assigned to, who can change its
+	// status even without the right to edit it
+	AssigneeID *uint `gorm:"index"`
+
+	// CommentCount is read along with the todo, it isn't a column
+	CommentCount int `gorm:"->;-:migration"`
+
+	Recurrence           string
+	RepeatFromCompletion bool
+	Occurrence           int `gorm:"not null;default:1"`
+
+	// SearchLanguage is copied from the owner and feeds the search_vector
+	// column generated by the database
+	SearchLanguage string `gorm:"type:regconfig;not null;default:'simple'"`
+}
+
+// SetDue stores the due date of the todo as an UTC instant. date must be in
+// 2006-01-02 format, clock is optional in 15:04 format and both are read in
+// the given timezone (UTC when empty).
+func (t *Todo) SetDue(date string, clock string, timeZone string) errs.Error {
+
+	if timeZone == "" {
+		timeZone = "UTC"
+	}
+
+	loc, err := time.LoadLocation(timeZone)
+
+	if err != nil {
+		return errs.NewBadRequestError("invalid timezone")
+	}
+
+	layout, value := "2006-01-02", date
+
+	if clock != "" {
+		layout, value = "2006-01-02 15:04", date+" "+clock
+	}
+
+	dueAt, err := time.ParseInLocation(layout, value, loc)
+
+	if err != nil {
+		return errs.NewBadRequestError("invalid due date or due time")
+	}
+
+	dueAt = dueAt.UTC()
+
+	t.DueAt = &dueAt
+	t.DueAllDay = clock == ""
+	t.TimeZone = loc.String()
+
+	return nil
+}
+
+// DueEnd returns the instant the todo becomes overdue. All day todos are due
+// until the end of their day in the todo timezone.
+func (t *Todo) DueEnd() *time.Time {
+
+	if t.DueAt == nil {
+		return nil
+	}
+
+	if !t.DueAllDay {
+		return t.DueAt
+	}
+
+	loc, err := time.LoadLocation(t.TimeZone)
+
+	if err != nil {
+		loc = time.UTC
+	}
+
+	end := t.DueAt.In(loc).AddDate(0, 0, 1).UTC()
+
+	return &end
+}
+
+func (t *Todo) IsOverdue(now time.Time) bool {
+
+	end := t.DueEnd()
+
+	if t.Status || end == nil {
+		return false
+	}
+
+	return !now.Before(*end)
+}
+
+// SetRecurrence validates and stores the RRULE of the todo, an empty rule
+// stops the todo from repeating. fromCompletion repeats the todo an interval
+// after it is completed instead of following its due date. The due date has
+// to be set first, monthly rules without BYMONTHDAY keep its day.
+func (t *Todo) SetRecurrence(rule string, fromCompletion bool) errs.Error {
+
+	if rule == "" {
+		t.Recurrence, t.RepeatFromCompletion = "", false
+		return nil
+	}
+
+	r, err := ParseRecurrence(rule)
+
+	if err != nil {
+		return err
+	}
+
+	if fromCompletion && (len(r.ByDay) > 0 || len(r.ByMonthDay) > 0) {
+		return errs.NewBadRequestError("repeat from completion can't be combined with BYDAY or BYMONTHDAY")
+	}
+
+	// monthly occurrences are anchored on the day of the due date, which
+	// would otherwise drift to the 28th after the first short month
+	if r.Freq == "MONTHLY" && len(r.ByMonthDay) == 0 && !fromCompletion && t.DueAt != nil {
+		loc, locErr := time.LoadLocation(t.TimeZone)
+
+		if locErr != nil {
+			loc = time.UTC
+		}
+
+		r.ByMonthDay = []int{t.DueAt.In(loc).Day()}
+	}
+
+	t.Recurrence, t.RepeatFromCompletion = r.String(), fromCompletion
+
+	return nil
+}
+
+// NextDue returns the due date of the occurrence after the todo, or nil when
+// the todo doesn't repeat or its recurrence has ended.
+func (t *Todo) NextDue(completedAt time.Time) *time.Time {
+
+	if t.Recurrence == "" || t.DueAt == nil {
+		return nil
+	}
+
+	r, err := ParseRecurrence(t.Recurrence)
+
+	if err != nil {
+		return nil
+	}
+
+	if r.Count > 0 && t.Occurrence >= r.Count {
+		return nil
+	}
+
+	loc, locErr := time.LoadLocation(t.TimeZone)
+
+	if locErr != nil {
+		loc = time.UTC
+	}
+
+	from := t.DueAt.In(loc)
+
+	if t.RepeatFromCompletion {
+		completed := completedAt.In(loc)
+		from = time.Date(completed.Year(), completed.Month(), completed.Day(), from.Hour(), from.Minute(), from.Second(), 0, loc)
+	}
+
+	next := r.Next(from)
+
+	if r.Ended(next) {
+		return nil
+	}
+
+	next = next.UTC()
+
+	return &next
+}
+
+// NextOccurrence copies a completed recurring todo into its next occurrence
+// with fresh subtasks, it returns nil when the recurrence has ended.
+func (t *Todo) NextOccurrence(completedAt time.Time) *Todo {
+
+	nextDue := t.NextDue(completedAt)
+
+	if nextDue == nil {
+		return nil
+	}
+
+	next := &Todo{
+		Todos:                t.Todos,
+		DueAt:                nextDue,
+		DueAllDay:            t.DueAllDay,
+		TimeZone:             t.TimeZone,
+		Priority:             t.Priority,
+		UserID:               t.UserID,
+		ProjectID:            t.ProjectID,
+		WorkspaceID:          t.WorkspaceID,
+		AssigneeID:           t.AssigneeID,
+		Tags:                 t.Tags,
+		Recurrence:           t.Recurrence,
+		RepeatFromCompletion: t.RepeatFromCompletion,
+		Occurrence:           t.Occurrence + 1,
+		SearchLanguage:       t.SearchLanguage,
+	}
+
+	for _, eachSubtask := range t.Subtasks {
+		next.Subtasks = append(next.Subtasks, Subtask{
+			Title:    eachSubtask.Title,
+			Position: eachSubtask.Position,
+		})
+	}
+
+	return next
 }
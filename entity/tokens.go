@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+	"todo-app/infra/config"
+	"todo-app/pkg/errs"
+
+	"gorm.io/gorm"
+)
+
+// RefreshToken is a single use token exchanged for a new access token. Each
+// exchange rotates it into a new token of the same family, so a token used
+// twice means it has leaked and the whole family gets revoked. The family is
+// the jti of the session the token was issued for.
+type RefreshToken struct {
+	gorm.Model
+	UserID    uint   `gorm:"index"`
+	FamilyID  string `gorm:"index"`
+	TokenHash string `gorm:"uniqueIndex"`
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	RevokedAt *time.Time
+}
+
+// NewRefreshToken issues a refresh token of the family, only the hash of the
+// returned raw token is stored.
+func NewRefreshToken(userId uint, familyId string) (*RefreshToken, string, errs.Error) {
+
+	raw, err := RandomToken(32)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	ttl := time.Duration(config.AppConfig().RefreshTokenTTLDays) * 24 * time.Hour
+
+	return &RefreshToken{
+		UserID:    userId,
+		FamilyID:  familyId,
+		TokenHash: HashToken(raw),
+		ExpiresAt: time.Now().Add(ttl),
+	}, raw, nil
+}
+
+func (rt *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(rt.ExpiresAt)
+}
+
+// RandomToken returns size random bytes encoded as url safe base64.
+func RandomToken(size int) (string, errs.Error) {
+
+	buf := make([]byte, size)
+
+	if _, err := rand.Read(buf); err != nil {
+		return "", errs.NewInternalServerError("something went wrong")
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -2,31 +2,124 @@ package entity
 
 import (
 	"strings"
+	"time"
 	"todo-app/infra/config"
 	"todo-app/pkg/errs"
+	"todo-app/pkg/keyring"
+	"todo-app/pkg/password"
 
+	"github.com/gofiber/fiber/v2/log"
 	"github.com/golang-jwt/jwt/v5"
-	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
 type User struct {
 	gorm.Model
-	Name     string
-	Email    string `gorm:"unique"`
-	Password string
-	Todos    []Todo
+	Name           string
+	Email          string `gorm:"unique"`
+	Password       string
+	SearchLanguage string `gorm:"not null;default:'simple'"`
+	Todos          []Todo
+
+	// EmailVerifiedAt is set once the user confirms their email, a changed
+	// email is kept in PendingEmail until it is confirmed
+	EmailVerifiedAt *time.Time
+	PendingEmail    string
+
+	// TOTPSecret is stored on enrolment and only used for logins once the
+	// user confirms it, TOTPLastStep keeps a code from being used twice
+	TOTPSecret    string
+	TOTPEnabledAt *time.Time
+	TOTPLastStep  int64
+
+	// SessionID is the jti of the access token the user authenticated with
+	SessionID string `gorm:"-"`
+
+	// Scopes are the scopes of the personal token the user authenticated
+	// with, nil when they logged in and may do anything
+	Scopes []string `gorm:"-"`
+
+	// WorkspaceID is the workspace selected for the request, 0 for the
+	// personal space
+	WorkspaceID uint `gorm:"-"`
 }
 
-func (u *User) parseToken(tokenString string) (*jwt.Token, errs.Error) {
+// SearchLanguages are the text search configurations shipped with Postgres,
+// simple doesn't stem words so it works with any language.
+var SearchLanguages = []string{
+	"simple", "arabic", "armenian", "basque", "catalan", "danish", "dutch", "english",
+	"finnish", "french", "german", "greek", "hindi", "hungarian", "indonesian", "irish",
+	"italian", "lithuanian", "nepali", "norwegian", "portuguese", "romanian", "russian",
+	"serbian", "spanish", "swedish", "tamil", "turkish", "yiddish",
+}
+
+func IsSearchLanguage(name string) bool {
+
+	for _, eachLanguage := range SearchLanguages {
+		if eachLanguage == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (u *User) IsVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+func (u *User) HasTOTP() bool {
+	return u.TOTPEnabledAt != nil
+}
+
+func (u *User) HasScope(scope string) bool {
+
+	if u.Scopes == nil {
+		return true
+	}
+
+	for _, eachScope := range u.Scopes {
+		if eachScope == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (u *User) parseToken(tokenString string, audience string) (*jwt.Token, errs.Error) {
+
+	secretKey := config.AppConfig().JwtSecretKey
+	methods := []string{}
+
+	if signingKeys != nil {
+		methods = append(methods, signingKeys.Methods()...)
+	}
+
+	// tokens signed with the secret key are still accepted after switching
+	// to a keyring until the secret is removed, so nobody is logged out
+	if signingKeys == nil || secretKey != "" {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+
 	token, err := jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
 
+		if _, ok := t.Header["kid"]; ok && signingKeys != nil {
+			return signingKeys.Keyfunc(t)
+		}
+
 		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errs.NewUnauthenticatedError("invalid token")
 		}
 
-		return []byte(config.AppConfig().JwtSecretKey), nil
-	})
+		return []byte(secretKey), nil
+	},
+		jwt.WithValidMethods(methods),
+		jwt.WithIssuer(config.AppConfig().JwtIssuer),
+		jwt.WithAudience(audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
 
 	if err != nil {
 		return nil, errs.NewUnauthenticatedError("invalid token")
@@ -49,6 +142,12 @@ func (u *User) bindTokenToEntity(mapClaims jwt.MapClaims) errs.Error {
 		u.Email = email
 	}
 
+	if jti, ok := mapClaims["jti"].(string); !ok || jti == "" {
+		return errs.NewUnauthenticatedError("invalid token")
+	} else {
+		u.SessionID = jti
+	}
+
 	return nil
 }
 
@@ -67,7 +166,7 @@ func (u *User) ValidateToken(bearerToken string) errs.Error {
 	}
 
 	tokenString := splitToken[1]
-	token, err := u.parseToken(tokenString)
+	token, err := u.parseToken(tokenString, config.AppConfig().JwtAudience)
 
 	if err != nil {
 		return err
@@ -88,36 +187,138 @@ func (u *User) ValidateToken(bearerToken string) errs.Error {
 }
 
 func (u *User) claim() jwt.MapClaims {
+
+	now := time.Now()
+
 	return jwt.MapClaims{
 		"id":    u.ID,
 		"email": u.Email,
+		"jti":   u.SessionID,
+		"iss":   config.AppConfig().JwtIssuer,
+		"aud":   config.AppConfig().JwtAudience,
+		"iat":   now.Unix(),
+		"nbf":   now.Unix(),
+		"exp":   now.Add(AccessTokenTTL()).Unix(),
 	}
 }
 
+// signingKeys signs tokens once asymmetric keys are configured, until then
+// they are signed with the secret key.
+var signingKeys *keyring.Keyring
+
+// UseKeyring signs every token issued from now on with the active key of kr,
+// it is called once on startup.
+func UseKeyring(kr *keyring.Keyring) {
+	signingKeys = kr
+}
+
+// signToken returns an empty token when signing fails, which is logged since
+// it means the signing key is unusable.
 func (u *User) signToken(claim jwt.MapClaims) string {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claim)
-	tokenString, _ := token.SignedString([]byte(config.AppConfig().JwtSecretKey))
+
+	var tokenString string
+	var err error
+
+	if signingKeys != nil {
+		tokenString, err = signingKeys.Sign(claim)
+	} else {
+		tokenString, err = jwt.NewWithClaims(jwt.SigningMethodHS256, claim).SignedString([]byte(config.AppConfig().JwtSecretKey))
+	}
+
+	if err != nil {
+		log.Errorf("error while signing token: %s", err.Error())
+	}
+
 	return tokenString
 }
 
+// AccessTokenTTL is how long an access token is valid after it is issued.
+func AccessTokenTTL() time.Duration {
+	return time.Duration(config.AppConfig().AccessTokenTTLMinutes) * time.Minute
+}
+
 func (u *User) GenerateToken() string {
 	return u.signToken(u.claim())
 }
 
+// mfaAudience keeps MFA challenge tokens from being accepted as access tokens.
+func mfaAudience() string {
+	return config.AppConfig().JwtAudience + ":mfa"
+}
+
+// MFATokenTTL is how long a user has to enter their second factor.
+const MFATokenTTL = 5 * time.Minute
+
+// GenerateMFAToken returns the challenge token a user with 2FA gets after
+// entering their password, it is exchanged for an access token with a code.
+func (u *User) GenerateMFAToken() string {
+
+	now := time.Now()
+
+	return u.signToken(jwt.MapClaims{
+		"id":  u.ID,
+		"iss": config.AppConfig().JwtIssuer,
+		"aud": mfaAudience(),
+		"iat": now.Unix(),
+		"nbf": now.Unix(),
+		"exp": now.Add(MFATokenTTL).Unix(),
+	})
+}
+
+func (u *User) ValidateMFAToken(tokenString string) errs.Error {
+
+	token, err := u.parseToken(tokenString, mfaAudience())
+
+	if err != nil {
+		return err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+
+	if !ok || !token.Valid {
+		return errs.NewUnauthenticatedError("invalid token")
+	}
+
+	id, ok := claims["id"].(float64)
+
+	if !ok {
+		return errs.NewUnauthenticatedError("invalid token")
+	}
+
+	u.ID = uint(id)
+
+	return nil
+}
+
+// passwordHasher hashes new passwords, hashes of the other algorithm still
+// verify and are upgraded on the next login.
+var passwordHasher = password.NewArgon2idHasher(password.DefaultArgon2Params)
+
+// UsePasswordHasher hashes every password from now on with h, it is called
+// once on startup with the configured hasher.
+func UsePasswordHasher(h password.Hasher) {
+	passwordHasher = h
+}
+
 func (u *User) HashPassword() error {
 
-	hashPassword, err := bcrypt.GenerateFromPassword([]byte(u.Password), bcrypt.DefaultCost)
+	hashPassword, err := passwordHasher.Hash(u.Password)
 
 	if err != nil {
 		return err
 	}
 
-	u.Password = string(hashPassword)
+	u.Password = hashPassword
 
 	return nil
 }
 
 func (u *User) CompareHashPassword(password string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password))
-	return err == nil
+	return passwordHasher.Verify(password, u.Password)
+}
+
+// PasswordNeedsRehash reports a password hash made with an algorithm or
+// parameters other than the configured ones.
+func (u *User) PasswordNeedsRehash() bool {
+	return passwordHasher.NeedsRehash(u.Password)
 }
@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	WorkspaceRoleOwner  = "owner"
+	WorkspaceRoleAdmin  = "admin"
+	WorkspaceRoleMember = "member"
+	WorkspaceRoleGuest  = "guest"
+)
+
+// workspaceRanks orders the roles, a role is allowed everything the roles
+// below it are allowed.
+var workspaceRanks = map[string]int{
+	WorkspaceRoleGuest:  1,
+	WorkspaceRoleMember: 2,
+	WorkspaceRoleAdmin:  3,
+	WorkspaceRoleOwner:  4,
+}
+
+// WorkspaceHeader selects the workspace of a request, requests without it
+// use the personal space of the user.
+const WorkspaceHeader = "X-Workspace-ID"
+
+// Workspace groups the todos and projects of a team, only its members can
+// see them.
+type Workspace struct {
+	gorm.Model
+	Name    string
+	OwnerID uint `gorm:"index"`
+}
+
+// WorkspaceMember is the membership of a user in a workspace. Invited users
+// only become members once they accept the invitation.
+type WorkspaceMember struct {
+	gorm.Model
+	WorkspaceID uint `gorm:"uniqueIndex:idx_workspace_members_user"`
+	UserID      uint `gorm:"index;uniqueIndex:idx_workspace_members_user"`
+	Role        string
+	InvitedBy   uint
+	AcceptedAt  *time.Time
+	Workspace   Workspace `gorm:"foreignKey:WorkspaceID"`
+	User        User      `gorm:"foreignKey:UserID"`
+}
+
+func IsWorkspaceRole(role string) bool {
+	_, ok := workspaceRanks[role]
+	return ok
+}
+
+// WorkspaceRoleAllows reports whether role grants at least the required
+// role, an empty role grants nothing.
+func WorkspaceRoleAllows(role string, required string) bool {
+	return role != "" && workspaceRanks[role] >= workspaceRanks[required]
+}
+
+func (wm *WorkspaceMember) IsAccepted() bool {
+	return wm.AcceptedAt != nil
+}
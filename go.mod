@@ -2,7 +2,11 @@ module todo-app
 
 go 1.21.5
 
-require github.com/gofiber/fiber/v2 v2.52.0
+require (
+	github.com/gofiber/fiber/v2 v2.52.0
+	github.com/gofiber/swagger v0.1.14
+	github.com/swaggo/swag v1.16.2
+)
 
 require (
 	github.com/KyleBanks/depth v1.2.1 // indirect
@@ -18,7 +22,6 @@ require (
 	github.com/go-openapi/swag v0.22.7 // indirect
 	github.com/go-openapi/validate v0.22.6 // indirect
 	github.com/gofiber/contrib/swagger v1.1.1 // indirect
-	github.com/gofiber/swagger v0.1.14 // indirect
 	github.com/josharian/intern v1.0.0 // indirect
 	github.com/kr/text v0.2.0 // indirect
 	github.com/mailru/easyjson v0.7.7 // indirect
@@ -27,7 +30,6 @@ require (
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/rogpeppe/go-internal v1.12.0 // indirect
 	github.com/swaggo/files/v2 v2.0.0 // indirect
-	github.com/swaggo/swag v1.16.2 // indirect
 	go.mongodb.org/mongo-driver v1.13.1 // indirect
 	golang.org/x/tools v0.16.1 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
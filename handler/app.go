@@ -2,16 +2,48 @@ package handler
 
 import (
 	"fmt"
+	"time"
 
+	"todo-app/entity"
+	"todo-app/handler/attachments_handler"
+	"todo-app/handler/comments_handler"
+	"todo-app/handler/jwks_handler"
+	"todo-app/handler/projects_handler"
+	"todo-app/handler/shares_handler"
+	"todo-app/handler/subtasks_handler"
+	"todo-app/handler/tags_handler"
 	"todo-app/handler/todos_handler"
 	"todo-app/handler/users_handler"
+	"todo-app/handler/workspaces_handler"
 	"todo-app/infra/config"
 	"todo-app/infra/db"
+	"todo-app/repo/attachments_repo/attachments_pg"
+	"todo-app/repo/attempts_repo/attempts_memory"
+	"todo-app/repo/attempts_repo/attempts_pg"
+	"todo-app/repo/comments_repo/comments_pg"
+	"todo-app/repo/mfa_repo/mfa_pg"
+	"todo-app/repo/personal_tokens_repo/personal_tokens_pg"
+	"todo-app/repo/projects_repo/projects_pg"
+	"todo-app/repo/resets_repo/resets_pg"
+	"todo-app/repo/sessions_repo/sessions_pg"
+	"todo-app/repo/shares_repo/shares_pg"
+	"todo-app/repo/subtasks_repo/subtasks_pg"
+	"todo-app/repo/tags_repo/tags_pg"
 	"todo-app/repo/todos_repo/todos_pg"
+	"todo-app/repo/tokens_repo/tokens_pg"
 	"todo-app/repo/users_repo/users_pg"
+	"todo-app/repo/verifications_repo/verifications_pg"
+	"todo-app/repo/workspaces_repo/workspaces_pg"
+	"todo-app/service/attachments_service"
 	"todo-app/service/auth_service"
+	"todo-app/service/comments_service"
+	"todo-app/service/projects_service"
+	"todo-app/service/shares_service"
+	"todo-app/service/subtasks_service"
+	"todo-app/service/tags_service"
 	"todo-app/service/todos_service"
 	"todo-app/service/users_service"
+	"todo-app/service/workspaces_service"
 
 	"github.com/gofiber/swagger"
 	"github.com/gofiber/fiber/v2"
@@ -33,18 +65,78 @@ func StartApp() {
 
 	config.LoadEnv()
 	db := db.DbConn()
+	kr := newKeyring()
+	usePasswordHasher()
 
 	userRepo := users_pg.NewUsersRepo(db)
-	userService := users_service.NewUserService(userRepo)
+	tokenRepo := tokens_pg.NewTokensRepo(db)
+	sessionRepo := sessions_pg.NewSessionsRepo(db, time.Duration(config.AppConfig().SessionCacheSeconds)*time.Second)
+	resetRepo := resets_pg.NewResetsRepo(db)
+	verificationRepo := verifications_pg.NewVerificationsRepo(db)
+	attemptRepo := attempts_memory.NewAttemptsRepo()
+
+	if config.AppConfig().LoginAttemptsStore == "postgres" {
+		attemptRepo = attempts_pg.NewAttemptsRepo(db)
+	}
+
+	mfaRepo := mfa_pg.NewMFARepo(db)
+	personalTokenRepo := personal_tokens_pg.NewPersonalTokensRepo(db, time.Duration(config.AppConfig().SessionCacheSeconds)*time.Second)
+
+	m := newMailer()
+	blobs := newStorage()
+	userService := users_service.NewUserService(userRepo, tokenRepo, sessionRepo, resetRepo, verificationRepo, attemptRepo, mfaRepo, personalTokenRepo, m, newPasswordPolicy(), notifyLockout(m))
 	userHandler := users_handler.NewUserHandler(userService)
+	jwksHandler := jwks_handler.NewJWKSHandler(kr)
+
+	tagRepo := tags_pg.NewTagRepo(db)
+	tagService := tags_service.NewTagService(tagRepo)
+	tagHandler := tags_handler.NewTagHandler(tagService)
+
+	projectRepo := projects_pg.NewProjectRepo(db)
+	shareRepo := shares_pg.NewSharesRepo(db)
+	workspaceRepo := workspaces_pg.NewWorkspacesRepo(db)
 
 	todoRepo := todos_pg.NewTodoRepo(db)
-	todoService := todos_service.NewTodoService(todoRepo)
+	todoService := todos_service.NewTodoService(todoRepo, tagRepo, projectRepo, shareRepo, userRepo, blobs, notifyAssignment(m))
 	todoHandler := todos_handler.NewTodoHandler(todoService)
 
-	authService := auth_service.NewAuthService(userRepo, todoRepo)
+	subtaskRepo := subtasks_pg.NewSubtaskRepo(db)
+	subtaskService := subtasks_service.NewSubtaskService(subtaskRepo)
+	subtaskHandler := subtasks_handler.NewSubtaskHandler(subtaskService)
+
+	projectService := projects_service.NewProjectService(projectRepo, todoRepo)
+	projectHandler := projects_handler.NewProjectHandler(projectService)
+
+	shareService := shares_service.NewShareService(shareRepo, userRepo, todoRepo, projectRepo, m)
+	shareHandler := shares_handler.NewShareHandler(shareService)
+
+	commentRepo := comments_pg.NewCommentsRepo(db)
+	commentService := comments_service.NewCommentService(commentRepo, todoRepo, userRepo, shareRepo, notifyMentions(m))
+	commentHandler := comments_handler.NewCommentHandler(commentService)
+
+	attachmentRepo := attachments_pg.NewAttachmentsRepo(db)
+	attachmentService := attachments_service.NewAttachmentService(attachmentRepo, todoRepo, blobs, attachmentSecret())
+	attachmentHandler := attachments_handler.NewAttachmentHandler(attachmentService)
+
+	workspaceService := workspaces_service.NewWorkspaceService(workspaceRepo, userRepo, m)
+	workspaceHandler := workspaces_handler.NewWorkspaceHandler(workspaceService)
+
+	authService := auth_service.NewAuthService(userRepo, sessionRepo, personalTokenRepo, todoRepo, tagRepo, projectRepo, shareRepo, workspaceRepo)
+
+	// TRASH_RETENTION_DAYS=0 keeps trashed todos until they are purged by hand
+	if retentionDays := config.AppConfig().TrashRetentionDays; retentionDays > 0 {
+		go purgeTrash(todoService, retentionDays)
+	}
 
-	app := fiber.New()
+	// uploads are checked against ATTACHMENT_MAX_BYTES by the attachment
+	// service, the body limit only leaves room for the multipart envelope
+	bodyLimit := config.AppConfig().AttachmentMaxBytes + 1<<20
+
+	if bodyLimit < fiber.DefaultBodyLimit {
+		bodyLimit = fiber.DefaultBodyLimit
+	}
+
+	app := fiber.New(fiber.Config{BodyLimit: bodyLimit})
 
 	app.Use(logger.New())
 	app.Use(cors.New(cors.Config{
@@ -57,23 +149,117 @@ func StartApp() {
 			fiber.MethodDelete,
 			fiber.MethodOptions,
 		),
-		AllowHeaders: "Content-Type, Authorization",
+		AllowHeaders: "Content-Type, Authorization, " + entity.WorkspaceHeader,
 	}))
 
 	// swagger
 	app.Get("/swagger/*", swagger.HandlerDefault)
 
+	// keys
+	app.Get("/.well-known/jwks.json", jwksHandler.Keys)
+
+	// routes are only reachable with a personal token when they name the
+	// scope it needs with authService.Scope before authentication
+
 	// users
 	app.Post("/api/v1/users/register", userHandler.Register)
 	app.Post("/api/v1/users/login", userHandler.Login)
-	app.Get("/api/v1/users/profile", authService.Authentication(), userHandler.Profile)
+	app.Post("/api/v1/users/login/mfa", userHandler.LoginMFA)
+	app.Post("/api/v1/users/refresh", userHandler.Refresh)
+	app.Post("/api/v1/users/logout", authService.Authentication(), userHandler.Logout)
+	app.Get("/api/v1/users/sessions", authService.Authentication(), userHandler.Sessions)
+	app.Delete("/api/v1/users/sessions", authService.Authentication(), userHandler.RevokeSessions)
+	app.Delete("/api/v1/users/sessions/:sessionId", authService.Authentication(), userHandler.RevokeSession)
+	app.Get("/api/v1/users/profile", authService.Scope(entity.ScopeProfileRead), authService.Authentication(), userHandler.Profile)
 	app.Patch("/api/v1/users/modify", authService.Authentication(), userHandler.Modify)
+	app.Patch("/api/v1/users/password", authService.Authentication(), userHandler.ChangePassword)
+	app.Post("/api/v1/users/password/forgot", userHandler.ForgotPassword)
+	app.Post("/api/v1/users/password/reset", userHandler.ResetPassword)
+	app.Post("/api/v1/users/email/verify", userHandler.VerifyEmail)
+	app.Post("/api/v1/users/email/resend", userHandler.ResendVerification)
+	app.Post("/api/v1/users/2fa/enroll", authService.Authentication(), userHandler.EnrollTOTP)
+	app.Post("/api/v1/users/2fa/confirm", authService.Authentication(), userHandler.ConfirmTOTP)
+	app.Post("/api/v1/users/2fa/disable", authService.Authentication(), userHandler.DisableTOTP)
+	app.Post("/api/v1/users/tokens", authService.Authentication(), userHandler.AddPersonalToken)
+	app.Get("/api/v1/users/tokens", authService.Authentication(), userHandler.PersonalTokens)
+	app.Delete("/api/v1/users/tokens/:tokenId", authService.Authentication(), userHandler.RevokePersonalToken)
+
+	app.Post("/api/v1/todos", authService.Scope(entity.ScopeTodosWrite), authService.Authentication(), authService.Workspace(), todoHandler.Add)
+	app.Get("/api/v1/todos", authService.Scope(entity.ScopeTodosRead), authService.Authentication(), authService.Workspace(), todoHandler.Fetch)
+	app.Patch("/api/v1/todos/reorder", authService.Scope(entity.ScopeTodosWrite), authService.Authentication(), todoHandler.Reorder)
+	app.Post("/api/v1/todos/bulk", authService.Scope(entity.ScopeTodosWrite), authService.Authentication(), todoHandler.Bulk)
+	app.Get("/api/v1/todos/search", authService.Scope(entity.ScopeTodosRead), authService.Authentication(), authService.Workspace(), todoHandler.Search)
+	app.Get("/api/v1/todos/trash", authService.Scope(entity.ScopeTodosRead), authService.Authentication(), authService.Workspace(), todoHandler.Trash)
+	app.Post("/api/v1/todos/:todoId/restore", authService.Scope(entity.ScopeTodosWrite), authService.Authentication(), authService.TrashAuthorization(), todoHandler.Restore)
+	app.Delete("/api/v1/todos/:todoId/purge", authService.Scope(entity.ScopeTodosWrite), authService.Authentication(), authService.TrashAuthorization(), todoHandler.Purge)
+	app.Delete("/api/v1/todos/:todoId", authService.Scope(entity.ScopeTodosWrite), authService.Authentication(), authService.Role(entity.ShareOwner), authService.Authorization(), todoHandler.Delete)
+	app.Get("/api/v1/todos/:todoId", authService.Scope(entity.ScopeTodosRead), authService.Authentication(), authService.Authorization(), todoHandler.Detail)
+	app.Patch("/api/v1/todos/:todoId", authService.Scope(entity.ScopeTodosWrite), authService.Authentication(), authService.Authorization(), todoHandler.Modify)
+	app.Patch("/api/v1/todos/:todoId/skip", authService.Scope(entity.ScopeTodosWrite), authService.Authentication(), authService.Authorization(), todoHandler.Skip)
+	app.Patch("/api/v1/todos/:todoId/status", authService.Scope(entity.ScopeTodosWrite), authService.Authentication(), authService.Assignee(), authService.Authorization(), todoHandler.ModifyStatus)
+	app.Post("/api/v1/todos/:todoId/assignee", authService.Scope(entity.ScopeTodosWrite), authService.Authentication(), authService.Authorization(), todoHandler.Assign)
+	app.Delete("/api/v1/todos/:todoId/assignee", authService.Scope(entity.ScopeTodosWrite), authService.Authentication(), authService.Authorization(), todoHandler.Unassign)
+
+	app.Post("/api/v1/todos/:todoId/subtasks", authService.Scope(entity.ScopeTodosWrite), authService.Authentication(), authService.Authorization(), subtaskHandler.Add)
+	app.Get("/api/v1/todos/:todoId/subtasks", authService.Scope(entity.ScopeTodosRead), authService.Authentication(), authService.Authorization(), subtaskHandler.Fetch)
+	app.Patch("/api/v1/todos/:todoId/subtasks/reorder", authService.Scope(entity.ScopeTodosWrite), authService.Authentication(), authService.Authorization(), subtaskHandler.Reorder)
+	app.Patch("/api/v1/todos/:todoId/subtasks/:subtaskId/toggle", authService.Scope(entity.ScopeTodosWrite), authService.Authentication(), authService.Authorization(), subtaskHandler.Toggle)
+	app.Patch("/api/v1/todos/:todoId/subtasks/:subtaskId", authService.Scope(entity.ScopeTodosWrite), authService.Authentication(), authService.Authorization(), subtaskHandler.Modify)
+	app.Delete("/api/v1/todos/:todoId/subtasks/:subtaskId", authService.Scope(entity.ScopeTodosWrite), authService.Authentication(), authService.Authorization(), subtaskHandler.Delete)
+
+	app.Post("/api/v1/todos/:todoId/comments", authService.Scope(entity.ScopeTodosWrite), authService.Authentication(), authService.Authorization(), commentHandler.Add)
+	app.Get("/api/v1/todos/:todoId/comments", authService.Scope(entity.ScopeTodosRead), authService.Authentication(), authService.Authorization(), commentHandler.Fetch)
+	app.Patch("/api/v1/todos/:todoId/comments/:commentId", authService.Scope(entity.ScopeTodosWrite), authService.Authentication(), authService.Authorization(), commentHandler.Modify)
+	app.Delete("/api/v1/todos/:todoId/comments/:commentId", authService.Scope(entity.ScopeTodosWrite), authService.Authentication(), authService.Authorization(), commentHandler.Delete)
+
+	app.Post("/api/v1/todos/:todoId/attachments", authService.Scope(entity.ScopeTodosWrite), authService.Authentication(), authService.Authorization(), attachmentHandler.Upload)
+	app.Get("/api/v1/todos/:todoId/attachments", authService.Scope(entity.ScopeTodosRead), authService.Authentication(), authService.Authorization(), attachmentHandler.Fetch)
+	app.Get("/api/v1/todos/:todoId/attachments/:attachmentId", authService.Scope(entity.ScopeTodosRead), authService.Authentication(), authService.Authorization(), attachmentHandler.Detail)
+	app.Delete("/api/v1/todos/:todoId/attachments/:attachmentId", authService.Scope(entity.ScopeTodosWrite), authService.Authentication(), authService.Authorization(), attachmentHandler.Delete)
+
+	// the signed URLs returned for attachments stand in for a token
+	app.Get("/api/v1/attachments/:attachmentId/download", attachmentHandler.Download)
+
+	app.Post("/api/v1/tags", authService.Authentication(), tagHandler.Add)
+	app.Get("/api/v1/tags", authService.Authentication(), tagHandler.Fetch)
+	app.Delete("/api/v1/tags/:tagId", authService.Authentication(), authService.TagAuthorization(), tagHandler.Delete)
+	app.Get("/api/v1/tags/:tagId", authService.Authentication(), authService.TagAuthorization(), tagHandler.Detail)
+	app.Patch("/api/v1/tags/:tagId", authService.Authentication(), authService.TagAuthorization(), tagHandler.Modify)
+
+	app.Post("/api/v1/projects", authService.Authentication(), authService.Workspace(), projectHandler.Add)
+	app.Get("/api/v1/projects", authService.Authentication(), authService.Workspace(), projectHandler.Fetch)
+	app.Get("/api/v1/projects/:projectId/todos", authService.Scope(entity.ScopeTodosRead), authService.Authentication(), authService.ProjectAuthorization(), projectHandler.FetchTodos)
+	app.Delete("/api/v1/projects/:projectId", authService.Authentication(), authService.Role(entity.ShareOwner), authService.ProjectAuthorization(), projectHandler.Delete)
+	app.Get("/api/v1/projects/:projectId", authService.Authentication(), authService.ProjectAuthorization(), projectHandler.Detail)
+	app.Patch("/api/v1/projects/:projectId", authService.Authentication(), authService.Role(entity.ShareOwner), authService.ProjectAuthorization(), projectHandler.Modify)
+
+	// shared todos and projects are let in by Authorization and
+	// ProjectAuthorization when the share role allows the route, see
+	// authService.Role
+	app.Post("/api/v1/todos/:todoId/shares", authService.Authentication(), authService.Verified(), authService.Role(entity.ShareOwner), authService.Authorization(), shareHandler.ShareTodo)
+	app.Get("/api/v1/todos/:todoId/shares", authService.Authentication(), authService.Authorization(), shareHandler.TodoShares)
+	app.Post("/api/v1/projects/:projectId/shares", authService.Authentication(), authService.Verified(), authService.Role(entity.ShareOwner), authService.ProjectAuthorization(), shareHandler.ShareProject)
+	app.Get("/api/v1/projects/:projectId/shares", authService.Authentication(), authService.ProjectAuthorization(), shareHandler.ProjectShares)
+	app.Get("/api/v1/shares/invitations", authService.Authentication(), shareHandler.Invitations)
+	app.Post("/api/v1/shares/:shareId/accept", authService.Authentication(), shareHandler.Accept)
+	app.Post("/api/v1/shares/:shareId/decline", authService.Authentication(), shareHandler.Decline)
+	app.Patch("/api/v1/shares/:shareId", authService.Authentication(), shareHandler.Modify)
+	app.Delete("/api/v1/shares/:shareId", authService.Authentication(), shareHandler.Delete)
 
-	app.Post("/api/v1/todos", authService.Authentication(), todoHandler.Add)
-	app.Get("/api/v1/todos", authService.Authentication(), todoHandler.Fetch)
-	app.Delete("/api/v1/todos/:todoId", authService.Authentication(), authService.Authorization(), todoHandler.Delete)
-	app.Get("/api/v1/todos/:todoId", authService.Authentication(), authService.Authorization(), todoHandler.Detail)
-	app.Patch("/api/v1/todos/:todoId", authService.Authentication(), authService.Authorization(), todoHandler.Modify)
+	// the todo and project lists above are scoped to the workspace selected
+	// with the X-Workspace-ID header by authService.Workspace
+	app.Post("/api/v1/workspaces", authService.Authentication(), workspaceHandler.Add)
+	app.Get("/api/v1/workspaces", authService.Authentication(), workspaceHandler.Fetch)
+	app.Get("/api/v1/workspaces/invitations", authService.Authentication(), workspaceHandler.Invitations)
+	app.Post("/api/v1/workspaces/invitations/:memberId/accept", authService.Authentication(), workspaceHandler.Accept)
+	app.Post("/api/v1/workspaces/invitations/:memberId/decline", authService.Authentication(), workspaceHandler.Decline)
+	app.Get("/api/v1/workspaces/:workspaceId", authService.Authentication(), authService.WorkspaceAuthorization(entity.WorkspaceRoleGuest), workspaceHandler.Detail)
+	app.Patch("/api/v1/workspaces/:workspaceId", authService.Authentication(), authService.WorkspaceAuthorization(entity.WorkspaceRoleAdmin), workspaceHandler.Modify)
+	app.Post("/api/v1/workspaces/:workspaceId/transfer", authService.Authentication(), authService.WorkspaceAuthorization(entity.WorkspaceRoleOwner), workspaceHandler.Transfer)
+	app.Post("/api/v1/workspaces/:workspaceId/members", authService.Authentication(), authService.Verified(), authService.WorkspaceAuthorization(entity.WorkspaceRoleAdmin), workspaceHandler.Invite)
+	app.Get("/api/v1/workspaces/:workspaceId/members", authService.Authentication(), authService.WorkspaceAuthorization(entity.WorkspaceRoleGuest), workspaceHandler.Members)
+	app.Patch("/api/v1/workspaces/:workspaceId/members/:memberId", authService.Authentication(), authService.WorkspaceAuthorization(entity.WorkspaceRoleAdmin), workspaceHandler.ModifyMember)
+	app.Delete("/api/v1/workspaces/:workspaceId/members/:memberId", authService.Authentication(), authService.WorkspaceAuthorization(entity.WorkspaceRoleGuest), workspaceHandler.RemoveMember)
 
 	app.Listen(":" + config.AppConfig().Port)
 }
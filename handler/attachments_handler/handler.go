@@ -0,0 +1,186 @@
+package attachments_handler
+
+import (
+	"mime"
+	"strconv"
+	"todo-app/dto"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+	"todo-app/service/attachments_service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type attachmentHandler struct {
+	as attachments_service.AttachmentService
+}
+
+type AttachmentHandler interface {
+	Upload(c *fiber.Ctx) error
+	Fetch(c *fiber.Ctx) error
+	Detail(c *fiber.Ctx) error
+	Delete(c *fiber.Ctx) error
+	Download(c *fiber.Ctx) error
+}
+
+func NewAttachmentHandler(attachmentService attachments_service.AttachmentService) AttachmentHandler {
+	return &attachmentHandler{as: attachmentService}
+}
+
+// Upload implements AttachmentHandler.
+// Upload godoc
+// @Summary Upload attachment
+// @Description Attach a file to a todo, its size and type are limited by ATTACHMENT_MAX_BYTES and ATTACHMENT_TYPES
+// @Tags Attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param todoId path int true "todo id"
+// @Param file formData file true "file to attach"
+// @Success 201 {object} dto.AttachmentResponse
+// @Router /todos/{todoId}/attachments [post]
+func (ah *attachmentHandler) Upload(c *fiber.Ctx) error {
+	user := c.Locals("user").(entity.User)
+	todoId, _ := strconv.Atoi(c.Params("todoId"))
+
+	fh, err := c.FormFile("file")
+
+	if err != nil {
+		missingFile := errs.NewBadRequestError("file can't be empty")
+		return c.Status(missingFile.Status()).JSON(missingFile)
+	}
+
+	file, err := fh.Open()
+
+	if err != nil {
+		unreadable := errs.NewBadRequestError("attachment can't be read")
+		return c.Status(unreadable.Status()).JSON(unreadable)
+	}
+
+	defer file.Close()
+
+	ar, uploadErr := ah.as.Upload(user.ID, uint(todoId), &dto.UploadAttachment{
+		Filename: fh.Filename,
+		Size:     fh.Size,
+		File:     file,
+	})
+
+	if uploadErr != nil {
+		return c.Status(uploadErr.Status()).JSON(uploadErr)
+	}
+
+	return c.Status(ar.Status).JSON(ar)
+}
+
+// Fetch implements AttachmentHandler.
+// Fetch godoc
+// @Summary Get attachments
+// @Description Get the attachments of a todo with signed download URLs
+// @Tags Attachments
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param todoId path int true "todo id"
+// @Success 200 {object} dto.AttachmentResponse
+// @Router /todos/{todoId}/attachments [get]
+func (ah *attachmentHandler) Fetch(c *fiber.Ctx) error {
+	todoId, _ := strconv.Atoi(c.Params("todoId"))
+
+	ar, err := ah.as.Fetch(uint(todoId))
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(ar.Status).JSON(ar)
+}
+
+// Detail implements AttachmentHandler.
+// Detail godoc
+// @Summary Get attachment
+// @Description Get an attachment of a todo with a freshly signed download URL
+// @Tags Attachments
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param todoId path int true "todo id"
+// @Param attachmentId path int true "attachment id"
+// @Success 200 {object} dto.AttachmentResponse
+// @Router /todos/{todoId}/attachments/{attachmentId} [get]
+func (ah *attachmentHandler) Detail(c *fiber.Ctx) error {
+	todoId, _ := strconv.Atoi(c.Params("todoId"))
+	attachmentId, _ := strconv.Atoi(c.Params("attachmentId"))
+
+	ar, err := ah.as.Detail(uint(todoId), uint(attachmentId))
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(ar.Status).JSON(ar)
+}
+
+// Delete implements AttachmentHandler.
+// Delete godoc
+// @Summary Delete attachment
+// @Description Delete an attachment and its file, its uploader or the owner of the todo can delete it
+// @Tags Attachments
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param todoId path int true "todo id"
+// @Param attachmentId path int true "attachment id"
+// @Success 200 {object} dto.AttachmentResponse
+// @Router /todos/{todoId}/attachments/{attachmentId} [delete]
+func (ah *attachmentHandler) Delete(c *fiber.Ctx) error {
+	user := c.Locals("user").(entity.User)
+	todoId, _ := strconv.Atoi(c.Params("todoId"))
+	attachmentId, _ := strconv.Atoi(c.Params("attachmentId"))
+
+	ar, err := ah.as.Delete(user.ID, uint(todoId), uint(attachmentId))
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(ar.Status).JSON(ar)
+}
+
+// Download implements AttachmentHandler.
+// Download godoc
+// @Summary Download attachment
+// @Description Download the file of an attachment with the signed URL returned for it, no token is needed
+// @Tags Attachments
+// @Produce octet-stream
+// @Param attachmentId path int true "attachment id"
+// @Param expires query int true "expiry of the URL as unix time"
+// @Param signature query string true "signature of the URL"
+// @Success 200 {file} file
+// @Router /attachments/{attachmentId}/download [get]
+func (ah *attachmentHandler) Download(c *fiber.Ctx) error {
+	query := &dto.DownloadQuery{}
+	attachmentId, _ := strconv.Atoi(c.Params("attachmentId"))
+
+	if err := c.QueryParser(query); err != nil {
+		invalidQuery := errs.NewBadRequestError("invalid query parameter")
+		return c.Status(invalidQuery.Status()).JSON(invalidQuery)
+	}
+
+	d, err := ah.as.Download(uint(attachmentId), query)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": d.Filename})
+
+	if disposition == "" {
+		disposition = "attachment"
+	}
+
+	c.Set(fiber.HeaderContentType, d.ContentType)
+	c.Set(fiber.HeaderContentDisposition, disposition)
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+
+	return c.SendStream(d.Body, int(d.Size))
+}
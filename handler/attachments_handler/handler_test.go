@@ -0,0 +1,174 @@
+package attachments_handler_test
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http/httptest"
+	"testing"
+
+	"todo-app/dto"
+	"todo-app/entity"
+	"todo-app/handler/attachments_handler"
+	"todo-app/pkg/errs"
+	"todo-app/service/attachments_service"
+	"todo-app/service/auth_service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var serviceMock = attachments_service.NewServiceMock()
+var handler = attachments_handler.NewAttachmentHandler(serviceMock)
+
+var app = fiber.New()
+
+var user = entity.User{
+	Model: gorm.Model{
+		ID: 1,
+	},
+	Name:  "jihan",
+	Email: "jihan@weeekly.com",
+}
+
+func TestUploadSuccess(t *testing.T) {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	part, _ := w.CreateFormFile("file", "notes.txt")
+	part.Write([]byte("buy milk"))
+	w.Close()
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	attachments_service.Upload = func(userId uint, todoId uint, payload *dto.UploadAttachment) (*dto.AttachmentResponse, errs.Error) {
+		content, _ := io.ReadAll(payload.File)
+		assert.Equal(t, "notes.txt", payload.Filename)
+		assert.Equal(t, int64(8), payload.Size)
+		assert.Equal(t, "buy milk", string(content))
+		return &dto.AttachmentResponse{
+			Status:  fiber.StatusCreated,
+			Message: "attachment successfully uploaded",
+		}, nil
+	}
+
+	app.Post("/todos/:todoId/attachments", auth_service.Authentication(), handler.Upload)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/todos/1/attachments", body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusCreated, res.StatusCode)
+}
+
+func TestUploadMissingFile(t *testing.T) {
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	app.Post("/todos/:todoId/attachments", auth_service.Authentication(), handler.Upload)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/todos/1/attachments", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+}
+
+func TestFetchSuccess(t *testing.T) {
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	attachments_service.Fetch = func(todoId uint) (*dto.AttachmentResponse, errs.Error) {
+		return &dto.AttachmentResponse{
+			Status:  fiber.StatusOK,
+			Message: "attachments successfully fetched",
+			Data:    []*dto.Attachment{},
+		}, nil
+	}
+
+	app.Get("/todos/:todoId/attachments", auth_service.Authentication(), handler.Fetch)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/todos/1/attachments", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestDeleteForbidden(t *testing.T) {
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	attachments_service.Delete = func(userId uint, todoId uint, attachmentId uint) (*dto.AttachmentResponse, errs.Error) {
+		return nil, errs.NewUnathorizedError("you're not authorized to delete this attachment")
+	}
+
+	app.Delete("/todos/:todoId/attachments/:attachmentId", auth_service.Authentication(), handler.Delete)
+
+	req := httptest.NewRequest(fiber.MethodDelete, "/todos/1/attachments/1", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusForbidden, res.StatusCode)
+}
+
+func TestDownloadSuccess(t *testing.T) {
+	attachments_service.Download = func(attachmentId uint, query *dto.DownloadQuery) (*dto.AttachmentDownload, errs.Error) {
+		assert.Equal(t, int64(1700000000), query.Expires)
+		assert.Equal(t, "abc", query.Signature)
+		return &dto.AttachmentDownload{
+			Filename:    "notes.txt",
+			ContentType: "text/plain; charset=utf-8",
+			Size:        8,
+			Body:        io.NopCloser(bytes.NewReader([]byte("buy milk"))),
+		}, nil
+	}
+
+	app.Get("/attachments/:attachmentId/download", handler.Download)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/attachments/1/download?expires=1700000000&signature=abc", nil)
+
+	res, _ := app.Test(req, 1)
+
+	content, _ := io.ReadAll(res.Body)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	assert.Equal(t, "buy milk", string(content))
+	assert.Equal(t, "attachment; filename=notes.txt", res.Header.Get(fiber.HeaderContentDisposition))
+	assert.Equal(t, "nosniff", res.Header.Get(fiber.HeaderXContentTypeOptions))
+}
+
+func TestDownloadInvalidSignature(t *testing.T) {
+	attachments_service.Download = func(attachmentId uint, query *dto.DownloadQuery) (*dto.AttachmentDownload, errs.Error) {
+		return nil, errs.NewUnathorizedError("invalid download signature")
+	}
+
+	app.Get("/attachments/:attachmentId/download", handler.Download)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/attachments/1/download?expires=1700000000&signature=abc", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusForbidden, res.StatusCode)
+}
@@ -0,0 +1,156 @@
+package comments_handler
+
+import (
+	"strconv"
+	"todo-app/dto"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+	"todo-app/pkg/helper"
+	"todo-app/service/comments_service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type commentHandler struct {
+	cs comments_service.CommentService
+}
+
+type CommentHandler interface {
+	Add(c *fiber.Ctx) error
+	Fetch(c *fiber.Ctx) error
+	Modify(c *fiber.Ctx) error
+	Delete(c *fiber.Ctx) error
+}
+
+func NewCommentHandler(commentService comments_service.CommentService) CommentHandler {
+	return &commentHandler{cs: commentService}
+}
+
+// Add implements CommentHandler.
+// Add godoc
+// @Summary Add comment
+// @Description Add a comment to a todo, users mentioned by their email like @friend@mail.com are notified
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param todoId path int true "todo id"
+// @Param dto.AddComment body dto.AddComment true "body request for add comment"
+// @Success 201 {object} dto.CommentResponse
+// @Router /todos/{todoId}/comments [post]
+func (ch *commentHandler) Add(c *fiber.Ctx) error {
+	payload := &dto.AddComment{}
+	user := c.Locals("user").(entity.User)
+	todoId, _ := strconv.Atoi(c.Params("todoId"))
+
+	if err := c.BodyParser(payload); err != nil {
+		invalidJson := errs.NewUnprocessableEntityError("invalid JSON body request")
+		return c.Status(invalidJson.Status()).JSON(invalidJson)
+	}
+
+	if err := helper.ValidateStruct(payload); err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	cr, err := ch.cs.Add(user.ID, uint(todoId), payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(cr.Status).JSON(cr)
+}
+
+// Fetch implements CommentHandler.
+// Fetch godoc
+// @Summary Get comments
+// @Description Get the comments of a todo, oldest first
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param todoId path int true "todo id"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param limit query int false "page size between 1 and 100, default 50"
+// @Success 200 {object} dto.CommentResponse
+// @Router /todos/{todoId}/comments [get]
+func (ch *commentHandler) Fetch(c *fiber.Ctx) error {
+	query := &dto.CommentQuery{}
+	todoId, _ := strconv.Atoi(c.Params("todoId"))
+
+	if err := c.QueryParser(query); err != nil {
+		invalidQuery := errs.NewBadRequestError("invalid query parameter")
+		return c.Status(invalidQuery.Status()).JSON(invalidQuery)
+	}
+
+	cr, err := ch.cs.Fetch(uint(todoId), query)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(cr.Status).JSON(cr)
+}
+
+// Modify implements CommentHandler.
+// Modify godoc
+// @Summary Modify comment
+// @Description Edit a comment, only its author can edit it
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param todoId path int true "todo id"
+// @Param commentId path int true "comment id"
+// @Param dto.ModifyComment body dto.ModifyComment true "body request for modify comment"
+// @Success 200 {object} dto.CommentResponse
+// @Router /todos/{todoId}/comments/{commentId} [patch]
+func (ch *commentHandler) Modify(c *fiber.Ctx) error {
+	payload := &dto.ModifyComment{}
+	user := c.Locals("user").(entity.User)
+	todoId, _ := strconv.Atoi(c.Params("todoId"))
+	commentId, _ := strconv.Atoi(c.Params("commentId"))
+
+	if err := c.BodyParser(payload); err != nil {
+		invalidJson := errs.NewUnprocessableEntityError("invalid JSON body request")
+		return c.Status(invalidJson.Status()).JSON(invalidJson)
+	}
+
+	if err := helper.ValidateStruct(payload); err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	cr, err := ch.cs.Modify(user.ID, uint(todoId), uint(commentId), payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(cr.Status).JSON(cr)
+}
+
+// Delete implements CommentHandler.
+// Delete godoc
+// @Summary Delete comment
+// @Description Delete a comment, its author or the owner of the todo can delete it
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param todoId path int true "todo id"
+// @Param commentId path int true "comment id"
+// @Success 200 {object} dto.CommentResponse
+// @Router /todos/{todoId}/comments/{commentId} [delete]
+func (ch *commentHandler) Delete(c *fiber.Ctx) error {
+	user := c.Locals("user").(entity.User)
+	todoId, _ := strconv.Atoi(c.Params("todoId"))
+	commentId, _ := strconv.Atoi(c.Params("commentId"))
+
+	cr, err := ch.cs.Delete(user.ID, uint(todoId), uint(commentId))
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(cr.Status).JSON(cr)
+}
@@ -0,0 +1,152 @@
+package comments_handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"todo-app/dto"
+	"todo-app/entity"
+	"todo-app/handler/comments_handler"
+	"todo-app/pkg/errs"
+	"todo-app/service/auth_service"
+	"todo-app/service/comments_service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var serviceMock = comments_service.NewServiceMock()
+var handler = comments_handler.NewCommentHandler(serviceMock)
+
+var app = fiber.New()
+
+var user = entity.User{
+	Model: gorm.Model{
+		ID: 1,
+	},
+	Name:  "jihan",
+	Email: "jihan@weeekly.com",
+}
+
+func TestAddSuccess(t *testing.T) {
+	b, _ := json.Marshal(&dto.AddComment{Body: "@zee@weeekly.com can you pick these up?"})
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	comments_service.Add = func(userId uint, todoId uint, payload *dto.AddComment) (*dto.CommentResponse, errs.Error) {
+		return &dto.CommentResponse{
+			Status:  fiber.StatusCreated,
+			Message: "comment successfully added",
+		}, nil
+	}
+
+	app.Post("/todos/:todoId/comments", auth_service.Authentication(), handler.Add)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/todos/1/comments", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusCreated, res.StatusCode)
+}
+
+func TestAddBadRequest(t *testing.T) {
+	b, _ := json.Marshal(&dto.AddComment{})
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	app.Post("/todos/:todoId/comments", auth_service.Authentication(), handler.Add)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/todos/1/comments", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+}
+
+func TestFetchSuccess(t *testing.T) {
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	comments_service.Fetch = func(todoId uint, query *dto.CommentQuery) (*dto.CommentResponse, errs.Error) {
+		assert.Equal(t, 10, query.Limit)
+		return &dto.CommentResponse{
+			Status:  fiber.StatusOK,
+			Message: "comments successfully fetched",
+			Data:    []*dto.Comment{},
+		}, nil
+	}
+
+	app.Get("/todos/:todoId/comments", auth_service.Authentication(), handler.Fetch)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/todos/1/comments?limit=10", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestModifyForbidden(t *testing.T) {
+	b, _ := json.Marshal(&dto.ModifyComment{Body: "edited"})
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	comments_service.Modify = func(userId uint, todoId uint, commentId uint, payload *dto.ModifyComment) (*dto.CommentResponse, errs.Error) {
+		return nil, errs.NewUnathorizedError("only the author can edit a comment")
+	}
+
+	app.Patch("/todos/:todoId/comments/:commentId", auth_service.Authentication(), handler.Modify)
+
+	req := httptest.NewRequest(fiber.MethodPatch, "/todos/1/comments/1", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusForbidden, res.StatusCode)
+}
+
+func TestDeleteNotFound(t *testing.T) {
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	comments_service.Delete = func(userId uint, todoId uint, commentId uint) (*dto.CommentResponse, errs.Error) {
+		return nil, errs.NewNotFoundError("comment not found")
+	}
+
+	app.Delete("/todos/:todoId/comments/:commentId", auth_service.Authentication(), handler.Delete)
+
+	req := httptest.NewRequest(fiber.MethodDelete, "/todos/1/comments/1", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+}
@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"time"
+	"todo-app/service/todos_service"
+
+	"github.com/gofiber/fiber/v2/log"
+)
+
+// purgeTrash permanently deletes todos that stayed in the trash longer than
+// the retention period, once at startup and then every hour.
+func purgeTrash(todoService todos_service.TodoService, retentionDays int) {
+
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		purged, err := todoService.PurgeTrash(retentionDays)
+
+		if err != nil {
+			log.Errorf("error while purging trash: %s", err.Message())
+		} else if purged > 0 {
+			log.Infof("%d trashed todos purged", purged)
+		}
+
+		<-ticker.C
+	}
+}
@@ -0,0 +1,37 @@
+package jwks_handler
+
+import (
+	"todo-app/pkg/keyring"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type jwksHandler struct {
+	kr *keyring.Keyring
+}
+
+type JWKSHandler interface {
+	Keys(c *fiber.Ctx) error
+}
+
+// NewJWKSHandler publishes the keys of kr, which is nil while tokens are
+// signed with the secret key.
+func NewJWKSHandler(kr *keyring.Keyring) JWKSHandler {
+	return &jwksHandler{kr: kr}
+}
+
+// Keys implements JWKSHandler. It serves the public keys other services
+// verify access tokens with, outside of the versioned API.
+func (jh *jwksHandler) Keys(c *fiber.Ctx) error {
+
+	set := keyring.JWKS{Keys: []keyring.JWK{}}
+
+	if jh.kr != nil {
+		set = jh.kr.JWKS()
+	}
+
+	// verifiers refetch the set at least this often to pick up rotations
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+
+	return c.Status(fiber.StatusOK).JSON(set)
+}
@@ -0,0 +1,59 @@
+package jwks_handler_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http/httptest"
+	"testing"
+	"todo-app/handler/jwks_handler"
+	"todo-app/pkg/keyring"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeysSuccess(t *testing.T) {
+	_, private, _ := ed25519.GenerateKey(rand.Reader)
+	der, _ := x509.MarshalPKCS8PrivateKey(private)
+
+	key, err := keyring.ParsePEM("2024-01", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	assert.Nil(t, err)
+
+	kr, err := keyring.New(key)
+	assert.Nil(t, err)
+
+	app := fiber.New()
+	app.Get("/.well-known/jwks.json", jwks_handler.NewJWKSHandler(kr).Keys)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/.well-known/jwks.json", nil)
+
+	res, _ := app.Test(req, -1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+
+	set := keyring.JWKS{}
+	json.NewDecoder(res.Body).Decode(&set)
+
+	assert.Len(t, set.Keys, 1)
+	assert.Equal(t, "2024-01", set.Keys[0].Kid)
+	assert.Equal(t, "EdDSA", set.Keys[0].Alg)
+}
+
+func TestKeysWithoutKeyring(t *testing.T) {
+	app := fiber.New()
+	app.Get("/.well-known/jwks.json", jwks_handler.NewJWKSHandler(nil).Keys)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/.well-known/jwks.json", nil)
+
+	res, _ := app.Test(req, -1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+
+	set := keyring.JWKS{}
+	json.NewDecoder(res.Body).Decode(&set)
+
+	assert.Empty(t, set.Keys)
+}
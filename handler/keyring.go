@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"todo-app/entity"
+	"todo-app/infra/config"
+	"todo-app/pkg/keyring"
+
+	"github.com/gofiber/fiber/v2/log"
+)
+
+// newKeyring loads the signing keys from JWT_KEYS_DIR once JWT_ACTIVE_KID is
+// set, tokens keep being signed with JWT_SECRET_KEY otherwise and nil is
+// returned.
+func newKeyring() *keyring.Keyring {
+
+	cfg := config.AppConfig()
+
+	if cfg.JwtActiveKid == "" {
+		return nil
+	}
+
+	kr, err := keyring.Load(cfg.JwtKeysDir, cfg.JwtActiveKid)
+
+	if err != nil {
+		log.Panicf("error while loading signing keys: %s", err.Error())
+	}
+
+	entity.UseKeyring(kr)
+
+	return kr
+}
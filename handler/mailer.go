@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+	"todo-app/entity"
+	"todo-app/infra/config"
+	"todo-app/pkg/mailer"
+	"todo-app/service/comments_service"
+	"todo-app/service/todos_service"
+	"todo-app/service/users_service"
+
+	"github.com/gofiber/fiber/v2/log"
+)
+
+// newMailer picks the mailer set by MAIL_DRIVER, mails are only logged
+// unless it is smtp.
+func newMailer() mailer.Mailer {
+
+	cfg := config.AppConfig()
+
+	if cfg.MailDriver == "smtp" {
+		return mailer.NewSMTPMailer(cfg.SmtpHost, cfg.SmtpPort, cfg.SmtpUsername, cfg.SmtpPassword, cfg.MailFrom)
+	}
+
+	return mailer.NewLogMailer()
+}
+
+// notifyLockout mails users whose account got locked out by failed logins.
+func notifyLockout(m mailer.Mailer) users_service.LockoutHook {
+	return func(user *entity.User, ip string, until time.Time) {
+
+		body := fmt.Sprintf("Hi %s,\n\nYour TodoKu account has been locked until %s after too many failed logins from %s.\n\nReset your password if it wasn't you.",
+			user.Name, until.UTC().Format(time.RFC1123), ip)
+
+		if err := m.Send(user.Email, "Your TodoKu account has been locked", body); err != nil {
+			log.Errorf("error while sending mail: %s", err.Error())
+		}
+	}
+}
+
+// notifyAssignment mails users who got a todo assigned by someone else.
+func notifyAssignment(m mailer.Mailer) todos_service.AssignHook {
+	return func(todo *entity.Todo, assignee *entity.User, assigner *entity.User) {
+
+		body := fmt.Sprintf("Hi %s,\n\n%s assigned you the todo \"%s\". Open TodoKu to see it in your assigned todos.",
+			assignee.Name, assigner.Name, todo.Todos)
+
+		if err := m.Send(assignee.Email, "A TodoKu todo has been assigned to you", body); err != nil {
+			log.Errorf("error while sending mail: %s", err.Error())
+		}
+	}
+}
+
+// notifyMentions mails the users mentioned in a comment.
+func notifyMentions(m mailer.Mailer) comments_service.MentionHook {
+	return func(todo *entity.Todo, comment *entity.Comment, mentioned []*entity.User) {
+
+		for _, eachUser := range mentioned {
+			body := fmt.Sprintf("Hi %s,\n\n%s mentioned you on the todo \"%s\":\n\n%s",
+				eachUser.Name, comment.User.Name, todo.Todos, comment.Body)
+
+			if err := m.Send(eachUser.Email, "You've been mentioned on a TodoKu todo", body); err != nil {
+				log.Errorf("error while sending mail: %s", err.Error())
+			}
+		}
+	}
+}
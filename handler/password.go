@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"todo-app/entity"
+	"todo-app/infra/config"
+	"todo-app/pkg/password"
+
+	"github.com/gofiber/fiber/v2/log"
+)
+
+// newPasswordPolicy builds the policy new passwords are checked against,
+// with the blocklist of PASSWORD_BLOCKLIST_FILE when it is set.
+func newPasswordPolicy() *password.Policy {
+
+	cfg := config.AppConfig()
+	blocklist := []string{}
+
+	if cfg.PasswordBlocklistFile != "" {
+		passwords, err := password.LoadBlocklist(cfg.PasswordBlocklistFile)
+
+		if err != nil {
+			log.Panicf("error while loading password blocklist: %s", err.Error())
+		}
+
+		blocklist = passwords
+	}
+
+	policy := password.NewPolicy(cfg.PasswordMinLength, cfg.PasswordMaxLength, blocklist)
+
+	// longer passwords would be truncated by bcrypt
+	if cfg.PasswordHasher == "bcrypt" {
+		policy.MaxBytes = password.BcryptMaxLength
+	}
+
+	return policy
+}
+
+// usePasswordHasher checks the PASSWORD_HASHER parameters once on startup,
+// passwords are hashed with the same hasher afterwards.
+func usePasswordHasher() {
+
+	cfg := config.AppConfig()
+
+	h, err := password.NewHasher(cfg.PasswordHasher, cfg.Argon2MemoryKiB, cfg.Argon2Iterations, cfg.Argon2Parallelism, cfg.BcryptCost)
+
+	if err != nil {
+		log.Panicf("error while configuring password hasher: %s", err.Error())
+	}
+
+	entity.UsePasswordHasher(h)
+}
@@ -0,0 +1,205 @@
+package projects_handler
+
+import (
+	"strconv"
+	"todo-app/dto"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+	"todo-app/pkg/helper"
+	"todo-app/service/projects_service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type projectHandler struct {
+	ps projects_service.ProjectService
+}
+
+type ProjectHandler interface {
+	Add(c *fiber.Ctx) error
+	Detail(c *fiber.Ctx) error
+	Fetch(c *fiber.Ctx) error
+	FetchTodos(c *fiber.Ctx) error
+	Modify(c *fiber.Ctx) error
+	Delete(c *fiber.Ctx) error
+}
+
+func NewProjectHandler(projectService projects_service.ProjectService) ProjectHandler {
+	return &projectHandler{ps: projectService}
+}
+
+// Add implements ProjectHandler.
+// Add godoc
+// @Summary Add project
+// @Description Add project request
+// @Tags Projects
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param X-Workspace-ID header int false "workspace id, the personal space when omitted"
+// @Param dto.AddProject body dto.AddProject true "body request for add project"
+// @Success 201 {object} dto.ProjectResponse
+// @Router /projects [post]
+func (ph *projectHandler) Add(c *fiber.Ctx) error {
+	payload := &dto.AddProject{}
+	user := c.Locals("user").(entity.User)
+
+	if err := c.BodyParser(payload); err != nil {
+		invalidJson := errs.NewUnprocessableEntityError("invalid JSON body request")
+		return c.Status(invalidJson.Status()).JSON(invalidJson)
+	}
+
+	if err := helper.ValidateStruct(payload); err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	pr, err := ph.ps.Add(user.ID, user.WorkspaceID, payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(pr.Status).JSON(pr)
+}
+
+// Delete implements ProjectHandler.
+// Delete godoc
+// @Summary Delete project
+// @Description Delete project request, todos are moved to the inbox unless mode is cascade
+// @Tags Projects
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param projectId path int true "project id"
+// @Param mode query string false "what happens to the project todos, default inbox" Enums(inbox, cascade)
+// @Success 200 {object} dto.ProjectResponse
+// @Router /projects/{projectId} [delete]
+func (ph *projectHandler) Delete(c *fiber.Ctx) error {
+	query := &dto.DeleteProjectQuery{}
+	projectId, _ := strconv.Atoi(c.Params("projectId"))
+
+	if err := c.QueryParser(query); err != nil {
+		invalidQuery := errs.NewBadRequestError("invalid query parameter")
+		return c.Status(invalidQuery.Status()).JSON(invalidQuery)
+	}
+
+	pr, err := ph.ps.Delete(uint(projectId), query)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(pr.Status).JSON(pr)
+}
+
+// Detail implements ProjectHandler.
+// Detail godoc
+// @Summary Detail project
+// @Description Detail project request
+// @Tags Projects
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param projectId path int true "project id"
+// @Success 200 {object} dto.ProjectResponse
+// @Router /projects/{projectId} [get]
+func (ph *projectHandler) Detail(c *fiber.Ctx) error {
+
+	projectId, _ := strconv.Atoi(c.Params("projectId"))
+
+	pr, err := ph.ps.Detail(uint(projectId))
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(pr.Status).JSON(pr)
+}
+
+// Fetch implements ProjectHandler.
+// Fetch godoc
+// @Summary Get all projects
+// @Description Get all projects request
+// @Tags Projects
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param X-Workspace-ID header int false "workspace id, the personal space when omitted"
+// @Param archived query bool false "fetch archived projects instead"
+// @Success 200 {object} dto.ProjectResponse
+// @Router /projects [get]
+func (ph *projectHandler) Fetch(c *fiber.Ctx) error {
+	query := &dto.ProjectQuery{}
+	user := c.Locals("user").(entity.User)
+
+	if err := c.QueryParser(query); err != nil {
+		invalidQuery := errs.NewBadRequestError("invalid query parameter")
+		return c.Status(invalidQuery.Status()).JSON(invalidQuery)
+	}
+
+	pr, err := ph.ps.Fetch(user.ID, user.WorkspaceID, query)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(pr.Status).JSON(pr)
+}
+
+// FetchTodos implements ProjectHandler.
+// FetchTodos godoc
+// @Summary Get project todos
+// @Description Get all todos of a project request
+// @Tags Projects
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param projectId path int true "project id"
+// @Success 200 {object} dto.ProjectResponse
+// @Router /projects/{projectId}/todos [get]
+func (ph *projectHandler) FetchTodos(c *fiber.Ctx) error {
+
+	projectId, _ := strconv.Atoi(c.Params("projectId"))
+
+	pr, err := ph.ps.FetchTodos(uint(projectId))
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(pr.Status).JSON(pr)
+}
+
+// Modify implements ProjectHandler.
+// Modify godoc
+// @Summary Modify project
+// @Description Modify project request
+// @Tags Projects
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param projectId path int true "project id"
+// @Param dto.ModifyProject body dto.ModifyProject true "body request for modify project"
+// @Success 200 {object} dto.ProjectResponse
+// @Router /projects/{projectId} [patch]
+func (ph *projectHandler) Modify(c *fiber.Ctx) error {
+	payload := &dto.ModifyProject{}
+	projectId, _ := strconv.Atoi(c.Params("projectId"))
+
+	if err := c.BodyParser(payload); err != nil {
+		invalidJson := errs.NewUnprocessableEntityError("invalid JSON body request")
+		return c.Status(invalidJson.Status()).JSON(invalidJson)
+	}
+
+	if err := helper.ValidateStruct(payload); err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	pr, err := ph.ps.Modify(uint(projectId), payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(pr.Status).JSON(pr)
+}
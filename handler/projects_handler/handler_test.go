@@ -0,0 +1,226 @@
+package projects_handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"todo-app/dto"
+	"todo-app/entity"
+	"todo-app/handler/projects_handler"
+	"todo-app/pkg/errs"
+	"todo-app/service/auth_service"
+	"todo-app/service/projects_service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var serviceMock = projects_service.NewServiceMock()
+var handler = projects_handler.NewProjectHandler(serviceMock)
+
+var app = fiber.New()
+
+var add = &dto.AddProject{
+	Name:  "groceries",
+	Color: "#00ff00",
+}
+
+var user = entity.User{
+	Model: gorm.Model{
+		ID: 1,
+	},
+	Name:  "jihan",
+	Email: "jihan@weeekly.com",
+}
+
+func TestAddSuccess(t *testing.T) {
+	b, _ := json.Marshal(add)
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	projects_service.Add = func(userId uint, workspaceId uint, payload *dto.AddProject) (*dto.ProjectResponse, errs.Error) {
+		return &dto.ProjectResponse{
+			Status:  fiber.StatusCreated,
+			Message: "project successfully added",
+		}, nil
+	}
+
+	app.Post("/projects", auth_service.Authentication(), handler.Add)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/projects", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusCreated, res.StatusCode)
+}
+
+func TestAddBadRequest(t *testing.T) {
+	b, _ := json.Marshal(&dto.AddProject{})
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	app.Post("/projects", auth_service.Authentication(), handler.Add)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/projects", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+}
+
+func TestFetchSuccess(t *testing.T) {
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	projects_service.Fetch = func(userId uint, workspaceId uint, query *dto.ProjectQuery) (*dto.ProjectResponse, errs.Error) {
+		assert.True(t, query.Archived)
+		return &dto.ProjectResponse{
+			Status:  fiber.StatusOK,
+			Message: "projects successfully fetched",
+		}, nil
+	}
+
+	app.Get("/projects", auth_service.Authentication(), handler.Fetch)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/projects?archived=true", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestFetchTodosSuccess(t *testing.T) {
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	auth_service.ProjectAuthorization = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	projects_service.FetchTodos = func(projectId uint) (*dto.ProjectResponse, errs.Error) {
+		return &dto.ProjectResponse{
+			Status:  fiber.StatusOK,
+			Message: "project todos successfully fetched",
+		}, nil
+	}
+
+	app.Get("/projects/:projectId/todos", auth_service.Authentication(), auth_service.ProjectAuthorization(), handler.FetchTodos)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/projects/1/todos", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestDetailNotFound(t *testing.T) {
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	auth_service.ProjectAuthorization = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	projects_service.Detail = func(projectId uint) (*dto.ProjectResponse, errs.Error) {
+		return nil, errs.NewNotFoundError("project not found")
+	}
+
+	app.Get("/projects/:projectId", auth_service.Authentication(), auth_service.ProjectAuthorization(), handler.Detail)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/projects/1", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+}
+
+func TestModifyInvalidJSON(t *testing.T) {
+	b, _ := json.Marshal(&dto.ModifyProject{Name: "groceries"})
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	auth_service.ProjectAuthorization = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	app.Patch("/projects/:projectId", auth_service.Authentication(), auth_service.ProjectAuthorization(), handler.Modify)
+
+	req := httptest.NewRequest(fiber.MethodPatch, "/projects/1", bytes.NewReader(b))
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusUnprocessableEntity, res.StatusCode)
+}
+
+func TestDeleteSuccess(t *testing.T) {
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	auth_service.ProjectAuthorization = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	projects_service.Delete = func(projectId uint, query *dto.DeleteProjectQuery) (*dto.ProjectResponse, errs.Error) {
+		assert.Equal(t, "cascade", query.Mode)
+		return &dto.ProjectResponse{
+			Status:  fiber.StatusOK,
+			Message: "project successfully deleted",
+		}, nil
+	}
+
+	app.Delete("/projects/:projectId", auth_service.Authentication(), auth_service.ProjectAuthorization(), handler.Delete)
+
+	req := httptest.NewRequest(fiber.MethodDelete, "/projects/1?mode=cascade", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
@@ -0,0 +1,283 @@
+package shares_handler
+
+import (
+	"strconv"
+	"todo-app/dto"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+	"todo-app/pkg/helper"
+	"todo-app/service/shares_service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type shareHandler struct {
+	ss shares_service.ShareService
+}
+
+type ShareHandler interface {
+	ShareTodo(c *fiber.Ctx) error
+	ShareProject(c *fiber.Ctx) error
+	TodoShares(c *fiber.Ctx) error
+	ProjectShares(c *fiber.Ctx) error
+	Invitations(c *fiber.Ctx) error
+	Accept(c *fiber.Ctx) error
+	Decline(c *fiber.Ctx) error
+	Modify(c *fiber.Ctx) error
+	Delete(c *fiber.Ctx) error
+}
+
+func NewShareHandler(shareService shares_service.ShareService) ShareHandler {
+	return &shareHandler{ss: shareService}
+}
+
+// ShareTodo implements ShareHandler.
+// ShareTodo godoc
+// @Summary Share todo
+// @Description Invite a user by email to a todo, the role is one of viewer, editor or owner
+// @Tags Shares
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param todoId path int true "todo id"
+// @Param dto.AddShare body dto.AddShare true "body request for share todo"
+// @Success 201 {object} dto.ShareResponse
+// @Router /todos/{todoId}/shares [post]
+func (sh *shareHandler) ShareTodo(c *fiber.Ctx) error {
+	payload := &dto.AddShare{}
+	user := c.Locals("user").(entity.User)
+	todoId, _ := strconv.Atoi(c.Params("todoId"))
+
+	if err := c.BodyParser(payload); err != nil {
+		invalidJson := errs.NewUnprocessableEntityError("invalid JSON body request")
+		return c.Status(invalidJson.Status()).JSON(invalidJson)
+	}
+
+	if err := helper.ValidateStruct(payload); err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	sr, err := sh.ss.ShareTodo(user.ID, uint(todoId), payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(sr.Status).JSON(sr)
+}
+
+// ShareProject implements ShareHandler.
+// ShareProject godoc
+// @Summary Share project
+// @Description Invite a user by email to a project, the role is one of viewer, editor or owner
+// @Tags Shares
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param projectId path int true "project id"
+// @Param dto.AddShare body dto.AddShare true "body request for share project"
+// @Success 201 {object} dto.ShareResponse
+// @Router /projects/{projectId}/shares [post]
+func (sh *shareHandler) ShareProject(c *fiber.Ctx) error {
+	payload := &dto.AddShare{}
+	user := c.Locals("user").(entity.User)
+	projectId, _ := strconv.Atoi(c.Params("projectId"))
+
+	if err := c.BodyParser(payload); err != nil {
+		invalidJson := errs.NewUnprocessableEntityError("invalid JSON body request")
+		return c.Status(invalidJson.Status()).JSON(invalidJson)
+	}
+
+	if err := helper.ValidateStruct(payload); err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	sr, err := sh.ss.ShareProject(user.ID, uint(projectId), payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(sr.Status).JSON(sr)
+}
+
+// TodoShares implements ShareHandler.
+// TodoShares godoc
+// @Summary Get todo shares
+// @Description Get the users a todo is shared with request
+// @Tags Shares
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param todoId path int true "todo id"
+// @Success 200 {object} dto.ShareResponse
+// @Router /todos/{todoId}/shares [get]
+func (sh *shareHandler) TodoShares(c *fiber.Ctx) error {
+
+	todoId, _ := strconv.Atoi(c.Params("todoId"))
+
+	sr, err := sh.ss.TodoShares(uint(todoId))
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(sr.Status).JSON(sr)
+}
+
+// ProjectShares implements ShareHandler.
+// ProjectShares godoc
+// @Summary Get project shares
+// @Description Get the users a project is shared with request
+// @Tags Shares
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param projectId path int true "project id"
+// @Success 200 {object} dto.ShareResponse
+// @Router /projects/{projectId}/shares [get]
+func (sh *shareHandler) ProjectShares(c *fiber.Ctx) error {
+
+	projectId, _ := strconv.Atoi(c.Params("projectId"))
+
+	sr, err := sh.ss.ProjectShares(uint(projectId))
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(sr.Status).JSON(sr)
+}
+
+// Invitations implements ShareHandler.
+// Invitations godoc
+// @Summary Get invitations
+// @Description Get the shares waiting to be accepted or declined by the user
+// @Tags Shares
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Success 200 {object} dto.ShareResponse
+// @Router /shares/invitations [get]
+func (sh *shareHandler) Invitations(c *fiber.Ctx) error {
+
+	user := c.Locals("user").(entity.User)
+
+	sr, err := sh.ss.Invitations(user.ID)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(sr.Status).JSON(sr)
+}
+
+// Accept implements ShareHandler.
+// Accept godoc
+// @Summary Accept invitation
+// @Description Accept an invitation to a shared todo or project
+// @Tags Shares
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param shareId path int true "share id"
+// @Success 200 {object} dto.ShareResponse
+// @Router /shares/{shareId}/accept [post]
+func (sh *shareHandler) Accept(c *fiber.Ctx) error {
+
+	user := c.Locals("user").(entity.User)
+	shareId, _ := strconv.Atoi(c.Params("shareId"))
+
+	sr, err := sh.ss.Accept(user.ID, uint(shareId))
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(sr.Status).JSON(sr)
+}
+
+// Decline implements ShareHandler.
+// Decline godoc
+// @Summary Decline invitation
+// @Description Decline an invitation to a shared todo or project
+// @Tags Shares
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param shareId path int true "share id"
+// @Success 200 {object} dto.ShareResponse
+// @Router /shares/{shareId}/decline [post]
+func (sh *shareHandler) Decline(c *fiber.Ctx) error {
+
+	user := c.Locals("user").(entity.User)
+	shareId, _ := strconv.Atoi(c.Params("shareId"))
+
+	sr, err := sh.ss.Decline(user.ID, uint(shareId))
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(sr.Status).JSON(sr)
+}
+
+// Modify implements ShareHandler.
+// Modify godoc
+// @Summary Modify share
+// @Description Change the role of a share, only the owner of the shared todo or project can
+// @Tags Shares
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param shareId path int true "share id"
+// @Param dto.ModifyShare body dto.ModifyShare true "body request for modify share"
+// @Success 200 {object} dto.ShareResponse
+// @Router /shares/{shareId} [patch]
+func (sh *shareHandler) Modify(c *fiber.Ctx) error {
+	payload := &dto.ModifyShare{}
+	user := c.Locals("user").(entity.User)
+	shareId, _ := strconv.Atoi(c.Params("shareId"))
+
+	if err := c.BodyParser(payload); err != nil {
+		invalidJson := errs.NewUnprocessableEntityError("invalid JSON body request")
+		return c.Status(invalidJson.Status()).JSON(invalidJson)
+	}
+
+	if err := helper.ValidateStruct(payload); err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	sr, err := sh.ss.Modify(user.ID, uint(shareId), payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(sr.Status).JSON(sr)
+}
+
+// Delete implements ShareHandler.
+// Delete godoc
+// @Summary Delete share
+// @Description The owner of the shared todo or project revokes a share, the user it was shared with leaves it
+// @Tags Shares
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param shareId path int true "share id"
+// @Success 200 {object} dto.ShareResponse
+// @Router /shares/{shareId} [delete]
+func (sh *shareHandler) Delete(c *fiber.Ctx) error {
+
+	user := c.Locals("user").(entity.User)
+	shareId, _ := strconv.Atoi(c.Params("shareId"))
+
+	sr, err := sh.ss.Delete(user.ID, uint(shareId))
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(sr.Status).JSON(sr)
+}
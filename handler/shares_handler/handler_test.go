@@ -0,0 +1,205 @@
+package shares_handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"todo-app/dto"
+	"todo-app/entity"
+	"todo-app/handler/shares_handler"
+	"todo-app/pkg/errs"
+	"todo-app/service/auth_service"
+	"todo-app/service/shares_service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var serviceMock = shares_service.NewServiceMock()
+var handler = shares_handler.NewShareHandler(serviceMock)
+
+var app = fiber.New()
+
+var add = &dto.AddShare{
+	Email: "zee@weeekly.com",
+	Role:  entity.ShareEditor,
+}
+
+var user = entity.User{
+	Model: gorm.Model{
+		ID: 1,
+	},
+	Name:  "jihan",
+	Email: "jihan@weeekly.com",
+}
+
+func TestShareTodoSuccess(t *testing.T) {
+	b, _ := json.Marshal(add)
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	shares_service.ShareTodo = func(userId uint, todoId uint, payload *dto.AddShare) (*dto.ShareResponse, errs.Error) {
+		return &dto.ShareResponse{
+			Status:  fiber.StatusCreated,
+			Message: "invitation successfully sent",
+		}, nil
+	}
+
+	app.Post("/todos/:todoId/shares", auth_service.Authentication(), handler.ShareTodo)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/todos/1/shares", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusCreated, res.StatusCode)
+}
+
+func TestShareTodoBadRequest(t *testing.T) {
+	b, _ := json.Marshal(&dto.AddShare{Email: "zee", Role: entity.ShareEditor})
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	app.Post("/todos/:todoId/shares", auth_service.Authentication(), handler.ShareTodo)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/todos/1/shares", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+}
+
+func TestShareProjectConflict(t *testing.T) {
+	b, _ := json.Marshal(add)
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	shares_service.ShareProject = func(userId uint, projectId uint, payload *dto.AddShare) (*dto.ShareResponse, errs.Error) {
+		return nil, errs.NewConflictError("already shared with this user")
+	}
+
+	app.Post("/projects/:projectId/shares", auth_service.Authentication(), handler.ShareProject)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/projects/1/shares", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusConflict, res.StatusCode)
+}
+
+func TestInvitationsSuccess(t *testing.T) {
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	shares_service.Invitations = func(userId uint) (*dto.ShareResponse, errs.Error) {
+		return &dto.ShareResponse{
+			Status:  fiber.StatusOK,
+			Message: "shares successfully fetched",
+			Data:    []*dto.Share{},
+		}, nil
+	}
+
+	app.Get("/shares/invitations", auth_service.Authentication(), handler.Invitations)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/shares/invitations", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestAcceptNotFound(t *testing.T) {
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	shares_service.Accept = func(userId uint, shareId uint) (*dto.ShareResponse, errs.Error) {
+		return nil, errs.NewNotFoundError("share not found")
+	}
+
+	app.Post("/shares/:shareId/accept", auth_service.Authentication(), handler.Accept)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/shares/1/accept", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+}
+
+func TestModifySuccess(t *testing.T) {
+	b, _ := json.Marshal(&dto.ModifyShare{Role: entity.ShareViewer})
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	shares_service.Modify = func(userId uint, shareId uint, payload *dto.ModifyShare) (*dto.ShareResponse, errs.Error) {
+		return &dto.ShareResponse{
+			Status:  fiber.StatusOK,
+			Message: "share successfully modified",
+		}, nil
+	}
+
+	app.Patch("/shares/:shareId", auth_service.Authentication(), handler.Modify)
+
+	req := httptest.NewRequest(fiber.MethodPatch, "/shares/1", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestDeleteForbidden(t *testing.T) {
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	shares_service.Delete = func(userId uint, shareId uint) (*dto.ShareResponse, errs.Error) {
+		return nil, errs.NewUnathorizedError("you're not authorized to manage this share")
+	}
+
+	app.Delete("/shares/:shareId", auth_service.Authentication(), handler.Delete)
+
+	req := httptest.NewRequest(fiber.MethodDelete, "/shares/1", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusForbidden, res.StatusCode)
+}
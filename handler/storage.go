@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"crypto/rand"
+	"todo-app/infra/config"
+	"todo-app/pkg/storage"
+
+	"github.com/gofiber/fiber/v2/log"
+)
+
+// newStorage picks the attachment storage set by STORAGE_DRIVER, files are
+// kept in STORAGE_LOCAL_DIR unless it is s3.
+func newStorage() storage.Storage {
+
+	cfg := config.AppConfig()
+
+	var blobs storage.Storage
+	var err error
+
+	if cfg.StorageDriver == "s3" {
+		blobs, err = storage.NewS3Storage(cfg.S3Endpoint, cfg.S3Region, cfg.S3Bucket, cfg.S3AccessKey, cfg.S3SecretKey, cfg.S3PathStyle)
+	} else {
+		blobs, err = storage.NewLocalStorage(cfg.StorageLocalDir)
+	}
+
+	if err != nil {
+		log.Panicf("error while setting up attachment storage: %s", err.Error())
+	}
+
+	return blobs
+}
+
+// attachmentSecret returns the key download URLs are signed with. Without
+// ATTACHMENT_URL_SECRET a random key is used, so URLs stop working after a
+// restart and on other instances.
+func attachmentSecret() []byte {
+
+	if secret := config.AppConfig().AttachmentURLSecret; secret != "" {
+		return []byte(secret)
+	}
+
+	log.Warnf("ATTACHMENT_URL_SECRET isn't set, attachment download URLs are signed with a random key")
+
+	secret := make([]byte, 32)
+
+	if _, err := rand.Read(secret); err != nil {
+		log.Panicf("error while generating attachment secret: %s", err.Error())
+	}
+
+	return secret
+}
@@ -0,0 +1,204 @@
+package subtasks_handler
+
+import (
+	"strconv"
+	"todo-app/dto"
+	"todo-app/pkg/errs"
+	"todo-app/pkg/helper"
+	"todo-app/service/subtasks_service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type subtaskHandler struct {
+	ss subtasks_service.SubtaskService
+}
+
+type SubtaskHandler interface {
+	Add(c *fiber.Ctx) error
+	Fetch(c *fiber.Ctx) error
+	Modify(c *fiber.Ctx) error
+	Toggle(c *fiber.Ctx) error
+	Reorder(c *fiber.Ctx) error
+	Delete(c *fiber.Ctx) error
+}
+
+func NewSubtaskHandler(subtaskService subtasks_service.SubtaskService) SubtaskHandler {
+	return &subtaskHandler{ss: subtaskService}
+}
+
+// Add implements SubtaskHandler.
+// Add godoc
+// @Summary Add subtask
+// @Description Add subtask to a todo request
+// @Tags Subtasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param todoId path int true "todo id"
+// @Param dto.AddSubtask body dto.AddSubtask true "body request for add subtask"
+// @Success 201 {object} dto.SubtaskResponse
+// @Router /todos/{todoId}/subtasks [post]
+func (sh *subtaskHandler) Add(c *fiber.Ctx) error {
+	payload := &dto.AddSubtask{}
+	todoId, _ := strconv.Atoi(c.Params("todoId"))
+
+	if err := c.BodyParser(payload); err != nil {
+		invalidJson := errs.NewUnprocessableEntityError("invalid JSON body request")
+		return c.Status(invalidJson.Status()).JSON(invalidJson)
+	}
+
+	if err := helper.ValidateStruct(payload); err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	sr, err := sh.ss.Add(uint(todoId), payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(sr.Status).JSON(sr)
+}
+
+// Delete implements SubtaskHandler.
+// Delete godoc
+// @Summary Delete subtask
+// @Description Delete subtask request
+// @Tags Subtasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param todoId path int true "todo id"
+// @Param subtaskId path int true "subtask id"
+// @Success 200 {object} dto.SubtaskResponse
+// @Router /todos/{todoId}/subtasks/{subtaskId} [delete]
+func (sh *subtaskHandler) Delete(c *fiber.Ctx) error {
+
+	todoId, _ := strconv.Atoi(c.Params("todoId"))
+	subtaskId, _ := strconv.Atoi(c.Params("subtaskId"))
+
+	sr, err := sh.ss.Delete(uint(todoId), uint(subtaskId))
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(sr.Status).JSON(sr)
+}
+
+// Fetch implements SubtaskHandler.
+// Fetch godoc
+// @Summary Get subtasks
+// @Description Get all subtasks of a todo request
+// @Tags Subtasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param todoId path int true "todo id"
+// @Success 200 {object} dto.SubtaskResponse
+// @Router /todos/{todoId}/subtasks [get]
+func (sh *subtaskHandler) Fetch(c *fiber.Ctx) error {
+
+	todoId, _ := strconv.Atoi(c.Params("todoId"))
+
+	sr, err := sh.ss.Fetch(uint(todoId))
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(sr.Status).JSON(sr)
+}
+
+// Modify implements SubtaskHandler.
+// Modify godoc
+// @Summary Modify subtask
+// @Description Modify subtask request
+// @Tags Subtasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param todoId path int true "todo id"
+// @Param subtaskId path int true "subtask id"
+// @Param dto.ModifySubtask body dto.ModifySubtask true "body request for modify subtask"
+// @Success 200 {object} dto.SubtaskResponse
+// @Router /todos/{todoId}/subtasks/{subtaskId} [patch]
+func (sh *subtaskHandler) Modify(c *fiber.Ctx) error {
+	payload := &dto.ModifySubtask{}
+	todoId, _ := strconv.Atoi(c.Params("todoId"))
+	subtaskId, _ := strconv.Atoi(c.Params("subtaskId"))
+
+	if err := c.BodyParser(payload); err != nil {
+		invalidJson := errs.NewUnprocessableEntityError("invalid JSON body request")
+		return c.Status(invalidJson.Status()).JSON(invalidJson)
+	}
+
+	if err := helper.ValidateStruct(payload); err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	sr, err := sh.ss.Modify(uint(todoId), uint(subtaskId), payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(sr.Status).JSON(sr)
+}
+
+// Reorder implements SubtaskHandler.
+// Reorder godoc
+// @Summary Reorder subtasks
+// @Description Reorder subtasks request, subtask ids are ordered from top to bottom
+// @Tags Subtasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param todoId path int true "todo id"
+// @Param dto.ReorderSubtasks body dto.ReorderSubtasks true "body request for reorder subtasks"
+// @Success 200 {object} dto.SubtaskResponse
+// @Router /todos/{todoId}/subtasks/reorder [patch]
+func (sh *subtaskHandler) Reorder(c *fiber.Ctx) error {
+	payload := &dto.ReorderSubtasks{}
+	todoId, _ := strconv.Atoi(c.Params("todoId"))
+
+	if err := c.BodyParser(payload); err != nil {
+		invalidJson := errs.NewUnprocessableEntityError("invalid JSON body request")
+		return c.Status(invalidJson.Status()).JSON(invalidJson)
+	}
+
+	sr, err := sh.ss.Reorder(uint(todoId), payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(sr.Status).JSON(sr)
+}
+
+// Toggle implements SubtaskHandler.
+// Toggle godoc
+// @Summary Toggle subtask
+// @Description Toggle subtask done state request
+// @Tags Subtasks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param todoId path int true "todo id"
+// @Param subtaskId path int true "subtask id"
+// @Success 200 {object} dto.SubtaskResponse
+// @Router /todos/{todoId}/subtasks/{subtaskId}/toggle [patch]
+func (sh *subtaskHandler) Toggle(c *fiber.Ctx) error {
+
+	todoId, _ := strconv.Atoi(c.Params("todoId"))
+	subtaskId, _ := strconv.Atoi(c.Params("subtaskId"))
+
+	sr, err := sh.ss.Toggle(uint(todoId), uint(subtaskId))
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(sr.Status).JSON(sr)
+}
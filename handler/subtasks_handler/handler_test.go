@@ -0,0 +1,251 @@
+package subtasks_handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"todo-app/dto"
+	"todo-app/entity"
+	"todo-app/handler/subtasks_handler"
+	"todo-app/pkg/errs"
+	"todo-app/service/auth_service"
+	"todo-app/service/subtasks_service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var serviceMock = subtasks_service.NewServiceMock()
+var handler = subtasks_handler.NewSubtaskHandler(serviceMock)
+
+var app = fiber.New()
+
+var add = &dto.AddSubtask{
+	Title: "buy milk",
+}
+
+var user = entity.User{
+	Model: gorm.Model{
+		ID: 1,
+	},
+	Name:  "jihan",
+	Email: "jihan@weeekly.com",
+}
+
+func TestAddSuccess(t *testing.T) {
+	b, _ := json.Marshal(add)
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	auth_service.Authorization = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	subtasks_service.Add = func(todoId uint, payload *dto.AddSubtask) (*dto.SubtaskResponse, errs.Error) {
+		return &dto.SubtaskResponse{
+			Status:  fiber.StatusCreated,
+			Message: "subtask successfully added",
+		}, nil
+	}
+
+	app.Post("/todos/:todoId/subtasks", auth_service.Authentication(), auth_service.Authorization(), handler.Add)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/todos/1/subtasks", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusCreated, res.StatusCode)
+}
+
+func TestAddBadRequest(t *testing.T) {
+	b, _ := json.Marshal(&dto.AddSubtask{})
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	auth_service.Authorization = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	app.Post("/todos/:todoId/subtasks", auth_service.Authentication(), auth_service.Authorization(), handler.Add)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/todos/1/subtasks", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+}
+
+func TestFetchSuccess(t *testing.T) {
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	auth_service.Authorization = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	subtasks_service.Fetch = func(todoId uint) (*dto.SubtaskResponse, errs.Error) {
+		return &dto.SubtaskResponse{
+			Status:  fiber.StatusOK,
+			Message: "subtasks successfully fetched",
+		}, nil
+	}
+
+	app.Get("/todos/:todoId/subtasks", auth_service.Authentication(), auth_service.Authorization(), handler.Fetch)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/todos/1/subtasks", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestReorderSuccess(t *testing.T) {
+	b, _ := json.Marshal(&dto.ReorderSubtasks{SubtaskIds: []uint{2, 1}})
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	auth_service.Authorization = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	subtasks_service.Reorder = func(todoId uint, payload *dto.ReorderSubtasks) (*dto.SubtaskResponse, errs.Error) {
+		return &dto.SubtaskResponse{
+			Status:  fiber.StatusOK,
+			Message: "subtasks successfully reordered",
+		}, nil
+	}
+
+	app.Patch("/todos/:todoId/subtasks/reorder", auth_service.Authentication(), auth_service.Authorization(), handler.Reorder)
+
+	req := httptest.NewRequest(fiber.MethodPatch, "/todos/1/subtasks/reorder", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestToggleNotFound(t *testing.T) {
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	auth_service.Authorization = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	subtasks_service.Toggle = func(todoId uint, subtaskId uint) (*dto.SubtaskResponse, errs.Error) {
+		return nil, errs.NewNotFoundError("subtask not found")
+	}
+
+	app.Patch("/todos/:todoId/subtasks/:subtaskId/toggle", auth_service.Authentication(), auth_service.Authorization(), handler.Toggle)
+
+	req := httptest.NewRequest(fiber.MethodPatch, "/todos/1/subtasks/1/toggle", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+}
+
+func TestModifySuccess(t *testing.T) {
+	b, _ := json.Marshal(&dto.ModifySubtask{Title: "buy oat milk", Done: true})
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	auth_service.Authorization = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	subtasks_service.Modify = func(todoId uint, subtaskId uint, payload *dto.ModifySubtask) (*dto.SubtaskResponse, errs.Error) {
+		return &dto.SubtaskResponse{
+			Status:  fiber.StatusOK,
+			Message: "subtask successfully modified",
+		}, nil
+	}
+
+	app.Patch("/todos/:todoId/subtasks/:subtaskId", auth_service.Authentication(), auth_service.Authorization(), handler.Modify)
+
+	req := httptest.NewRequest(fiber.MethodPatch, "/todos/1/subtasks/1", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestDeleteSuccess(t *testing.T) {
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	auth_service.Authorization = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	subtasks_service.Delete = func(todoId uint, subtaskId uint) (*dto.SubtaskResponse, errs.Error) {
+		return &dto.SubtaskResponse{
+			Status:  fiber.StatusOK,
+			Message: "subtask successfully deleted",
+		}, nil
+	}
+
+	app.Delete("/todos/:todoId/subtasks/:subtaskId", auth_service.Authentication(), auth_service.Authorization(), handler.Delete)
+
+	req := httptest.NewRequest(fiber.MethodDelete, "/todos/1/subtasks/1", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
@@ -0,0 +1,166 @@
+package tags_handler
+
+import (
+	"strconv"
+	"todo-app/dto"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+	"todo-app/pkg/helper"
+	"todo-app/service/tags_service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type tagHandler struct {
+	ts tags_service.TagService
+}
+
+type TagHandler interface {
+	Add(c *fiber.Ctx) error
+	Detail(c *fiber.Ctx) error
+	Fetch(c *fiber.Ctx) error
+	Modify(c *fiber.Ctx) error
+	Delete(c *fiber.Ctx) error
+}
+
+func NewTagHandler(tagService tags_service.TagService) TagHandler {
+	return &tagHandler{ts: tagService}
+}
+
+// Add implements TagHandler.
+// Add godoc
+// @Summary Add tag
+// @Description Add tag request
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param dto.AddTag body dto.AddTag true "body request for add tag"
+// @Success 201 {object} dto.TagResponse
+// @Router /tags [post]
+func (th *tagHandler) Add(c *fiber.Ctx) error {
+	payload := &dto.AddTag{}
+	user := c.Locals("user").(entity.User)
+
+	if err := c.BodyParser(payload); err != nil {
+		invalidJson := errs.NewUnprocessableEntityError("invalid JSON body request")
+		return c.Status(invalidJson.Status()).JSON(invalidJson)
+	}
+
+	if err := helper.ValidateStruct(payload); err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	tr, err := th.ts.Add(user.ID, payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(tr.Status).JSON(tr)
+}
+
+// Delete implements TagHandler.
+// Delete godoc
+// @Summary Delete tag
+// @Description Delete tag request, the tag is removed from every todo
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param tagId path int true "tag id"
+// @Success 200 {object} dto.TagResponse
+// @Router /tags/{tagId} [delete]
+func (th *tagHandler) Delete(c *fiber.Ctx) error {
+
+	tagId, _ := strconv.Atoi(c.Params("tagId"))
+
+	tr, err := th.ts.Delete(uint(tagId))
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(tr.Status).JSON(tr)
+}
+
+// Detail implements TagHandler.
+// Detail godoc
+// @Summary Detail tag
+// @Description Detail tag request
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param tagId path int true "tag id"
+// @Success 200 {object} dto.TagResponse
+// @Router /tags/{tagId} [get]
+func (th *tagHandler) Detail(c *fiber.Ctx) error {
+
+	tagId, _ := strconv.Atoi(c.Params("tagId"))
+
+	tr, err := th.ts.Detail(uint(tagId))
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(tr.Status).JSON(tr)
+}
+
+// Fetch implements TagHandler.
+// Fetch godoc
+// @Summary Get all tags
+// @Description Get all tags request
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Success 200 {object} dto.TagResponse
+// @Router /tags [get]
+func (th *tagHandler) Fetch(c *fiber.Ctx) error {
+
+	user := c.Locals("user").(entity.User)
+
+	tr, err := th.ts.Fetch(user.ID)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(tr.Status).JSON(tr)
+}
+
+// Modify implements TagHandler.
+// Modify godoc
+// @Summary Modify tag
+// @Description Modify tag request
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param tagId path int true "tag id"
+// @Param dto.ModifyTag body dto.ModifyTag true "body request for modify tag"
+// @Success 200 {object} dto.TagResponse
+// @Router /tags/{tagId} [patch]
+func (th *tagHandler) Modify(c *fiber.Ctx) error {
+	payload := &dto.ModifyTag{}
+	tagId, _ := strconv.Atoi(c.Params("tagId"))
+
+	if err := c.BodyParser(payload); err != nil {
+		invalidJson := errs.NewUnprocessableEntityError("invalid JSON body request")
+		return c.Status(invalidJson.Status()).JSON(invalidJson)
+	}
+
+	if err := helper.ValidateStruct(payload); err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	tr, err := th.ts.Modify(uint(tagId), payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(tr.Status).JSON(tr)
+}
@@ -0,0 +1,224 @@
+package tags_handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"todo-app/dto"
+	"todo-app/entity"
+	"todo-app/handler/tags_handler"
+	"todo-app/pkg/errs"
+	"todo-app/service/auth_service"
+	"todo-app/service/tags_service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var serviceMock = tags_service.NewServiceMock()
+var handler = tags_handler.NewTagHandler(serviceMock)
+
+var app = fiber.New()
+
+var add = &dto.AddTag{
+	Name:  "work",
+	Color: "#ff0000",
+}
+
+var modify = &dto.ModifyTag{
+	Name: "home",
+}
+
+var user = entity.User{
+	Model: gorm.Model{
+		ID: 1,
+	},
+	Name:  "jihan",
+	Email: "jihan@weeekly.com",
+}
+
+func TestAddSuccess(t *testing.T) {
+	b, _ := json.Marshal(add)
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	tags_service.Add = func(userId uint, payload *dto.AddTag) (*dto.TagResponse, errs.Error) {
+		return &dto.TagResponse{
+			Status:  fiber.StatusCreated,
+			Message: "tag successfully added",
+		}, nil
+	}
+
+	app.Post("/tags", auth_service.Authentication(), handler.Add)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/tags", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusCreated, res.StatusCode)
+}
+
+func TestAddBadRequest(t *testing.T) {
+	b, _ := json.Marshal(&dto.AddTag{Name: "work", Color: "red"})
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	app.Post("/tags", auth_service.Authentication(), handler.Add)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/tags", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+}
+
+func TestAddInvalidJSON(t *testing.T) {
+	b, _ := json.Marshal(add)
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	app.Post("/tags", auth_service.Authentication(), handler.Add)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/tags", bytes.NewReader(b))
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusUnprocessableEntity, res.StatusCode)
+}
+
+func TestFetchSuccess(t *testing.T) {
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	tags_service.Fetch = func(userId uint) (*dto.TagResponse, errs.Error) {
+		return &dto.TagResponse{
+			Status:  fiber.StatusOK,
+			Message: "tags successfully fetched",
+		}, nil
+	}
+
+	app.Get("/tags", auth_service.Authentication(), handler.Fetch)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/tags", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestDetailNotFound(t *testing.T) {
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	auth_service.TagAuthorization = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	tags_service.Detail = func(tagId uint) (*dto.TagResponse, errs.Error) {
+		return nil, errs.NewNotFoundError("tag not found")
+	}
+
+	app.Get("/tags/:tagId", auth_service.Authentication(), auth_service.TagAuthorization(), handler.Detail)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/tags/1", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+}
+
+func TestModifySuccess(t *testing.T) {
+	b, _ := json.Marshal(modify)
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	auth_service.TagAuthorization = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	tags_service.Modify = func(tagId uint, payload *dto.ModifyTag) (*dto.TagResponse, errs.Error) {
+		return &dto.TagResponse{
+			Status:  fiber.StatusOK,
+			Message: "tag successfully modified",
+		}, nil
+	}
+
+	app.Patch("/tags/:tagId", auth_service.Authentication(), auth_service.TagAuthorization(), handler.Modify)
+
+	req := httptest.NewRequest(fiber.MethodPatch, "/tags/1", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestDeleteSuccess(t *testing.T) {
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	auth_service.TagAuthorization = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	tags_service.Delete = func(tagId uint) (*dto.TagResponse, errs.Error) {
+		return &dto.TagResponse{
+			Status:  fiber.StatusOK,
+			Message: "tag successfully deleted",
+		}, nil
+	}
+
+	app.Delete("/tags/:tagId", auth_service.Authentication(), auth_service.TagAuthorization(), handler.Delete)
+
+	req := httptest.NewRequest(fiber.MethodDelete, "/tags/1", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
@@ -20,7 +20,17 @@ type TodoHandler interface {
 	Detail(c *fiber.Ctx) error
 	Fetch(c *fiber.Ctx) error
 	Modify(c *fiber.Ctx) error
+	ModifyStatus(c *fiber.Ctx) error
+	Assign(c *fiber.Ctx) error
+	Unassign(c *fiber.Ctx) error
 	Delete(c *fiber.Ctx) error
+	Reorder(c *fiber.Ctx) error
+	Search(c *fiber.Ctx) error
+	Skip(c *fiber.Ctx) error
+	Trash(c *fiber.Ctx) error
+	Restore(c *fiber.Ctx) error
+	Purge(c *fiber.Ctx) error
+	Bulk(c *fiber.Ctx) error
 }
 
 func NewTodoHandler(todoService todos_service.TodoService) TodoHandler {
@@ -35,6 +45,7 @@ func NewTodoHandler(todoService todos_service.TodoService) TodoHandler {
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer Token"
+// @Param X-Workspace-ID header int false "workspace id, the personal space when omitted"
 // @Param dto.AddTodo body dto.AddTodo true "body request for add todo"
 // @Success 201 {object} dto.TodoResponse
 // @Router /todos [post]
@@ -51,7 +62,7 @@ func (th *todoHandler) Add(c *fiber.Ctx) error {
 		return c.Status(err.Status()).JSON(err)
 	}
 
-	tr, err := th.ts.Add(user.ID, payload)
+	tr, err := th.ts.Add(user.ID, user.WorkspaceID, payload)
 
 	if err != nil {
 		return c.Status(err.Status()).JSON(err)
@@ -116,13 +127,34 @@ func (th *todoHandler) Detail(c *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer Token"
+// @Param X-Workspace-ID header int false "workspace id, the personal space when omitted"
+// @Param due query string false "due view" Enums(overdue, today, week)
+// @Param tz query string false "timezone used for the due view, default UTC"
+// @Param tags query []int false "tag ids" collectionFormat(multi)
+// @Param tag_mode query string false "match any or all of the tags, default any" Enums(any, all)
+// @Param q query string false "text search"
+// @Param status query string false "todo status" Enums(done, open)
+// @Param assignee query string false "todos assigned to the user, from every workspace when no workspace is selected" Enums(me)
+// @Param created_from query string false "created on or after, 2006-01-02 or RFC 3339"
+// @Param created_to query string false "created on or before, 2006-01-02 or RFC 3339"
+// @Param updated_from query string false "updated on or after, 2006-01-02 or RFC 3339"
+// @Param updated_to query string false "updated on or before, 2006-01-02 or RFC 3339"
+// @Param sort query string false "sort field, default position" Enums(position, priority, due_at, created_at, updated_at)
+// @Param order query string false "sort direction, default asc (desc for priority)" Enums(asc, desc)
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param limit query int false "page size between 1 and 100, default 50"
 // @Success 200 {object} dto.TodoResponse
 // @Router /todos/ [get]
 func (th *todoHandler) Fetch(c *fiber.Ctx) error {
-
+	query := &dto.TodoQuery{}
 	user := c.Locals("user").(entity.User)
 
-	tr, err := th.ts.Fetch(user.ID)
+	if err := c.QueryParser(query); err != nil {
+		invalidQuery := errs.NewBadRequestError("invalid query parameter")
+		return c.Status(invalidQuery.Status()).JSON(invalidQuery)
+	}
+
+	tr, err := th.ts.Fetch(user.ID, user.WorkspaceID, query)
 
 	if err != nil {
 		return c.Status(err.Status()).JSON(err)
@@ -164,3 +196,281 @@ func (th *todoHandler) Modify(c *fiber.Ctx) error {
 
 	return c.Status(tr.Status).JSON(tr)
 }
+
+// ModifyStatus implements TodoHandler.
+// ModifyStatus godoc
+// @Summary Modify todo status
+// @Description Complete or reopen a todo, the assignee of the todo can do it without edit access
+// @Tags Todos
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param todoId path int true "todo id"
+// @Param dto.ModifyStatus body dto.ModifyStatus true "body request for modify todo status"
+// @Success 200 {object} dto.TodoResponse
+// @Router /todos/{todoId}/status [patch]
+func (th *todoHandler) ModifyStatus(c *fiber.Ctx) error {
+	payload := &dto.ModifyStatus{}
+	todoId, _ := strconv.Atoi(c.Params("todoId"))
+
+	if err := c.BodyParser(payload); err != nil {
+		invalidJson := errs.NewUnprocessableEntityError("invalid JSON body request")
+		return c.Status(invalidJson.Status()).JSON(invalidJson)
+	}
+
+	tr, err := th.ts.ModifyStatus(uint(todoId), payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(tr.Status).JSON(tr)
+}
+
+// Assign implements TodoHandler.
+// Assign godoc
+// @Summary Assign todo
+// @Description Assign a todo to a user who can see it, the assignee is notified by mail
+// @Tags Todos
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param todoId path int true "todo id"
+// @Param dto.AssignTodo body dto.AssignTodo true "body request for assign todo"
+// @Success 200 {object} dto.TodoResponse
+// @Router /todos/{todoId}/assignee [post]
+func (th *todoHandler) Assign(c *fiber.Ctx) error {
+	user := c.Locals("user").(entity.User)
+	payload := &dto.AssignTodo{}
+	todoId, _ := strconv.Atoi(c.Params("todoId"))
+
+	if err := c.BodyParser(payload); err != nil {
+		invalidJson := errs.NewUnprocessableEntityError("invalid JSON body request")
+		return c.Status(invalidJson.Status()).JSON(invalidJson)
+	}
+
+	if err := helper.ValidateStruct(payload); err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	tr, err := th.ts.Assign(user.ID, uint(todoId), payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(tr.Status).JSON(tr)
+}
+
+// Unassign implements TodoHandler.
+// Unassign godoc
+// @Summary Unassign todo
+// @Description Remove the assignee of a todo
+// @Tags Todos
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param todoId path int true "todo id"
+// @Success 200 {object} dto.TodoResponse
+// @Router /todos/{todoId}/assignee [delete]
+func (th *todoHandler) Unassign(c *fiber.Ctx) error {
+
+	todoId, _ := strconv.Atoi(c.Params("todoId"))
+
+	tr, err := th.ts.Unassign(uint(todoId))
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(tr.Status).JSON(tr)
+}
+
+// Reorder implements TodoHandler.
+// Reorder godoc
+// @Summary Reorder todos
+// @Description Reorder todos request, todo ids are ordered from top to bottom
+// @Tags Todos
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param dto.ReorderTodos body dto.ReorderTodos true "body request for reorder todos"
+// @Success 200 {object} dto.TodoResponse
+// @Router /todos/reorder [patch]
+func (th *todoHandler) Reorder(c *fiber.Ctx) error {
+	payload := &dto.ReorderTodos{}
+	user := c.Locals("user").(entity.User)
+
+	if err := c.BodyParser(payload); err != nil {
+		invalidJson := errs.NewUnprocessableEntityError("invalid JSON body request")
+		return c.Status(invalidJson.Status()).JSON(invalidJson)
+	}
+
+	tr, err := th.ts.Reorder(user.ID, payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(tr.Status).JSON(tr)
+}
+
+// Search implements TodoHandler.
+// Search godoc
+// @Summary Search todos
+// @Description Full text search on todos ranked by relevance, every word matches as a prefix. Matching words in the snippet are wrapped in <mark> tags, the todo text itself is not HTML escaped
+// @Tags Todos
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param X-Workspace-ID header int false "workspace id, the personal space when omitted"
+// @Param q query string true "search text"
+// @Param limit query int false "number of results between 1 and 100, default 50"
+// @Success 200 {object} dto.TodoResponse
+// @Router /todos/search [get]
+func (th *todoHandler) Search(c *fiber.Ctx) error {
+	query := &dto.SearchQuery{}
+	user := c.Locals("user").(entity.User)
+
+	if err := c.QueryParser(query); err != nil {
+		invalidQuery := errs.NewBadRequestError("invalid query parameter")
+		return c.Status(invalidQuery.Status()).JSON(invalidQuery)
+	}
+
+	tr, err := th.ts.Search(user.ID, user.WorkspaceID, query)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(tr.Status).JSON(tr)
+}
+
+// Skip implements TodoHandler.
+// Skip godoc
+// @Summary Skip todo occurrence
+// @Description Skip the current occurrence of a recurring todo, moving its due date to the next occurrence
+// @Tags Todos
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param todoId path int true "todo id"
+// @Success 200 {object} dto.TodoResponse
+// @Router /todos/{todoId}/skip [patch]
+func (th *todoHandler) Skip(c *fiber.Ctx) error {
+
+	todoId, _ := strconv.Atoi(c.Params("todoId"))
+
+	tr, err := th.ts.Skip(uint(todoId))
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(tr.Status).JSON(tr)
+}
+
+// Trash implements TodoHandler.
+// Trash godoc
+// @Summary Get trashed todos
+// @Description Get deleted todos that can still be restored request
+// @Tags Todos
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param X-Workspace-ID header int false "workspace id, the personal space when omitted"
+// @Success 200 {object} dto.TodoResponse
+// @Router /todos/trash [get]
+func (th *todoHandler) Trash(c *fiber.Ctx) error {
+	user := c.Locals("user").(entity.User)
+
+	tr, err := th.ts.Trash(user.ID, user.WorkspaceID)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(tr.Status).JSON(tr)
+}
+
+// Restore implements TodoHandler.
+// Restore godoc
+// @Summary Restore todo
+// @Description Restore todo from the trash request
+// @Tags Todos
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param todoId path int true "todo id"
+// @Success 200 {object} dto.TodoResponse
+// @Router /todos/{todoId}/restore [post]
+func (th *todoHandler) Restore(c *fiber.Ctx) error {
+
+	todoId, _ := strconv.Atoi(c.Params("todoId"))
+
+	tr, err := th.ts.Restore(uint(todoId))
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(tr.Status).JSON(tr)
+}
+
+// Purge implements TodoHandler.
+// Purge godoc
+// @Summary Purge todo
+// @Description Permanently delete todo from the trash request
+// @Tags Todos
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param todoId path int true "todo id"
+// @Success 200 {object} dto.TodoResponse
+// @Router /todos/{todoId}/purge [delete]
+func (th *todoHandler) Purge(c *fiber.Ctx) error {
+
+	todoId, _ := strconv.Atoi(c.Params("todoId"))
+
+	tr, err := th.ts.Purge(uint(todoId))
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(tr.Status).JSON(tr)
+}
+
+// Bulk implements TodoHandler.
+// Bulk godoc
+// @Summary Bulk todos
+// @Description Apply one action (complete, reopen, delete, move or tag) to many todos in a single transaction with a result per todo. With all_or_nothing a single failure rolls back every todo and responds 422
+// @Tags Todos
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param dto.BulkTodos body dto.BulkTodos true "body request for bulk todos"
+// @Success 200 {object} dto.TodoResponse
+// @Failure 422 {object} dto.TodoResponse
+// @Router /todos/bulk [post]
+func (th *todoHandler) Bulk(c *fiber.Ctx) error {
+	payload := &dto.BulkTodos{}
+	user := c.Locals("user").(entity.User)
+
+	if err := c.BodyParser(payload); err != nil {
+		invalidJson := errs.NewUnprocessableEntityError("invalid JSON body request")
+		return c.Status(invalidJson.Status()).JSON(invalidJson)
+	}
+
+	if err := helper.ValidateStruct(payload); err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	tr, err := th.ts.Bulk(user.ID, payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(tr.Status).JSON(tr)
+}
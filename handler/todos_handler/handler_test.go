@@ -53,7 +53,7 @@ func TestAddSuccess(t *testing.T) {
 		}
 	}
 
-	todos_service.Add = func(userId uint, payload *dto.AddTodo) (*dto.TodoResponse, errs.Error) {
+	todos_service.Add = func(userId uint, workspaceId uint, payload *dto.AddTodo) (*dto.TodoResponse, errs.Error) {
 		return &dto.TodoResponse{
 			Status:  fiber.StatusCreated,
 			Message: "todo successfully added",
@@ -80,7 +80,7 @@ func TestAddServerError(t *testing.T) {
 		}
 	}
 
-	todos_service.Add = func(userId uint, payload *dto.AddTodo) (*dto.TodoResponse, errs.Error) {
+	todos_service.Add = func(userId uint, workspaceId uint, payload *dto.AddTodo) (*dto.TodoResponse, errs.Error) {
 		return nil, errs.NewInternalServerError("something went wrong")
 	}
 
@@ -142,10 +142,14 @@ func TestFetchSuccess(t *testing.T) {
 		}
 	}
 
-	todos_service.Fetch = func(userId uint) (*dto.TodoResponse, errs.Error) {
+	todos_service.Fetch = func(userId uint, workspaceId uint, query *dto.TodoQuery) (*dto.TodoResponse, errs.Error) {
+		assert.Equal(t, 0, query.Limit)
+		nextCursor := ""
 		return &dto.TodoResponse{
-			Status:  fiber.StatusOK,
-			Message: "todos successfully fetched",
+			Status:     fiber.StatusOK,
+			Message:    "todos successfully fetched",
+			Data:       []*dto.Todo{},
+			NextCursor: &nextCursor,
 		}, nil
 	}
 
@@ -156,7 +160,11 @@ func TestFetchSuccess(t *testing.T) {
 
 	res, _ := app.Test(req, 1)
 
+	body := map[string]any{}
+	json.NewDecoder(res.Body).Decode(&body)
+
 	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	assert.Contains(t, body, "next_cursor")
 }
 
 func TestFetchServerError(t *testing.T) {
@@ -168,7 +176,7 @@ func TestFetchServerError(t *testing.T) {
 		}
 	}
 
-	todos_service.Fetch = func(userId uint) (*dto.TodoResponse, errs.Error) {
+	todos_service.Fetch = func(userId uint, workspaceId uint, query *dto.TodoQuery) (*dto.TodoResponse, errs.Error) {
 		return nil, errs.NewInternalServerError("something went wrong")
 	}
 
@@ -514,3 +522,397 @@ func TestModifyServerError(t *testing.T) {
 
 	assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
 }
+
+func TestReorderSuccess(t *testing.T) {
+
+	b, _ := json.Marshal(&dto.ReorderTodos{TodoIds: []uint{2, 1}})
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	todos_service.Reorder = func(userId uint, payload *dto.ReorderTodos) (*dto.TodoResponse, errs.Error) {
+		return &dto.TodoResponse{
+			Status:  fiber.StatusOK,
+			Message: "todos successfully reordered",
+		}, nil
+	}
+
+	// a new app is used since "/todos/:todoId" is already registered on the shared one
+	app := fiber.New()
+	app.Patch("/todos/reorder", auth_service.Authentication(), handler.Reorder)
+
+	req := httptest.NewRequest(fiber.MethodPatch, "/todos/reorder", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestReorderInvalidJSON(t *testing.T) {
+
+	b, _ := json.Marshal(&dto.ReorderTodos{TodoIds: []uint{2, 1}})
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	// a new app is used since "/todos/:todoId" is already registered on the shared one
+	app := fiber.New()
+	app.Patch("/todos/reorder", auth_service.Authentication(), handler.Reorder)
+
+	req := httptest.NewRequest(fiber.MethodPatch, "/todos/reorder", bytes.NewReader(b))
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusUnprocessableEntity, res.StatusCode)
+}
+
+func TestSkipSuccess(t *testing.T) {
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	auth_service.Authorization = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	todos_service.Skip = func(todoId uint) (*dto.TodoResponse, errs.Error) {
+		return &dto.TodoResponse{
+			Status:  fiber.StatusOK,
+			Message: "todo occurrence successfully skipped",
+		}, nil
+	}
+
+	app.Patch("/todos/:todoId/skip", auth_service.Authentication(), auth_service.Authorization(), handler.Skip)
+
+	req := httptest.NewRequest(fiber.MethodPatch, "/todos/1/skip", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestSkipBadRequest(t *testing.T) {
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	auth_service.Authorization = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	todos_service.Skip = func(todoId uint) (*dto.TodoResponse, errs.Error) {
+		return nil, errs.NewBadRequestError("todo is not recurring")
+	}
+
+	app.Patch("/todos/:todoId/skip", auth_service.Authentication(), auth_service.Authorization(), handler.Skip)
+
+	req := httptest.NewRequest(fiber.MethodPatch, "/todos/1/skip", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+}
+
+func TestSearchSuccess(t *testing.T) {
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	todos_service.Search = func(userId uint, workspaceId uint, query *dto.SearchQuery) (*dto.TodoResponse, errs.Error) {
+		assert.Equal(t, "pay rent", query.Q)
+		return &dto.TodoResponse{
+			Status:  fiber.StatusOK,
+			Message: "todos successfully searched",
+		}, nil
+	}
+
+	// a new app is used since "/todos/:todoId" is already registered on the shared one
+	app := fiber.New()
+	app.Get("/todos/search", auth_service.Authentication(), handler.Search)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/todos/search?q=pay+rent", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestSearchBadRequest(t *testing.T) {
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	todos_service.Search = func(userId uint, workspaceId uint, query *dto.SearchQuery) (*dto.TodoResponse, errs.Error) {
+		return nil, errs.NewBadRequestError("q can't be empty")
+	}
+
+	// a new app is used since "/todos/:todoId" is already registered on the shared one
+	app := fiber.New()
+	app.Get("/todos/search", auth_service.Authentication(), handler.Search)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/todos/search", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+}
+
+func TestTrashSuccess(t *testing.T) {
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	todos_service.Trash = func(userId uint, workspaceId uint) (*dto.TodoResponse, errs.Error) {
+		return &dto.TodoResponse{
+			Status:  fiber.StatusOK,
+			Message: "trashed todos successfully fetched",
+		}, nil
+	}
+
+	// a new app is used since "/todos/:todoId" is already registered on the shared one
+	app := fiber.New()
+	app.Get("/todos/trash", auth_service.Authentication(), handler.Trash)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/todos/trash", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestRestoreSuccess(t *testing.T) {
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	auth_service.TrashAuthorization = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	todos_service.Restore = func(todoId uint) (*dto.TodoResponse, errs.Error) {
+		return &dto.TodoResponse{
+			Status:  fiber.StatusOK,
+			Message: "todo successfully restored",
+		}, nil
+	}
+
+	app.Post("/todos/:todoId/restore", auth_service.Authentication(), auth_service.TrashAuthorization(), handler.Restore)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/todos/1/restore", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestPurgeNotFound(t *testing.T) {
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	auth_service.TrashAuthorization = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			err := errs.NewNotFoundError("todo not found in trash")
+			return c.Status(err.Status()).JSON(err)
+		}
+	}
+
+	app.Delete("/todos/:todoId/purge", auth_service.Authentication(), auth_service.TrashAuthorization(), handler.Purge)
+
+	req := httptest.NewRequest(fiber.MethodDelete, "/todos/1/purge", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+}
+
+func TestBulkSuccess(t *testing.T) {
+
+	b, _ := json.Marshal(&dto.BulkTodos{TodoIds: []uint{1, 2}, Action: "complete"})
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	todos_service.Bulk = func(userId uint, payload *dto.BulkTodos) (*dto.TodoResponse, errs.Error) {
+		return &dto.TodoResponse{
+			Status:  fiber.StatusOK,
+			Message: "todos successfully processed",
+		}, nil
+	}
+
+	app.Post("/todos/bulk", auth_service.Authentication(), handler.Bulk)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/todos/bulk", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestBulkBadRequest(t *testing.T) {
+
+	b, _ := json.Marshal(&dto.BulkTodos{TodoIds: []uint{1, 2}})
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	app.Post("/todos/bulk", auth_service.Authentication(), handler.Bulk)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/todos/bulk", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+}
+
+func TestModifyStatusSuccess(t *testing.T) {
+
+	b, _ := json.Marshal(&dto.ModifyStatus{Status: true})
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	todos_service.ModifyStatus = func(todoId uint, payload *dto.ModifyStatus) (*dto.TodoResponse, errs.Error) {
+		return &dto.TodoResponse{
+			Status:  fiber.StatusOK,
+			Message: "todo status successfully modified",
+		}, nil
+	}
+
+	app.Patch("/todos/:todoId/status", auth_service.Authentication(), handler.ModifyStatus)
+
+	req := httptest.NewRequest(fiber.MethodPatch, "/todos/1/status", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestAssignSuccess(t *testing.T) {
+
+	b, _ := json.Marshal(&dto.AssignTodo{UserId: 2})
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	todos_service.Assign = func(userId uint, todoId uint, payload *dto.AssignTodo) (*dto.TodoResponse, errs.Error) {
+		return &dto.TodoResponse{
+			Status:  fiber.StatusOK,
+			Message: "todo successfully assigned",
+		}, nil
+	}
+
+	app.Post("/todos/:todoId/assignee", auth_service.Authentication(), handler.Assign)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/todos/1/assignee", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestAssignBadRequest(t *testing.T) {
+
+	b, _ := json.Marshal(&dto.AssignTodo{})
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	app.Post("/todos/:todoId/assignee", auth_service.Authentication(), handler.Assign)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/todos/1/assignee", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+}
+
+func TestUnassignNotFound(t *testing.T) {
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	todos_service.Unassign = func(todoId uint) (*dto.TodoResponse, errs.Error) {
+		return nil, errs.NewNotFoundError("todo not found")
+	}
+
+	app.Delete("/todos/:todoId/assignee", auth_service.Authentication(), handler.Unassign)
+
+	req := httptest.NewRequest(fiber.MethodDelete, "/todos/1/assignee", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+}
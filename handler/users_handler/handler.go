@@ -1,6 +1,7 @@
 package users_handler
 
 import (
+	"strconv"
 	"todo-app/dto"
 	"todo-app/entity"
 	"todo-app/pkg/errs"
@@ -20,6 +21,23 @@ type UserHandler interface {
 	Login(c *fiber.Ctx) error
 	Profile(c *fiber.Ctx) error
 	Modify(c *fiber.Ctx) error
+	ChangePassword(c *fiber.Ctx) error
+	ForgotPassword(c *fiber.Ctx) error
+	ResetPassword(c *fiber.Ctx) error
+	VerifyEmail(c *fiber.Ctx) error
+	ResendVerification(c *fiber.Ctx) error
+	Refresh(c *fiber.Ctx) error
+	Logout(c *fiber.Ctx) error
+	Sessions(c *fiber.Ctx) error
+	RevokeSession(c *fiber.Ctx) error
+	RevokeSessions(c *fiber.Ctx) error
+	LoginMFA(c *fiber.Ctx) error
+	EnrollTOTP(c *fiber.Ctx) error
+	ConfirmTOTP(c *fiber.Ctx) error
+	DisableTOTP(c *fiber.Ctx) error
+	AddPersonalToken(c *fiber.Ctx) error
+	PersonalTokens(c *fiber.Ctx) error
+	RevokePersonalToken(c *fiber.Ctx) error
 }
 
 func NewUserHandler(userService users_service.UserService) UserHandler {
@@ -35,6 +53,7 @@ func NewUserHandler(userService users_service.UserService) UserHandler {
 // @Produce json
 // @Param dto.Login body dto.Login true "body request for user login"
 // @Success 200 {object} dto.UserResponse
+// @Failure 429 {object} errs.RetryAfterError
 // @Router /users/login [post]
 func (uh *userHandler) Login(c *fiber.Ctx) error {
 	payload := &dto.Login{}
@@ -44,6 +63,9 @@ func (uh *userHandler) Login(c *fiber.Ctx) error {
 		return c.Status(invalidJSON.Status()).JSON(invalidJSON)
 	}
 
+	payload.IP = c.IP()
+	payload.UserAgent = c.Get(fiber.HeaderUserAgent)
+
 	err := helper.ValidateStruct(payload)
 
 	if err != nil {
@@ -53,6 +75,9 @@ func (uh *userHandler) Login(c *fiber.Ctx) error {
 	ur, err := uh.us.Login(payload)
 
 	if err != nil {
+		if retryErr, ok := err.(*errs.RetryAfterError); ok {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(retryErr.RetryAfter))
+		}
 		return c.Status(err.Status()).JSON(err)
 	}
 
@@ -93,6 +118,173 @@ func (uh *userHandler) Modify(c *fiber.Ctx) error {
 	return c.Status(ur.Status).JSON(ur)
 }
 
+// ChangePassword implements UserHandler.
+// ChangePassword godoc
+// @Summary User change password
+// @Description Change the password of the user and log every other session out
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param dto.ChangePassword body dto.ChangePassword true "body request for user change password"
+// @Success 200 {object} dto.UserResponse
+// @Router /users/password [patch]
+func (uh *userHandler) ChangePassword(c *fiber.Ctx) error {
+	payload := &dto.ChangePassword{}
+	user := c.Locals("user").(entity.User)
+
+	if err := c.BodyParser(payload); err != nil {
+		invalidJSON := errs.NewUnprocessableEntityError("invalid JSON body request")
+		return c.Status(invalidJSON.Status()).JSON(invalidJSON)
+	}
+
+	err := helper.ValidateStruct(payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	ur, err := uh.us.ChangePassword(user.ID, user.SessionID, payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(ur.Status).JSON(ur)
+}
+
+// ForgotPassword implements UserHandler.
+// ForgotPassword godoc
+// @Summary User forgot password
+// @Description Mail a password reset link, the response is the same whether the email is registered or not
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param dto.ForgotPassword body dto.ForgotPassword true "body request for user forgot password"
+// @Success 200 {object} dto.UserResponse
+// @Router /users/password/forgot [post]
+func (uh *userHandler) ForgotPassword(c *fiber.Ctx) error {
+	payload := &dto.ForgotPassword{}
+
+	if err := c.BodyParser(payload); err != nil {
+		invalidJSON := errs.NewUnprocessableEntityError("invalid JSON body request")
+		return c.Status(invalidJSON.Status()).JSON(invalidJSON)
+	}
+
+	err := helper.ValidateStruct(payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	ur, err := uh.us.ForgotPassword(payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(ur.Status).JSON(ur)
+}
+
+// ResetPassword implements UserHandler.
+// ResetPassword godoc
+// @Summary User reset password
+// @Description Set a new password with a mailed reset token and log every session out
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param dto.ResetPassword body dto.ResetPassword true "body request for user reset password"
+// @Success 200 {object} dto.UserResponse
+// @Router /users/password/reset [post]
+func (uh *userHandler) ResetPassword(c *fiber.Ctx) error {
+	payload := &dto.ResetPassword{}
+
+	if err := c.BodyParser(payload); err != nil {
+		invalidJSON := errs.NewUnprocessableEntityError("invalid JSON body request")
+		return c.Status(invalidJSON.Status()).JSON(invalidJSON)
+	}
+
+	err := helper.ValidateStruct(payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	ur, err := uh.us.ResetPassword(payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(ur.Status).JSON(ur)
+}
+
+// VerifyEmail implements UserHandler.
+// VerifyEmail godoc
+// @Summary User verify email
+// @Description Confirm the email of a new account or a changed email with a mailed token
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param dto.VerifyEmail body dto.VerifyEmail true "body request for user verify email"
+// @Success 200 {object} dto.UserResponse
+// @Router /users/email/verify [post]
+func (uh *userHandler) VerifyEmail(c *fiber.Ctx) error {
+	payload := &dto.VerifyEmail{}
+
+	if err := c.BodyParser(payload); err != nil {
+		invalidJSON := errs.NewUnprocessableEntityError("invalid JSON body request")
+		return c.Status(invalidJSON.Status()).JSON(invalidJSON)
+	}
+
+	err := helper.ValidateStruct(payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	ur, err := uh.us.VerifyEmail(payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(ur.Status).JSON(ur)
+}
+
+// ResendVerification implements UserHandler.
+// ResendVerification godoc
+// @Summary User resend verification
+// @Description Mail a new verification link to an unverified account, the response is the same whether the email is registered or not
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param dto.ResendVerification body dto.ResendVerification true "body request for user resend verification"
+// @Success 200 {object} dto.UserResponse
+// @Router /users/email/resend [post]
+func (uh *userHandler) ResendVerification(c *fiber.Ctx) error {
+	payload := &dto.ResendVerification{}
+
+	if err := c.BodyParser(payload); err != nil {
+		invalidJSON := errs.NewUnprocessableEntityError("invalid JSON body request")
+		return c.Status(invalidJSON.Status()).JSON(invalidJSON)
+	}
+
+	err := helper.ValidateStruct(payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	ur, err := uh.us.ResendVerification(payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(ur.Status).JSON(ur)
+}
+
 // Profile implements UserHandler.
 // Profile godoc
 // @Summary User profile
@@ -147,3 +339,339 @@ func (uh *userHandler) Register(c *fiber.Ctx) error {
 
 	return c.Status(ur.Status).JSON(ur)
 }
+
+// Refresh implements UserHandler.
+// Refresh godoc
+// @Summary User refresh token
+// @Description Exchange a refresh token for a new access token and refresh token
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param dto.RefreshToken body dto.RefreshToken true "body request for user refresh token"
+// @Success 200 {object} dto.UserResponse
+// @Router /users/refresh [post]
+func (uh *userHandler) Refresh(c *fiber.Ctx) error {
+	payload := &dto.RefreshToken{}
+
+	if err := c.BodyParser(payload); err != nil {
+		invalidJSON := errs.NewUnprocessableEntityError("invalid JSON body request")
+		return c.Status(invalidJSON.Status()).JSON(invalidJSON)
+	}
+
+	err := helper.ValidateStruct(payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	ur, err := uh.us.Refresh(payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(ur.Status).JSON(ur)
+}
+
+// Logout implements UserHandler.
+// Logout godoc
+// @Summary User logout
+// @Description Revoke the current session and its refresh tokens
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Success 200 {object} dto.UserResponse
+// @Router /users/logout [post]
+func (uh *userHandler) Logout(c *fiber.Ctx) error {
+	user := c.Locals("user").(entity.User)
+
+	ur, err := uh.us.Logout(user.SessionID)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(ur.Status).JSON(ur)
+}
+
+// Sessions implements UserHandler.
+// Sessions godoc
+// @Summary User sessions
+// @Description List the active sessions of the user
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Success 200 {object} dto.UserResponse
+// @Router /users/sessions [get]
+func (uh *userHandler) Sessions(c *fiber.Ctx) error {
+	user := c.Locals("user").(entity.User)
+
+	ur, err := uh.us.Sessions(user.ID, user.SessionID)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(ur.Status).JSON(ur)
+}
+
+// RevokeSession implements UserHandler.
+// RevokeSession godoc
+// @Summary User revoke session
+// @Description Log a session of the user out
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param sessionId path int true "session id"
+// @Success 200 {object} dto.UserResponse
+// @Router /users/sessions/{sessionId} [delete]
+func (uh *userHandler) RevokeSession(c *fiber.Ctx) error {
+	user := c.Locals("user").(entity.User)
+	sessionId, _ := strconv.Atoi(c.Params("sessionId"))
+
+	ur, err := uh.us.RevokeSession(user.ID, uint(sessionId))
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(ur.Status).JSON(ur)
+}
+
+// RevokeSessions implements UserHandler.
+// RevokeSessions godoc
+// @Summary User logout everywhere
+// @Description Log every session of the user out, including the current one
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Success 200 {object} dto.UserResponse
+// @Router /users/sessions [delete]
+func (uh *userHandler) RevokeSessions(c *fiber.Ctx) error {
+	user := c.Locals("user").(entity.User)
+
+	ur, err := uh.us.RevokeSessions(user.ID)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(ur.Status).JSON(ur)
+}
+
+// LoginMFA implements UserHandler.
+// LoginMFA godoc
+// @Summary User login second step
+// @Description Exchange the MFA token returned by login and a TOTP or recovery code for an access token
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param dto.LoginMFA body dto.LoginMFA true "body request for user login second step"
+// @Success 200 {object} dto.UserResponse
+// @Failure 429 {object} errs.RetryAfterError
+// @Router /users/login/mfa [post]
+func (uh *userHandler) LoginMFA(c *fiber.Ctx) error {
+	payload := &dto.LoginMFA{}
+
+	if err := c.BodyParser(payload); err != nil {
+		invalidJSON := errs.NewUnprocessableEntityError("invalid JSON body request")
+		return c.Status(invalidJSON.Status()).JSON(invalidJSON)
+	}
+
+	payload.IP = c.IP()
+	payload.UserAgent = c.Get(fiber.HeaderUserAgent)
+
+	err := helper.ValidateStruct(payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	ur, err := uh.us.LoginMFA(payload)
+
+	if err != nil {
+		if retryErr, ok := err.(*errs.RetryAfterError); ok {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(retryErr.RetryAfter))
+		}
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(ur.Status).JSON(ur)
+}
+
+// EnrollTOTP implements UserHandler.
+// EnrollTOTP godoc
+// @Summary User enroll two factor authentication
+// @Description Generate a TOTP secret and its otpauth URI, it is enabled once a code is confirmed
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Success 200 {object} dto.UserResponse
+// @Router /users/2fa/enroll [post]
+func (uh *userHandler) EnrollTOTP(c *fiber.Ctx) error {
+	user := c.Locals("user").(entity.User)
+
+	ur, err := uh.us.EnrollTOTP(user.ID)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(ur.Status).JSON(ur)
+}
+
+// ConfirmTOTP implements UserHandler.
+// ConfirmTOTP godoc
+// @Summary User confirm two factor authentication
+// @Description Enable two factor authentication with a code from the authenticator app, the recovery codes are returned only once
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param dto.ConfirmTOTP body dto.ConfirmTOTP true "body request for user confirm two factor authentication"
+// @Success 200 {object} dto.UserResponse
+// @Router /users/2fa/confirm [post]
+func (uh *userHandler) ConfirmTOTP(c *fiber.Ctx) error {
+	payload := &dto.ConfirmTOTP{}
+	user := c.Locals("user").(entity.User)
+
+	if err := c.BodyParser(payload); err != nil {
+		invalidJSON := errs.NewUnprocessableEntityError("invalid JSON body request")
+		return c.Status(invalidJSON.Status()).JSON(invalidJSON)
+	}
+
+	err := helper.ValidateStruct(payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	ur, err := uh.us.ConfirmTOTP(user.ID, payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(ur.Status).JSON(ur)
+}
+
+// DisableTOTP implements UserHandler.
+// DisableTOTP godoc
+// @Summary User disable two factor authentication
+// @Description Disable two factor authentication after re-entering the password
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param dto.DisableTOTP body dto.DisableTOTP true "body request for user disable two factor authentication"
+// @Success 200 {object} dto.UserResponse
+// @Router /users/2fa/disable [post]
+func (uh *userHandler) DisableTOTP(c *fiber.Ctx) error {
+	payload := &dto.DisableTOTP{}
+	user := c.Locals("user").(entity.User)
+
+	if err := c.BodyParser(payload); err != nil {
+		invalidJSON := errs.NewUnprocessableEntityError("invalid JSON body request")
+		return c.Status(invalidJSON.Status()).JSON(invalidJSON)
+	}
+
+	err := helper.ValidateStruct(payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	ur, err := uh.us.DisableTOTP(user.ID, payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(ur.Status).JSON(ur)
+}
+
+// AddPersonalToken implements UserHandler.
+// AddPersonalToken godoc
+// @Summary User add personal token
+// @Description Create a scoped personal token for scripts, the token is returned only once
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param dto.AddPersonalToken body dto.AddPersonalToken true "body request for user add personal token"
+// @Success 201 {object} dto.UserResponse
+// @Router /users/tokens [post]
+func (uh *userHandler) AddPersonalToken(c *fiber.Ctx) error {
+	payload := &dto.AddPersonalToken{}
+	user := c.Locals("user").(entity.User)
+
+	if err := c.BodyParser(payload); err != nil {
+		invalidJSON := errs.NewUnprocessableEntityError("invalid JSON body request")
+		return c.Status(invalidJSON.Status()).JSON(invalidJSON)
+	}
+
+	err := helper.ValidateStruct(payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	ur, err := uh.us.AddPersonalToken(user.ID, payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(ur.Status).JSON(ur)
+}
+
+// PersonalTokens implements UserHandler.
+// PersonalTokens godoc
+// @Summary User personal tokens
+// @Description List the personal tokens of the user that haven't been revoked
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Success 200 {object} dto.UserResponse
+// @Router /users/tokens [get]
+func (uh *userHandler) PersonalTokens(c *fiber.Ctx) error {
+	user := c.Locals("user").(entity.User)
+
+	ur, err := uh.us.PersonalTokens(user.ID)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(ur.Status).JSON(ur)
+}
+
+// RevokePersonalToken implements UserHandler.
+// RevokePersonalToken godoc
+// @Summary User revoke personal token
+// @Description Revoke a personal token of the user
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param tokenId path int true "personal token id"
+// @Success 200 {object} dto.UserResponse
+// @Router /users/tokens/{tokenId} [delete]
+func (uh *userHandler) RevokePersonalToken(c *fiber.Ctx) error {
+	user := c.Locals("user").(entity.User)
+	tokenId, _ := strconv.Atoi(c.Params("tokenId"))
+
+	ur, err := uh.us.RevokePersonalToken(user.ID, uint(tokenId))
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(ur.Status).JSON(ur)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http/httptest"
 	"testing"
+	"time"
 	"todo-app/dto"
 	"todo-app/entity"
 	"todo-app/handler/users_handler"
@@ -369,3 +370,682 @@ func TestProfileServerError(t *testing.T) {
 
 	assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
 }
+
+var refreshToken = &dto.RefreshToken{RefreshToken: "refresh-token"}
+
+func TestRefreshSuccess(t *testing.T) {
+	b, _ := json.Marshal(refreshToken)
+
+	users_service.Refresh = func(payload *dto.RefreshToken) (*dto.UserResponse, errs.Error) {
+		return &dto.UserResponse{
+			Status:  fiber.StatusOK,
+			Message: "token successfully refreshed",
+			Data: dto.Token{
+				TokenString: user.GenerateToken(),
+			},
+		}, nil
+	}
+
+	app.Post("/users/refresh", userHandler.Refresh)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/users/refresh", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestRefreshUnauthenticated(t *testing.T) {
+	b, _ := json.Marshal(refreshToken)
+
+	users_service.Refresh = func(payload *dto.RefreshToken) (*dto.UserResponse, errs.Error) {
+		return nil, errs.NewUnauthenticatedError("refresh token reuse detected")
+	}
+
+	app.Post("/users/refresh", userHandler.Refresh)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/users/refresh", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+}
+
+func TestRefreshBadRequest(t *testing.T) {
+	b, _ := json.Marshal(&dto.RefreshToken{})
+
+	app.Post("/users/refresh", userHandler.Refresh)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/users/refresh", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+}
+
+func TestLogoutSuccess(t *testing.T) {
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	users_service.Logout = func(sessionId string) (*dto.UserResponse, errs.Error) {
+		return &dto.UserResponse{
+			Status:  fiber.StatusOK,
+			Message: "user successfully loged out",
+			Data:    nil,
+		}, nil
+	}
+
+	app.Post("/users/logout", authMock.Authentication(), userHandler.Logout)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/users/logout", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestSessionsSuccess(t *testing.T) {
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	users_service.Sessions = func(userId uint, sessionId string) (*dto.UserResponse, errs.Error) {
+		return &dto.UserResponse{
+			Status:  fiber.StatusOK,
+			Message: "sessions successfully fetched",
+			Data:    []*dto.Session{},
+		}, nil
+	}
+
+	app.Get("/users/sessions", authMock.Authentication(), userHandler.Sessions)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/users/sessions", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestRevokeSessionSuccess(t *testing.T) {
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	users_service.RevokeSession = func(userId uint, sessionId uint) (*dto.UserResponse, errs.Error) {
+		assert.Equal(t, uint(2), sessionId)
+
+		return &dto.UserResponse{
+			Status:  fiber.StatusOK,
+			Message: "session successfully revoked",
+			Data:    nil,
+		}, nil
+	}
+
+	app.Delete("/users/sessions/:sessionId", authMock.Authentication(), userHandler.RevokeSession)
+
+	req := httptest.NewRequest(fiber.MethodDelete, "/users/sessions/2", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestRevokeSessionNotFound(t *testing.T) {
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	users_service.RevokeSession = func(userId uint, sessionId uint) (*dto.UserResponse, errs.Error) {
+		return nil, errs.NewNotFoundError("session not found")
+	}
+
+	app.Delete("/users/sessions/:sessionId", authMock.Authentication(), userHandler.RevokeSession)
+
+	req := httptest.NewRequest(fiber.MethodDelete, "/users/sessions/2", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+}
+
+func TestRevokeSessionsSuccess(t *testing.T) {
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	users_service.RevokeSessions = func(userId uint) (*dto.UserResponse, errs.Error) {
+		return &dto.UserResponse{
+			Status:  fiber.StatusOK,
+			Message: "user successfully loged out everywhere",
+			Data:    nil,
+		}, nil
+	}
+
+	app.Delete("/users/sessions", authMock.Authentication(), userHandler.RevokeSessions)
+
+	req := httptest.NewRequest(fiber.MethodDelete, "/users/sessions", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestChangePasswordSuccess(t *testing.T) {
+	b, _ := json.Marshal(&dto.ChangePassword{CurrentPassword: "secret", NewPassword: "new-secret"})
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	users_service.ChangePassword = func(userId uint, sessionId string, payload *dto.ChangePassword) (*dto.UserResponse, errs.Error) {
+		return &dto.UserResponse{
+			Status:  fiber.StatusOK,
+			Message: "password successfully changed",
+			Data:    nil,
+		}, nil
+	}
+
+	app.Patch("/users/password", authMock.Authentication(), userHandler.ChangePassword)
+
+	req := httptest.NewRequest(fiber.MethodPatch, "/users/password", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestChangePasswordBadRequest(t *testing.T) {
+	b, _ := json.Marshal(&dto.ChangePassword{})
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	app.Patch("/users/password", authMock.Authentication(), userHandler.ChangePassword)
+
+	req := httptest.NewRequest(fiber.MethodPatch, "/users/password", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+}
+
+func TestChangePasswordInvalidJSON(t *testing.T) {
+	b, _ := json.Marshal(&dto.ChangePassword{})
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	app.Patch("/users/password", authMock.Authentication(), userHandler.ChangePassword)
+
+	req := httptest.NewRequest(fiber.MethodPatch, "/users/password", bytes.NewReader(b))
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusUnprocessableEntity, res.StatusCode)
+}
+
+func TestForgotPasswordSuccess(t *testing.T) {
+	b, _ := json.Marshal(&dto.ForgotPassword{Email: "jihan@weeekly.com"})
+
+	users_service.ForgotPassword = func(payload *dto.ForgotPassword) (*dto.UserResponse, errs.Error) {
+		return &dto.UserResponse{
+			Status:  fiber.StatusOK,
+			Message: "a password reset link has been sent if the email is registered",
+			Data:    nil,
+		}, nil
+	}
+
+	app.Post("/users/password/forgot", userHandler.ForgotPassword)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/users/password/forgot", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestForgotPasswordBadRequest(t *testing.T) {
+	b, _ := json.Marshal(&dto.ForgotPassword{Email: "jihan"})
+
+	app.Post("/users/password/forgot", userHandler.ForgotPassword)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/users/password/forgot", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+}
+
+func TestResetPasswordSuccess(t *testing.T) {
+	b, _ := json.Marshal(&dto.ResetPassword{Token: "reset-token", NewPassword: "new-secret"})
+
+	users_service.ResetPassword = func(payload *dto.ResetPassword) (*dto.UserResponse, errs.Error) {
+		return &dto.UserResponse{
+			Status:  fiber.StatusOK,
+			Message: "password successfully reset",
+			Data:    nil,
+		}, nil
+	}
+
+	app.Post("/users/password/reset", userHandler.ResetPassword)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/users/password/reset", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestResetPasswordInvalidToken(t *testing.T) {
+	b, _ := json.Marshal(&dto.ResetPassword{Token: "reset-token", NewPassword: "new-secret"})
+
+	users_service.ResetPassword = func(payload *dto.ResetPassword) (*dto.UserResponse, errs.Error) {
+		return nil, errs.NewBadRequestError("invalid or expired password reset token")
+	}
+
+	app.Post("/users/password/reset", userHandler.ResetPassword)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/users/password/reset", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+}
+
+func TestVerifyEmailSuccess(t *testing.T) {
+	b, _ := json.Marshal(&dto.VerifyEmail{Token: "verify-token"})
+
+	users_service.VerifyEmail = func(payload *dto.VerifyEmail) (*dto.UserResponse, errs.Error) {
+		return &dto.UserResponse{
+			Status:  fiber.StatusOK,
+			Message: "email successfully verified",
+			Data:    nil,
+		}, nil
+	}
+
+	app.Post("/users/email/verify", userHandler.VerifyEmail)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/users/email/verify", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestVerifyEmailBadRequest(t *testing.T) {
+	b, _ := json.Marshal(&dto.VerifyEmail{})
+
+	app.Post("/users/email/verify", userHandler.VerifyEmail)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/users/email/verify", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+}
+
+func TestResendVerificationSuccess(t *testing.T) {
+	b, _ := json.Marshal(&dto.ResendVerification{Email: "jihan@weeekly.com"})
+
+	users_service.ResendVerification = func(payload *dto.ResendVerification) (*dto.UserResponse, errs.Error) {
+		return &dto.UserResponse{
+			Status:  fiber.StatusOK,
+			Message: "a verification link has been sent if the email is registered and not verified",
+			Data:    nil,
+		}, nil
+	}
+
+	app.Post("/users/email/resend", userHandler.ResendVerification)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/users/email/resend", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestLoginTooManyRequests(t *testing.T) {
+	b, _ := json.Marshal(login)
+
+	users_service.Login = func(payload *dto.Login) (*dto.UserResponse, errs.Error) {
+		return nil, errs.NewTooManyRequestsError("too many failed login attempts, try again later", 90*time.Second)
+	}
+
+	app.Post("/users/login", userHandler.Login)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/users/login", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusTooManyRequests, res.StatusCode)
+	assert.Equal(t, "90", res.Header.Get(fiber.HeaderRetryAfter))
+}
+
+func TestLoginMFASuccess(t *testing.T) {
+	b, _ := json.Marshal(&dto.LoginMFA{MFAToken: "mfa-token", Code: "123456"})
+
+	users_service.LoginMFA = func(payload *dto.LoginMFA) (*dto.UserResponse, errs.Error) {
+		return &dto.UserResponse{
+			Status:  fiber.StatusOK,
+			Message: "user successfully loged in",
+			Data:    nil,
+		}, nil
+	}
+
+	app.Post("/users/login/mfa", userHandler.LoginMFA)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/users/login/mfa", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestLoginMFABadRequest(t *testing.T) {
+	b, _ := json.Marshal(&dto.LoginMFA{})
+
+	app.Post("/users/login/mfa", userHandler.LoginMFA)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/users/login/mfa", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+}
+
+func TestLoginMFATooManyRequests(t *testing.T) {
+	b, _ := json.Marshal(&dto.LoginMFA{MFAToken: "mfa-token", Code: "123456"})
+
+	users_service.LoginMFA = func(payload *dto.LoginMFA) (*dto.UserResponse, errs.Error) {
+		return nil, errs.NewTooManyRequestsError("too many failed login attempts, try again later", 60*time.Second)
+	}
+
+	app.Post("/users/login/mfa", userHandler.LoginMFA)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/users/login/mfa", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusTooManyRequests, res.StatusCode)
+	assert.Equal(t, "60", res.Header.Get(fiber.HeaderRetryAfter))
+}
+
+func TestEnrollTOTPSuccess(t *testing.T) {
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	users_service.EnrollTOTP = func(userId uint) (*dto.UserResponse, errs.Error) {
+		return &dto.UserResponse{
+			Status:  fiber.StatusOK,
+			Message: "scan the code with your authenticator app and confirm it",
+			Data:    dto.TOTPEnrolment{},
+		}, nil
+	}
+
+	app.Post("/users/2fa/enroll", authMock.Authentication(), userHandler.EnrollTOTP)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/users/2fa/enroll", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestEnrollTOTPConflict(t *testing.T) {
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	users_service.EnrollTOTP = func(userId uint) (*dto.UserResponse, errs.Error) {
+		return nil, errs.NewConflictError("two factor authentication is already enabled")
+	}
+
+	app.Post("/users/2fa/enroll", authMock.Authentication(), userHandler.EnrollTOTP)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/users/2fa/enroll", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusConflict, res.StatusCode)
+}
+
+func TestConfirmTOTPSuccess(t *testing.T) {
+	b, _ := json.Marshal(&dto.ConfirmTOTP{Code: "123456"})
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	users_service.ConfirmTOTP = func(userId uint, payload *dto.ConfirmTOTP) (*dto.UserResponse, errs.Error) {
+		return &dto.UserResponse{
+			Status:  fiber.StatusOK,
+			Message: "two factor authentication successfully enabled",
+			Data:    dto.RecoveryCodes{},
+		}, nil
+	}
+
+	app.Post("/users/2fa/confirm", authMock.Authentication(), userHandler.ConfirmTOTP)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/users/2fa/confirm", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestConfirmTOTPBadRequest(t *testing.T) {
+	b, _ := json.Marshal(&dto.ConfirmTOTP{})
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	app.Post("/users/2fa/confirm", authMock.Authentication(), userHandler.ConfirmTOTP)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/users/2fa/confirm", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+}
+
+func TestDisableTOTPSuccess(t *testing.T) {
+	b, _ := json.Marshal(&dto.DisableTOTP{Password: "secret"})
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	users_service.DisableTOTP = func(userId uint, payload *dto.DisableTOTP) (*dto.UserResponse, errs.Error) {
+		return &dto.UserResponse{
+			Status:  fiber.StatusOK,
+			Message: "two factor authentication successfully disabled",
+			Data:    nil,
+		}, nil
+	}
+
+	app.Post("/users/2fa/disable", authMock.Authentication(), userHandler.DisableTOTP)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/users/2fa/disable", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestDisableTOTPBadRequest(t *testing.T) {
+	b, _ := json.Marshal(&dto.DisableTOTP{})
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	app.Post("/users/2fa/disable", authMock.Authentication(), userHandler.DisableTOTP)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/users/2fa/disable", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+}
+
+func TestAddPersonalTokenSuccess(t *testing.T) {
+	b, _ := json.Marshal(&dto.AddPersonalToken{Name: "backup script", Scopes: []string{entity.ScopeTodosRead}})
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	users_service.AddPersonalToken = func(userId uint, payload *dto.AddPersonalToken) (*dto.UserResponse, errs.Error) {
+		return &dto.UserResponse{
+			Status:  fiber.StatusCreated,
+			Message: "personal token successfully created",
+			Data:    nil,
+		}, nil
+	}
+
+	app.Post("/users/tokens", authMock.Authentication(), userHandler.AddPersonalToken)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/users/tokens", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusCreated, res.StatusCode)
+}
+
+func TestAddPersonalTokenBadRequest(t *testing.T) {
+	b, _ := json.Marshal(&dto.AddPersonalToken{})
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	app.Post("/users/tokens", authMock.Authentication(), userHandler.AddPersonalToken)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/users/tokens", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+}
+
+func TestPersonalTokensSuccess(t *testing.T) {
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	users_service.PersonalTokens = func(userId uint) (*dto.UserResponse, errs.Error) {
+		return &dto.UserResponse{
+			Status:  fiber.StatusOK,
+			Message: "personal tokens successfully fetched",
+			Data:    []*dto.PersonalToken{},
+		}, nil
+	}
+
+	app.Get("/users/tokens", authMock.Authentication(), userHandler.PersonalTokens)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/users/tokens", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestRevokePersonalTokenNotFound(t *testing.T) {
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	users_service.RevokePersonalToken = func(userId uint, tokenId uint) (*dto.UserResponse, errs.Error) {
+		assert.Equal(t, uint(2), tokenId)
+		return nil, errs.NewNotFoundError("personal token not found")
+	}
+
+	app.Delete("/users/tokens/:tokenId", authMock.Authentication(), userHandler.RevokePersonalToken)
+
+	req := httptest.NewRequest(fiber.MethodDelete, "/users/tokens/2", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+}
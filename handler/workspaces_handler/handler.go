@@ -0,0 +1,380 @@
+package workspaces_handler
+
+import (
+	"strconv"
+	"todo-app/dto"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+	"todo-app/pkg/helper"
+	"todo-app/service/workspaces_service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type workspaceHandler struct {
+	ws workspaces_service.WorkspaceService
+}
+
+type WorkspaceHandler interface {
+	Add(c *fiber.Ctx) error
+	Fetch(c *fiber.Ctx) error
+	Detail(c *fiber.Ctx) error
+	Modify(c *fiber.Ctx) error
+	Invite(c *fiber.Ctx) error
+	Members(c *fiber.Ctx) error
+	Invitations(c *fiber.Ctx) error
+	Accept(c *fiber.Ctx) error
+	Decline(c *fiber.Ctx) error
+	ModifyMember(c *fiber.Ctx) error
+	RemoveMember(c *fiber.Ctx) error
+	Transfer(c *fiber.Ctx) error
+}
+
+func NewWorkspaceHandler(workspaceService workspaces_service.WorkspaceService) WorkspaceHandler {
+	return &workspaceHandler{ws: workspaceService}
+}
+
+// Add implements WorkspaceHandler.
+// Add godoc
+// @Summary Add workspace
+// @Description Add workspace request, the user becomes its owner
+// @Tags Workspaces
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param dto.AddWorkspace body dto.AddWorkspace true "body request for add workspace"
+// @Success 201 {object} dto.WorkspaceResponse
+// @Router /workspaces [post]
+func (wh *workspaceHandler) Add(c *fiber.Ctx) error {
+	payload := &dto.AddWorkspace{}
+	user := c.Locals("user").(entity.User)
+
+	if err := c.BodyParser(payload); err != nil {
+		invalidJson := errs.NewUnprocessableEntityError("invalid JSON body request")
+		return c.Status(invalidJson.Status()).JSON(invalidJson)
+	}
+
+	if err := helper.ValidateStruct(payload); err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	wr, err := wh.ws.Add(user.ID, payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(wr.Status).JSON(wr)
+}
+
+// Fetch implements WorkspaceHandler.
+// Fetch godoc
+// @Summary Get workspaces
+// @Description Get the workspaces the user is a member of
+// @Tags Workspaces
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Success 200 {object} dto.WorkspaceResponse
+// @Router /workspaces [get]
+func (wh *workspaceHandler) Fetch(c *fiber.Ctx) error {
+
+	user := c.Locals("user").(entity.User)
+
+	wr, err := wh.ws.Fetch(user.ID)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(wr.Status).JSON(wr)
+}
+
+// Detail implements WorkspaceHandler.
+// Detail godoc
+// @Summary Detail workspace
+// @Description Detail workspace request
+// @Tags Workspaces
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param workspaceId path int true "workspace id"
+// @Success 200 {object} dto.WorkspaceResponse
+// @Router /workspaces/{workspaceId} [get]
+func (wh *workspaceHandler) Detail(c *fiber.Ctx) error {
+
+	workspaceId, _ := strconv.Atoi(c.Params("workspaceId"))
+
+	wr, err := wh.ws.Detail(uint(workspaceId))
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(wr.Status).JSON(wr)
+}
+
+// Modify implements WorkspaceHandler.
+// Modify godoc
+// @Summary Modify workspace
+// @Description Rename a workspace, needs an admin
+// @Tags Workspaces
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param workspaceId path int true "workspace id"
+// @Param dto.ModifyWorkspace body dto.ModifyWorkspace true "body request for modify workspace"
+// @Success 200 {object} dto.WorkspaceResponse
+// @Router /workspaces/{workspaceId} [patch]
+func (wh *workspaceHandler) Modify(c *fiber.Ctx) error {
+	payload := &dto.ModifyWorkspace{}
+	workspaceId, _ := strconv.Atoi(c.Params("workspaceId"))
+
+	if err := c.BodyParser(payload); err != nil {
+		invalidJson := errs.NewUnprocessableEntityError("invalid JSON body request")
+		return c.Status(invalidJson.Status()).JSON(invalidJson)
+	}
+
+	if err := helper.ValidateStruct(payload); err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	wr, err := wh.ws.Modify(uint(workspaceId), payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(wr.Status).JSON(wr)
+}
+
+// Invite implements WorkspaceHandler.
+// Invite godoc
+// @Summary Invite member
+// @Description Invite a user by email as admin, member or guest, needs an admin and only the owner invites admins
+// @Tags Workspaces
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param workspaceId path int true "workspace id"
+// @Param dto.InviteMember body dto.InviteMember true "body request for invite member"
+// @Success 201 {object} dto.WorkspaceResponse
+// @Router /workspaces/{workspaceId}/members [post]
+func (wh *workspaceHandler) Invite(c *fiber.Ctx) error {
+	payload := &dto.InviteMember{}
+	user := c.Locals("user").(entity.User)
+	workspaceId, _ := strconv.Atoi(c.Params("workspaceId"))
+
+	if err := c.BodyParser(payload); err != nil {
+		invalidJson := errs.NewUnprocessableEntityError("invalid JSON body request")
+		return c.Status(invalidJson.Status()).JSON(invalidJson)
+	}
+
+	if err := helper.ValidateStruct(payload); err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	wr, err := wh.ws.Invite(user.ID, uint(workspaceId), payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(wr.Status).JSON(wr)
+}
+
+// Members implements WorkspaceHandler.
+// Members godoc
+// @Summary Get members
+// @Description Get the members and pending invitations of a workspace
+// @Tags Workspaces
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param workspaceId path int true "workspace id"
+// @Success 200 {object} dto.WorkspaceResponse
+// @Router /workspaces/{workspaceId}/members [get]
+func (wh *workspaceHandler) Members(c *fiber.Ctx) error {
+
+	workspaceId, _ := strconv.Atoi(c.Params("workspaceId"))
+
+	wr, err := wh.ws.Members(uint(workspaceId))
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(wr.Status).JSON(wr)
+}
+
+// Invitations implements WorkspaceHandler.
+// Invitations godoc
+// @Summary Get invitations
+// @Description Get the workspace invitations waiting to be accepted or declined by the user
+// @Tags Workspaces
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Success 200 {object} dto.WorkspaceResponse
+// @Router /workspaces/invitations [get]
+func (wh *workspaceHandler) Invitations(c *fiber.Ctx) error {
+
+	user := c.Locals("user").(entity.User)
+
+	wr, err := wh.ws.Invitations(user.ID)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(wr.Status).JSON(wr)
+}
+
+// Accept implements WorkspaceHandler.
+// Accept godoc
+// @Summary Accept invitation
+// @Description Accept an invitation to a workspace
+// @Tags Workspaces
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param memberId path int true "member id"
+// @Success 200 {object} dto.WorkspaceResponse
+// @Router /workspaces/invitations/{memberId}/accept [post]
+func (wh *workspaceHandler) Accept(c *fiber.Ctx) error {
+
+	user := c.Locals("user").(entity.User)
+	memberId, _ := strconv.Atoi(c.Params("memberId"))
+
+	wr, err := wh.ws.Accept(user.ID, uint(memberId))
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(wr.Status).JSON(wr)
+}
+
+// Decline implements WorkspaceHandler.
+// Decline godoc
+// @Summary Decline invitation
+// @Description Decline an invitation to a workspace
+// @Tags Workspaces
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param memberId path int true "member id"
+// @Success 200 {object} dto.WorkspaceResponse
+// @Router /workspaces/invitations/{memberId}/decline [post]
+func (wh *workspaceHandler) Decline(c *fiber.Ctx) error {
+
+	user := c.Locals("user").(entity.User)
+	memberId, _ := strconv.Atoi(c.Params("memberId"))
+
+	wr, err := wh.ws.Decline(user.ID, uint(memberId))
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(wr.Status).JSON(wr)
+}
+
+// ModifyMember implements WorkspaceHandler.
+// ModifyMember godoc
+// @Summary Modify member
+// @Description Change the role of a member, needs an admin and only the owner manages admins
+// @Tags Workspaces
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param workspaceId path int true "workspace id"
+// @Param memberId path int true "member id"
+// @Param dto.ModifyMember body dto.ModifyMember true "body request for modify member"
+// @Success 200 {object} dto.WorkspaceResponse
+// @Router /workspaces/{workspaceId}/members/{memberId} [patch]
+func (wh *workspaceHandler) ModifyMember(c *fiber.Ctx) error {
+	payload := &dto.ModifyMember{}
+	user := c.Locals("user").(entity.User)
+	workspaceId, _ := strconv.Atoi(c.Params("workspaceId"))
+	memberId, _ := strconv.Atoi(c.Params("memberId"))
+
+	if err := c.BodyParser(payload); err != nil {
+		invalidJson := errs.NewUnprocessableEntityError("invalid JSON body request")
+		return c.Status(invalidJson.Status()).JSON(invalidJson)
+	}
+
+	if err := helper.ValidateStruct(payload); err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	wr, err := wh.ws.ModifyMember(user.ID, uint(workspaceId), uint(memberId), payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(wr.Status).JSON(wr)
+}
+
+// RemoveMember implements WorkspaceHandler.
+// RemoveMember godoc
+// @Summary Remove member
+// @Description Remove a member from a workspace, members can remove themselves to leave it
+// @Tags Workspaces
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param workspaceId path int true "workspace id"
+// @Param memberId path int true "member id"
+// @Success 200 {object} dto.WorkspaceResponse
+// @Router /workspaces/{workspaceId}/members/{memberId} [delete]
+func (wh *workspaceHandler) RemoveMember(c *fiber.Ctx) error {
+
+	user := c.Locals("user").(entity.User)
+	workspaceId, _ := strconv.Atoi(c.Params("workspaceId"))
+	memberId, _ := strconv.Atoi(c.Params("memberId"))
+
+	wr, err := wh.ws.RemoveMember(user.ID, uint(workspaceId), uint(memberId))
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(wr.Status).JSON(wr)
+}
+
+// Transfer implements WorkspaceHandler.
+// Transfer godoc
+// @Summary Transfer workspace
+// @Description Hand the workspace over to another member, the previous owner stays on as an admin
+// @Tags Workspaces
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param workspaceId path int true "workspace id"
+// @Param dto.TransferWorkspace body dto.TransferWorkspace true "body request for transfer workspace"
+// @Success 200 {object} dto.WorkspaceResponse
+// @Router /workspaces/{workspaceId}/transfer [post]
+func (wh *workspaceHandler) Transfer(c *fiber.Ctx) error {
+	payload := &dto.TransferWorkspace{}
+	user := c.Locals("user").(entity.User)
+	workspaceId, _ := strconv.Atoi(c.Params("workspaceId"))
+
+	if err := c.BodyParser(payload); err != nil {
+		invalidJson := errs.NewUnprocessableEntityError("invalid JSON body request")
+		return c.Status(invalidJson.Status()).JSON(invalidJson)
+	}
+
+	if err := helper.ValidateStruct(payload); err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	wr, err := wh.ws.Transfer(user.ID, uint(workspaceId), payload)
+
+	if err != nil {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	return c.Status(wr.Status).JSON(wr)
+}
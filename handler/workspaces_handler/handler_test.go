@@ -0,0 +1,232 @@
+package workspaces_handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"todo-app/dto"
+	"todo-app/entity"
+	"todo-app/handler/workspaces_handler"
+	"todo-app/pkg/errs"
+	"todo-app/service/auth_service"
+	"todo-app/service/workspaces_service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var serviceMock = workspaces_service.NewServiceMock()
+var handler = workspaces_handler.NewWorkspaceHandler(serviceMock)
+
+var app = fiber.New()
+
+var invite = &dto.InviteMember{
+	Email: "zee@weeekly.com",
+	Role:  entity.WorkspaceRoleMember,
+}
+
+var user = entity.User{
+	Model: gorm.Model{
+		ID: 1,
+	},
+	Name:  "jihan",
+	Email: "jihan@weeekly.com",
+}
+
+func TestAddSuccess(t *testing.T) {
+	b, _ := json.Marshal(&dto.AddWorkspace{Name: "Marketing"})
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	workspaces_service.Add = func(userId uint, payload *dto.AddWorkspace) (*dto.WorkspaceResponse, errs.Error) {
+		return &dto.WorkspaceResponse{
+			Status:  fiber.StatusCreated,
+			Message: "workspace successfully added",
+		}, nil
+	}
+
+	app.Post("/workspaces", auth_service.Authentication(), handler.Add)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/workspaces", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusCreated, res.StatusCode)
+}
+
+func TestAddBadRequest(t *testing.T) {
+	b, _ := json.Marshal(&dto.AddWorkspace{})
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	app.Post("/workspaces", auth_service.Authentication(), handler.Add)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/workspaces", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+}
+
+func TestFetchSuccess(t *testing.T) {
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	workspaces_service.Fetch = func(userId uint) (*dto.WorkspaceResponse, errs.Error) {
+		return &dto.WorkspaceResponse{
+			Status:  fiber.StatusOK,
+			Message: "workspaces successfully fetched",
+			Data:    []*dto.Workspace{},
+		}, nil
+	}
+
+	app.Get("/workspaces", auth_service.Authentication(), handler.Fetch)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/workspaces", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestInviteConflict(t *testing.T) {
+	b, _ := json.Marshal(invite)
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	workspaces_service.Invite = func(userId uint, workspaceId uint, payload *dto.InviteMember) (*dto.WorkspaceResponse, errs.Error) {
+		return nil, errs.NewConflictError("user has been invited to this workspace")
+	}
+
+	app.Post("/workspaces/:workspaceId/members", auth_service.Authentication(), handler.Invite)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/workspaces/1/members", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusConflict, res.StatusCode)
+}
+
+func TestAcceptNotFound(t *testing.T) {
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	workspaces_service.Accept = func(userId uint, memberId uint) (*dto.WorkspaceResponse, errs.Error) {
+		return nil, errs.NewNotFoundError("member not found")
+	}
+
+	app.Post("/workspaces/invitations/:memberId/accept", auth_service.Authentication(), handler.Accept)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/workspaces/invitations/1/accept", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+}
+
+func TestModifyMemberSuccess(t *testing.T) {
+	b, _ := json.Marshal(&dto.ModifyMember{Role: entity.WorkspaceRoleGuest})
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	workspaces_service.ModifyMember = func(userId uint, workspaceId uint, memberId uint, payload *dto.ModifyMember) (*dto.WorkspaceResponse, errs.Error) {
+		return &dto.WorkspaceResponse{
+			Status:  fiber.StatusOK,
+			Message: "member successfully modified",
+		}, nil
+	}
+
+	app.Patch("/workspaces/:workspaceId/members/:memberId", auth_service.Authentication(), handler.ModifyMember)
+
+	req := httptest.NewRequest(fiber.MethodPatch, "/workspaces/1/members/2", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
+
+func TestRemoveMemberForbidden(t *testing.T) {
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	workspaces_service.RemoveMember = func(userId uint, workspaceId uint, memberId uint) (*dto.WorkspaceResponse, errs.Error) {
+		return nil, errs.NewUnathorizedError("your workspace role doesn't allow this")
+	}
+
+	app.Delete("/workspaces/:workspaceId/members/:memberId", auth_service.Authentication(), handler.RemoveMember)
+
+	req := httptest.NewRequest(fiber.MethodDelete, "/workspaces/1/members/2", nil)
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusForbidden, res.StatusCode)
+}
+
+func TestTransferSuccess(t *testing.T) {
+	b, _ := json.Marshal(&dto.TransferWorkspace{UserId: 2})
+
+	auth_service.Authentication = func() fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			c.Locals("user", user)
+			return c.Next()
+		}
+	}
+
+	workspaces_service.Transfer = func(userId uint, workspaceId uint, payload *dto.TransferWorkspace) (*dto.WorkspaceResponse, errs.Error) {
+		return &dto.WorkspaceResponse{
+			Status:  fiber.StatusOK,
+			Message: "workspace successfully transferred",
+		}, nil
+	}
+
+	app.Post("/workspaces/:workspaceId/transfer", auth_service.Authentication(), handler.Transfer)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/workspaces/1/transfer", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, _ := app.Test(req, 1)
+
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+}
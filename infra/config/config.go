@@ -2,11 +2,21 @@ package config
 
 import (
 	"os"
+	"strconv"
 
 	"github.com/gofiber/fiber/v2/log"
 	"github.com/joho/godotenv"
 )
 
+// policies for accounts whose email isn't verified yet: allow doesn't limit
+// them, restrict lets them log in but not use features that reach other
+// users and deny doesn't let them log in
+const (
+	PolicyAllow    = "allow"
+	PolicyRestrict = "restrict"
+	PolicyDeny     = "deny"
+)
+
 type appConfig struct {
 	DbHost       string
 	DbPort       string
@@ -16,6 +26,59 @@ type appConfig struct {
 	DbDialect    string
 	Port         string
 	JwtSecretKey string
+
+	TrashRetentionDays int
+
+	JwtIssuer             string
+	JwtAudience           string
+	JwtKeysDir            string
+	JwtActiveKid          string
+	AccessTokenTTLMinutes int
+	RefreshTokenTTLDays   int
+	SessionCacheSeconds   int
+
+	AppURL                  string
+	PasswordResetTTLMinutes int
+	MailDriver              string
+	MailFrom                string
+	SmtpHost                string
+	SmtpPort                string
+	SmtpUsername            string
+	SmtpPassword            string
+
+	EmailVerificationTTLHours int
+	UnverifiedAccountPolicy   string
+
+	LoginAttemptsStore    string
+	LoginMaxFailures      int
+	LoginMaxFailuresPerIP int
+	LoginLockoutMinutes   int
+	LoginBackoffSeconds   int
+
+	TOTPIssuer string
+
+	PasswordHasher        string
+	Argon2MemoryKiB       int
+	Argon2Iterations      int
+	Argon2Parallelism     int
+	BcryptCost            int
+	PasswordMinLength     int
+	PasswordMaxLength     int
+	PasswordBlocklistFile string
+
+	ApiURL                  string
+	StorageDriver           string
+	StorageLocalDir         string
+	S3Endpoint              string
+	S3Region                string
+	S3Bucket                string
+	S3AccessKey             string
+	S3SecretKey             string
+	S3PathStyle             bool
+	AttachmentMaxBytes      int
+	AttachmentTypes         string
+	AttachmentURLTTLMinutes int
+	AttachmentURLSecret     string
 }
 
 func LoadEnv() {
@@ -36,5 +99,92 @@ func AppConfig() *appConfig {
 		DbDialect:    os.Getenv("DB_DIALECT"),
 		Port:         os.Getenv("PORT"),
 		JwtSecretKey: os.Getenv("JWT_SECRET_KEY"),
+
+		TrashRetentionDays: envInt("TRASH_RETENTION_DAYS", 30),
+
+		JwtIssuer:             envString("JWT_ISSUER", "todo-app"),
+		JwtAudience:           envString("JWT_AUDIENCE", "todo-app"),
+		JwtKeysDir:            envString("JWT_KEYS_DIR", "keys"),
+		JwtActiveKid:          os.Getenv("JWT_ACTIVE_KID"),
+		AccessTokenTTLMinutes: envInt("ACCESS_TOKEN_TTL_MINUTES", 15),
+		RefreshTokenTTLDays:   envInt("REFRESH_TOKEN_TTL_DAYS", 30),
+		SessionCacheSeconds:   envInt("SESSION_CACHE_SECONDS", 30),
+
+		AppURL:                  envString("APP_URL", "http://localhost:3000"),
+		PasswordResetTTLMinutes: envInt("PASSWORD_RESET_TTL_MINUTES", 60),
+		MailDriver:              envString("MAIL_DRIVER", "log"),
+		MailFrom:                envString("MAIL_FROM", "no-reply@todoku.local"),
+		SmtpHost:                os.Getenv("SMTP_HOST"),
+		SmtpPort:                envString("SMTP_PORT", "25"),
+		SmtpUsername:            os.Getenv("SMTP_USERNAME"),
+		SmtpPassword:            os.Getenv("SMTP_PASSWORD"),
+
+		EmailVerificationTTLHours: envInt("EMAIL_VERIFICATION_TTL_HOURS", 24),
+		UnverifiedAccountPolicy:   envString("UNVERIFIED_ACCOUNT_POLICY", PolicyRestrict),
+
+		LoginAttemptsStore:    envString("LOGIN_ATTEMPTS_STORE", "memory"),
+		LoginMaxFailures:      envInt("LOGIN_MAX_FAILURES", 5),
+		LoginMaxFailuresPerIP: envInt("LOGIN_MAX_FAILURES_PER_IP", 20),
+		LoginLockoutMinutes:   envInt("LOGIN_LOCKOUT_MINUTES", 15),
+		LoginBackoffSeconds:   envInt("LOGIN_BACKOFF_SECONDS", 1),
+
+		TOTPIssuer: envString("TOTP_ISSUER", "TodoKu"),
+
+		PasswordHasher:        envString("PASSWORD_HASHER", "argon2id"),
+		Argon2MemoryKiB:       envInt("ARGON2_MEMORY_KIB", 64*1024),
+		Argon2Iterations:      envInt("ARGON2_ITERATIONS", 3),
+		Argon2Parallelism:     envInt("ARGON2_PARALLELISM", 2),
+		BcryptCost:            envInt("BCRYPT_COST", 10),
+		PasswordMinLength:     envInt("PASSWORD_MIN_LENGTH", 8),
+		PasswordMaxLength:     envInt("PASSWORD_MAX_LENGTH", 128),
+		PasswordBlocklistFile: os.Getenv("PASSWORD_BLOCKLIST_FILE"),
+
+		ApiURL:                  envString("API_URL", "http://localhost:8080"),
+		StorageDriver:           envString("STORAGE_DRIVER", "local"),
+		StorageLocalDir:         envString("STORAGE_LOCAL_DIR", "uploads"),
+		S3Endpoint:              os.Getenv("S3_ENDPOINT"),
+		S3Region:                envString("S3_REGION", "us-east-1"),
+		S3Bucket:                os.Getenv("S3_BUCKET"),
+		S3AccessKey:             os.Getenv("S3_ACCESS_KEY"),
+		S3SecretKey:             os.Getenv("S3_SECRET_KEY"),
+		S3PathStyle:             envBool("S3_PATH_STYLE", true),
+		AttachmentMaxBytes:      envInt("ATTACHMENT_MAX_BYTES", 10<<20),
+		AttachmentTypes:         envString("ATTACHMENT_TYPES", "image/png,image/jpeg,image/gif,image/webp,application/pdf,text/plain,application/zip"),
+		AttachmentURLTTLMinutes: envInt("ATTACHMENT_URL_TTL_MINUTES", 15),
+		AttachmentURLSecret:     os.Getenv("ATTACHMENT_URL_SECRET"),
 	}
 }
+
+// envString reads a variable, falling back when it is unset.
+func envString(key string, fallback string) string {
+
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+
+	return fallback
+}
+
+// envInt reads a numeric variable, falling back when it is unset or invalid.
+func envInt(key string, fallback int) int {
+
+	value, err := strconv.Atoi(os.Getenv(key))
+
+	if err != nil {
+		return fallback
+	}
+
+	return value
+}
+
+// envBool reads a boolean variable, falling back when it is unset or invalid.
+func envBool(key string, fallback bool) bool {
+
+	value, err := strconv.ParseBool(os.Getenv(key))
+
+	if err != nil {
+		return fallback
+	}
+
+	return value
+}
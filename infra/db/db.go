@@ -22,7 +22,8 @@ func DbConn() *gorm.DB {
 	)
 
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Silent),
+		Logger:         logger.Default.LogMode(logger.Silent),
+		TranslateError: true,
 	})
 
 	if err != nil {
@@ -41,7 +42,22 @@ func DbConn() *gorm.DB {
 	d.SetMaxIdleConns(10)
 	d.SetMaxOpenConns(100)
 
-	err = db.AutoMigrate(&entity.User{}, &entity.Todo{})
+	err = db.AutoMigrate(&entity.User{}, &entity.Tag{}, &entity.Project{}, &entity.Todo{}, &entity.Subtask{}, &entity.Session{}, &entity.RefreshToken{}, &entity.PasswordReset{}, &entity.EmailVerification{}, &entity.LoginAttempt{}, &entity.RecoveryCode{}, &entity.PersonalToken{}, &entity.Share{}, &entity.Workspace{}, &entity.WorkspaceMember{}, &entity.Comment{}, &entity.CommentMention{}, &entity.Attachment{})
+
+	if err != nil {
+		log.Panic("error while migration: ", err.Error())
+	}
+
+	// gorm can't migrate generated columns, so the full text search vector
+	// and its index are created here
+	err = db.Exec(`ALTER TABLE todos ADD COLUMN IF NOT EXISTS search_vector tsvector
+		GENERATED ALWAYS AS (to_tsvector(search_language, coalesce(todos, ''))) STORED`).Error
+
+	if err != nil {
+		log.Panic("error while migration: ", err.Error())
+	}
+
+	err = db.Exec("CREATE INDEX IF NOT EXISTS idx_todos_search_vector ON todos USING GIN (search_vector)").Error
 
 	if err != nil {
 		log.Panic("error while migration: ", err.Error())
@@ -2,6 +2,7 @@ package main
 
 import (
 	"runtime"
+	_ "time/tzdata"
 	"todo-app/handler"
 )
 
@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// Cache is an in memory map safe for concurrent use whose entries expire a
+// fixed ttl after they are set.
+type Cache[K comparable, V any] struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	entries   map[K]entry[V]
+	lastSweep time.Time
+}
+
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+func New[K comparable, V any](ttl time.Duration) *Cache[K, V] {
+	return &Cache[K, V]{
+		ttl:       ttl,
+		entries:   map[K]entry[V]{},
+		lastSweep: time.Now(),
+	}
+}
+
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+
+	if !ok || !time.Now().Before(e.expiresAt) {
+		var zero V
+		return zero, false
+	}
+
+	return e.value, true
+}
+
+func (c *Cache[K, V]) Set(key K, value V) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+
+	// expired entries are dropped at most once per ttl so keys that are
+	// never read again don't pile up
+	if now.Sub(c.lastSweep) >= c.ttl {
+		for k, e := range c.entries {
+			if !now.Before(e.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		c.lastSweep = now
+	}
+
+	c.entries[key] = entry[V]{value: value, expiresAt: now.Add(c.ttl)}
+}
+
+// Update replaces the value of a live entry with fn applied to it. The entry
+// keeps its expiry, so frequent updates don't keep stale values around past
+// the ttl. Missing and expired entries are left alone.
+func (c *Cache[K, V]) Update(key K, fn func(V) V) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+
+	if !ok || !time.Now().Before(e.expiresAt) {
+		return
+	}
+
+	c.entries[key] = entry[V]{value: fn(e.value), expiresAt: e.expiresAt}
+}
+
+func (c *Cache[K, V]) Delete(key K) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
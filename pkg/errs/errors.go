@@ -1,6 +1,10 @@
 package errs
 
-import "net/http"
+import (
+	"math"
+	"net/http"
+	"time"
+)
 
 type Error interface {
 	Status() int
@@ -92,3 +96,36 @@ func NewUnauthorizedError(message string) Error {
 		ErrErrors:  "UNAUTHORIZED_ERROR",
 	}
 }
+
+func NewRequestEntityTooLargeError(message string) Error {
+	return &ErrorData{
+		ErrStatus:  http.StatusRequestEntityTooLarge,
+		ErrMessage: message,
+		ErrErrors:  "REQUEST_ENTITY_TOO_LARGE",
+	}
+}
+
+func NewUnsupportedMediaTypeError(message string) Error {
+	return &ErrorData{
+		ErrStatus:  http.StatusUnsupportedMediaType,
+		ErrMessage: message,
+		ErrErrors:  "UNSUPPORTED_MEDIA_TYPE",
+	}
+}
+
+// RetryAfterError tells the client how many seconds to wait before retrying.
+type RetryAfterError struct {
+	ErrorData
+	RetryAfter int `json:"retry_after"`
+}
+
+func NewTooManyRequestsError(message string, retryAfter time.Duration) Error {
+	return &RetryAfterError{
+		ErrorData: ErrorData{
+			ErrStatus:  http.StatusTooManyRequests,
+			ErrMessage: message,
+			ErrErrors:  "TOO_MANY_REQUESTS",
+		},
+		RetryAfter: int(math.Ceil(retryAfter.Seconds())),
+	}
+}
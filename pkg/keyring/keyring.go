@@ -0,0 +1,227 @@
+package keyring
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Key is a signing key identified by the kid of the tokens it signs. Retired
+// keys may only have their public half.
+type Key struct {
+	ID      string
+	Method  jwt.SigningMethod
+	private crypto.Signer
+	public  crypto.PublicKey
+}
+
+// Keyring holds the asymmetric keys JWTs are signed and verified with. One
+// key is active and signs new tokens, the others are retired and only verify
+// tokens issued before a rotation.
+type Keyring struct {
+	active *Key
+	keys   map[string]*Key
+}
+
+// JWK is a public key as published in a JWKS document.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// ParsePEM reads an RSA or Ed25519 key, either private in PKCS8 or PKCS1
+// form or public in PKIX form.
+func ParsePEM(id string, data []byte) (*Key, error) {
+
+	block, _ := pem.Decode(data)
+
+	if block == nil {
+		return nil, fmt.Errorf("key %s: no PEM block found", id)
+	}
+
+	var parsed any
+	var err error
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("key %s: unsupported PEM block %q", id, block.Type)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("key %s: %w", id, err)
+	}
+
+	key := &Key{ID: id}
+
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.Method, key.private, key.public = jwt.SigningMethodRS256, k, &k.PublicKey
+	case *rsa.PublicKey:
+		key.Method, key.public = jwt.SigningMethodRS256, k
+	case ed25519.PrivateKey:
+		key.Method, key.private, key.public = jwt.SigningMethodEdDSA, k, k.Public()
+	case ed25519.PublicKey:
+		key.Method, key.public = jwt.SigningMethodEdDSA, k
+	default:
+		return nil, fmt.Errorf("key %s: only RSA and Ed25519 keys are supported", id)
+	}
+
+	return key, nil
+}
+
+// Load reads every .pem file of dir as a key named after the file, the key
+// named activeId signs new tokens.
+func Load(dir string, activeId string) (*Keyring, error) {
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	var active *Key
+	retired := []*Key{}
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := ParsePEM(strings.TrimSuffix(filepath.Base(path), ".pem"), data)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if key.ID == activeId {
+			active = key
+			continue
+		}
+
+		retired = append(retired, key)
+	}
+
+	if active == nil {
+		return nil, fmt.Errorf("active key %s not found in %s", activeId, dir)
+	}
+
+	return New(active, retired...)
+}
+
+func New(active *Key, retired ...*Key) (*Keyring, error) {
+
+	if active.private == nil {
+		return nil, fmt.Errorf("active key %s has no private key", active.ID)
+	}
+
+	kr := &Keyring{active: active, keys: map[string]*Key{active.ID: active}}
+
+	for _, key := range retired {
+		if _, ok := kr.keys[key.ID]; ok {
+			return nil, fmt.Errorf("key %s is duplicated", key.ID)
+		}
+		kr.keys[key.ID] = key
+	}
+
+	return kr, nil
+}
+
+// Sign signs the claims with the active key and names it in the kid header.
+func (kr *Keyring) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(kr.active.Method, claims)
+	token.Header["kid"] = kr.active.ID
+	return token.SignedString(kr.active.private)
+}
+
+// Keyfunc resolves the verification key of a token from its kid header, the
+// key also decides the algorithm so a token can't pick a weaker one.
+func (kr *Keyring) Keyfunc(t *jwt.Token) (any, error) {
+
+	kid, _ := t.Header["kid"].(string)
+	key, ok := kr.keys[kid]
+
+	if !ok {
+		return nil, errors.New("unknown key")
+	}
+
+	if t.Method.Alg() != key.Method.Alg() {
+		return nil, errors.New("unexpected signing method")
+	}
+
+	return key.public, nil
+}
+
+// Methods are the algorithms of the keys on the ring.
+func (kr *Keyring) Methods() []string {
+
+	methods := []string{}
+	seen := map[string]bool{}
+
+	for _, key := range kr.keys {
+		if alg := key.Method.Alg(); !seen[alg] {
+			seen[alg] = true
+			methods = append(methods, alg)
+		}
+	}
+
+	return methods
+}
+
+// JWKS returns the public half of every key on the ring.
+func (kr *Keyring) JWKS() JWKS {
+
+	set := JWKS{Keys: []JWK{}}
+
+	for _, key := range kr.keys {
+		jwk := JWK{Kid: key.ID, Use: "sig", Alg: key.Method.Alg()}
+
+		switch pub := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		}
+
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	sort.Slice(set.Keys, func(i, j int) bool {
+		return set.Keys[i].Kid < set.Keys[j].Kid
+	})
+
+	return set
+}
@@ -0,0 +1,201 @@
+package keyring_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"todo-app/pkg/keyring"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func rsaPEM(t *testing.T) ([]byte, []byte) {
+
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	private, _ := x509.MarshalPKCS8PrivateKey(k)
+	public, _ := x509.MarshalPKIXPublicKey(&k.PublicKey)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: private}),
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: public})
+}
+
+func ed25519PEM(t *testing.T) ([]byte, []byte) {
+
+	pub, k, err := ed25519.GenerateKey(rand.Reader)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	private, _ := x509.MarshalPKCS8PrivateKey(k)
+	public, _ := x509.MarshalPKIXPublicKey(pub)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: private}),
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: public})
+}
+
+func parse(t *testing.T, id string, data []byte) *keyring.Key {
+
+	key, err := keyring.ParsePEM(id, data)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return key
+}
+
+func claims() jwt.MapClaims {
+	return jwt.MapClaims{"id": 1, "exp": time.Now().Add(time.Minute).Unix()}
+}
+
+func verify(kr *keyring.Keyring, tokenString string) error {
+	_, err := jwt.Parse(tokenString, kr.Keyfunc, jwt.WithValidMethods(kr.Methods()))
+	return err
+}
+
+func TestSignNamesActiveKey(t *testing.T) {
+	private, _ := ed25519PEM(t)
+
+	kr, err := keyring.New(parse(t, "2024-01", private))
+
+	assert.Nil(t, err)
+
+	tokenString, err := kr.Sign(claims())
+
+	assert.Nil(t, err)
+	assert.Nil(t, verify(kr, tokenString))
+
+	token, _, _ := jwt.NewParser().ParseUnverified(tokenString, jwt.MapClaims{})
+
+	assert.Equal(t, "2024-01", token.Header["kid"])
+	assert.Equal(t, "EdDSA", token.Header["alg"])
+}
+
+func TestRotation(t *testing.T) {
+	oldPrivate, oldPublic := rsaPEM(t)
+	newPrivate, _ := ed25519PEM(t)
+
+	before, _ := keyring.New(parse(t, "old", oldPrivate))
+	issued, _ := before.Sign(claims())
+
+	// the old key is retired with only its public half
+	after, err := keyring.New(parse(t, "new", newPrivate), parse(t, "old", oldPublic))
+
+	assert.Nil(t, err)
+	assert.Nil(t, verify(after, issued))
+	assert.ElementsMatch(t, []string{"EdDSA", "RS256"}, after.Methods())
+
+	rotated, _ := after.Sign(claims())
+	token, _, _ := jwt.NewParser().ParseUnverified(rotated, jwt.MapClaims{})
+
+	assert.Equal(t, "new", token.Header["kid"])
+
+	// once the old key is dropped its tokens stop verifying
+	dropped, _ := keyring.New(parse(t, "new", newPrivate))
+
+	assert.NotNil(t, verify(dropped, issued))
+	assert.Nil(t, verify(dropped, rotated))
+}
+
+func TestNewRejectsPublicActiveKey(t *testing.T) {
+	_, public := ed25519PEM(t)
+
+	kr, err := keyring.New(parse(t, "public", public))
+
+	assert.Nil(t, kr)
+	assert.NotNil(t, err)
+}
+
+func TestNewRejectsDuplicatedKey(t *testing.T) {
+	private, public := ed25519PEM(t)
+
+	kr, err := keyring.New(parse(t, "a", private), parse(t, "a", public))
+
+	assert.Nil(t, kr)
+	assert.NotNil(t, err)
+}
+
+func TestKeyfunc(t *testing.T) {
+	rsaPrivate, _ := rsaPEM(t)
+	edPrivate, _ := ed25519PEM(t)
+
+	kr, _ := keyring.New(parse(t, "rsa", rsaPrivate), parse(t, "ed", edPrivate))
+
+	tests := []struct {
+		name  string
+		token *jwt.Token
+		valid bool
+	}{
+		{"matching kid and alg", tokenWith(jwt.SigningMethodRS256, "rsa"), true},
+		{"retired key", tokenWith(jwt.SigningMethodEdDSA, "ed"), true},
+		{"unknown kid", tokenWith(jwt.SigningMethodRS256, "other"), false},
+		{"missing kid", tokenWith(jwt.SigningMethodRS256, ""), false},
+		{"alg of another key", tokenWith(jwt.SigningMethodEdDSA, "rsa"), false},
+		{"weaker alg", tokenWith(jwt.SigningMethodHS256, "rsa"), false},
+		{"none alg", tokenWith(jwt.SigningMethodNone, "ed"), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			key, err := kr.Keyfunc(test.token)
+
+			if test.valid {
+				assert.Nil(t, err)
+				assert.NotNil(t, key)
+			} else {
+				assert.Nil(t, key)
+				assert.NotNil(t, err)
+			}
+		})
+	}
+}
+
+func tokenWith(method jwt.SigningMethod, kid string) *jwt.Token {
+
+	token := jwt.New(method)
+
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+
+	return token
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	activePrivate, _ := ed25519PEM(t)
+	_, retiredPublic := rsaPEM(t)
+
+	os.WriteFile(filepath.Join(dir, "2024-02.pem"), activePrivate, 0600)
+	os.WriteFile(filepath.Join(dir, "2024-01.pem"), retiredPublic, 0600)
+
+	kr, err := keyring.Load(dir, "2024-02")
+
+	assert.Nil(t, err)
+
+	jwks := kr.JWKS()
+
+	assert.Len(t, jwks.Keys, 2)
+	assert.Equal(t, "2024-01", jwks.Keys[0].Kid)
+	assert.Equal(t, "RSA", jwks.Keys[0].Kty)
+	assert.Equal(t, "2024-02", jwks.Keys[1].Kid)
+	assert.Equal(t, "OKP", jwks.Keys[1].Kty)
+
+	kr, err = keyring.Load(dir, "2024-03")
+
+	assert.Nil(t, kr)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,67 @@
+package mailer
+
+import (
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/gofiber/fiber/v2/log"
+)
+
+type Mailer interface {
+	Send(to string, subject string, body string) error
+}
+
+type smtpMailer struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPMailer sends plain text mails through an SMTP server. Credentials
+// are optional so local sinks without authentication work as well.
+func NewSMTPMailer(host string, port string, username string, password string, from string) Mailer {
+
+	m := &smtpMailer{addr: net.JoinHostPort(host, port), from: from}
+
+	if username != "" {
+		m.auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return m
+}
+
+// Send implements Mailer.
+func (m *smtpMailer) Send(to string, subject string, body string) error {
+
+	if strings.ContainsAny(to+subject, "\r\n") {
+		return fmt.Errorf("mail header contains a line break")
+	}
+
+	msg := strings.Join([]string{
+		"From: " + m.from,
+		"To: " + to,
+		"Subject: " + subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		body,
+	}, "\r\n")
+
+	return smtp.SendMail(m.addr, m.auth, m.from, []string{to}, []byte(msg))
+}
+
+type logMailer struct {
+}
+
+// NewLogMailer only logs the mails, for development without an SMTP server.
+func NewLogMailer() Mailer {
+	return &logMailer{}
+}
+
+// Send implements Mailer.
+func (m *logMailer) Send(to string, subject string, body string) error {
+	log.Infof("mail to %s: %s\n%s", to, subject, body)
+	return nil
+}
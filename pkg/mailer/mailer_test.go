@@ -0,0 +1,161 @@
+package mailer_test
+
+import (
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"todo-app/pkg/mailer"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mail is what the fake SMTP server received.
+type mail struct {
+	from string
+	to   []string
+	data string
+}
+
+// fakeSMTP accepts one connection on a local port and speaks just enough
+// SMTP for net/smtp, recipients in reject are refused.
+func fakeSMTP(t *testing.T, reject string) (string, string, <-chan mail) {
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { l.Close() })
+
+	received := make(chan mail, 1)
+
+	go func() {
+		conn, err := l.Accept()
+
+		if err != nil {
+			return
+		}
+
+		defer conn.Close()
+
+		tp := textproto.NewConn(conn)
+		m := mail{}
+
+		tp.PrintfLine("220 localhost ESMTP")
+
+		for {
+			line, err := tp.ReadLine()
+
+			if err != nil {
+				return
+			}
+
+			command, arg, _ := strings.Cut(line, " ")
+
+			switch strings.ToUpper(command) {
+			case "EHLO", "HELO":
+				tp.PrintfLine("250 localhost")
+			case "MAIL":
+				m.from = strings.TrimPrefix(arg, "FROM:")
+				tp.PrintfLine("250 OK")
+			case "RCPT":
+				to := strings.TrimPrefix(arg, "TO:")
+
+				if to == "<"+reject+">" {
+					tp.PrintfLine("550 no such user")
+					continue
+				}
+
+				m.to = append(m.to, to)
+				tp.PrintfLine("250 OK")
+			case "DATA":
+				tp.PrintfLine("354 end data with <CR><LF>.<CR><LF>")
+
+				data, err := tp.ReadDotBytes()
+
+				if err != nil {
+					return
+				}
+
+				m.data = string(data)
+				tp.PrintfLine("250 OK")
+			case "QUIT":
+				tp.PrintfLine("221 bye")
+				received <- m
+				return
+			default:
+				tp.PrintfLine("502 not implemented")
+			}
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(l.Addr().String())
+
+	return host, port, received
+}
+
+func TestSMTPSend(t *testing.T) {
+	host, port, received := fakeSMTP(t, "")
+
+	m := mailer.NewSMTPMailer(host, port, "", "", "todo@weeekly.com")
+
+	err := m.Send("jihan@weeekly.com", "Verify your email address", "Hello,\n.\nbye")
+
+	assert.Nil(t, err)
+
+	mail := <-received
+
+	assert.Equal(t, "<todo@weeekly.com>", mail.from)
+	assert.Equal(t, []string{"<jihan@weeekly.com>"}, mail.to)
+
+	header, body, _ := strings.Cut(mail.data, "\n\n")
+
+	assert.Equal(t, []string{
+		"From: todo@weeekly.com",
+		"To: jihan@weeekly.com",
+		"Subject: Verify your email address",
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+	}, strings.Split(header, "\n"))
+
+	// the lone dot is escaped on the wire and arrives unchanged
+	assert.Equal(t, "Hello,\n.\nbye\n", body)
+}
+
+func TestSMTPSendRejectedRecipient(t *testing.T) {
+	host, port, _ := fakeSMTP(t, "nobody@weeekly.com")
+
+	m := mailer.NewSMTPMailer(host, port, "", "", "todo@weeekly.com")
+
+	err := m.Send("nobody@weeekly.com", "Verify your email address", "Hello")
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "550")
+}
+
+func TestSMTPSendLineBreakInHeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		to      string
+		subject string
+	}{
+		{"carriage return in recipient", "jihan@weeekly.com\rBcc: all@weeekly.com", "Hello"},
+		{"line feed in recipient", "jihan@weeekly.com\nBcc: all@weeekly.com", "Hello"},
+		{"line break in subject", "jihan@weeekly.com", "Hello\r\nBcc: all@weeekly.com"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// nothing listens on the address, a mail that got past the check
+			// would fail with a connection error instead
+			m := mailer.NewSMTPMailer("127.0.0.1", "1", "", "", "todo@weeekly.com")
+
+			err := m.Send(test.to, test.subject, "Hello")
+
+			assert.NotNil(t, err)
+			assert.Equal(t, "mail header contains a line break", err.Error())
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package mailer
+
+type mailerMock struct {
+}
+
+var (
+	Send func(to string, subject string, body string) error
+)
+
+func NewMailerMock() Mailer {
+	return &mailerMock{}
+}
+
+// Send implements Mailer.
+func (mm *mailerMock) Send(to string, subject string, body string) error {
+	return Send(to, subject, body)
+}
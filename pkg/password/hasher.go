@@ -0,0 +1,226 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Hasher hashes new passwords with one algorithm while verifying hashes of
+// every supported one, the algorithm and its parameters are encoded in the
+// hash so older hashes keep working after the configuration changes.
+type Hasher interface {
+	Hash(password string) (string, error)
+	Verify(password string, encoded string) bool
+
+	// NeedsRehash reports a hash made with another algorithm or other
+	// parameters than the ones new hashes are made with.
+	NeedsRehash(encoded string) bool
+}
+
+// Argon2Params are the argon2id parameters, Memory is in KiB.
+type Argon2Params struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultArgon2Params are the defaults of ARGON2_MEMORY_KIB,
+// ARGON2_ITERATIONS and ARGON2_PARALLELISM.
+var DefaultArgon2Params = Argon2Params{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 2,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// BcryptMaxLength is the length in bytes bcrypt stops reading a password at.
+const BcryptMaxLength = 72
+
+var errTooLong = errors.New("password is longer than bcrypt supports")
+
+type argon2idHasher struct {
+	params Argon2Params
+}
+
+type bcryptHasher struct {
+	cost int
+}
+
+func NewArgon2idHasher(params Argon2Params) Hasher {
+	return &argon2idHasher{params: params}
+}
+
+func NewBcryptHasher(cost int) Hasher {
+	return &bcryptHasher{cost: cost}
+}
+
+// NewHasher builds the hasher of algorithm, argon2id or bcrypt, from the
+// configured parameters. Parameters out of range are reported instead of
+// being truncated, the argon2id salt and key lengths are the defaults.
+func NewHasher(algorithm string, memoryKiB int, iterations int, parallelism int, bcryptCost int) (Hasher, error) {
+
+	switch algorithm {
+	case "bcrypt":
+		if bcryptCost < bcrypt.MinCost || bcryptCost > bcrypt.MaxCost {
+			return nil, fmt.Errorf("bcrypt cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+		}
+
+		return NewBcryptHasher(bcryptCost), nil
+	case "argon2id":
+		if parallelism < 1 || parallelism > math.MaxUint8 {
+			return nil, fmt.Errorf("argon2 parallelism must be between 1 and %d", math.MaxUint8)
+		}
+
+		if iterations < 1 || iterations > math.MaxUint32 {
+			return nil, fmt.Errorf("argon2 iterations must be between 1 and %d", uint32(math.MaxUint32))
+		}
+
+		// argon2 needs at least 8 KiB per lane
+		if memoryKiB < 8*parallelism || memoryKiB > math.MaxUint32 {
+			return nil, fmt.Errorf("argon2 memory must be between %d and %d KiB", 8*parallelism, uint32(math.MaxUint32))
+		}
+
+		params := DefaultArgon2Params
+		params.Memory, params.Iterations, params.Parallelism = uint32(memoryKiB), uint32(iterations), uint8(parallelism)
+
+		return NewArgon2idHasher(params), nil
+	default:
+		return nil, fmt.Errorf("password hasher must be one of argon2id or bcrypt, got %q", algorithm)
+	}
+}
+
+// Hash implements Hasher. The hash is in the PHC string format
+// $argon2id$v=19$m=65536,t=3,p=2$salt$key.
+func (h *argon2idHasher) Hash(password string) (string, error) {
+
+	salt := make([]byte, h.params.SaltLength)
+
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.params.Iterations, h.params.Memory, h.params.Parallelism, h.params.KeyLength)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, h.params.Memory, h.params.Iterations, h.params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify implements Hasher.
+func (h *argon2idHasher) Verify(password string, encoded string) bool {
+	return verify(password, encoded)
+}
+
+// NeedsRehash implements Hasher.
+func (h *argon2idHasher) NeedsRehash(encoded string) bool {
+
+	params, _, key, err := decodeArgon2id(encoded)
+
+	if err != nil {
+		return true
+	}
+
+	return params.Memory != h.params.Memory ||
+		params.Iterations != h.params.Iterations ||
+		params.Parallelism != h.params.Parallelism ||
+		uint32(len(key)) != h.params.KeyLength
+}
+
+// Hash implements Hasher.
+func (h *bcryptHasher) Hash(password string) (string, error) {
+
+	// bcrypt would silently ignore the rest of the password
+	if len(password) > BcryptMaxLength {
+		return "", errTooLong
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
+
+// Verify implements Hasher.
+func (h *bcryptHasher) Verify(password string, encoded string) bool {
+	return verify(password, encoded)
+}
+
+// NeedsRehash implements Hasher.
+func (h *bcryptHasher) NeedsRehash(encoded string) bool {
+
+	cost, err := bcrypt.Cost([]byte(encoded))
+
+	return err != nil || cost != h.cost
+}
+
+// verify checks a password against a hash of any supported algorithm.
+func verify(password string, encoded string) bool {
+
+	if !strings.HasPrefix(encoded, "$argon2id$") {
+		return bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password)) == nil
+	}
+
+	params, salt, key, err := decodeArgon2id(encoded)
+
+	if err != nil {
+		return false
+	}
+
+	other := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(key)))
+
+	return subtle.ConstantTimeCompare(key, other) == 1
+}
+
+func decodeArgon2id(encoded string) (*Argon2Params, []byte, []byte, error) {
+
+	parts := strings.Split(encoded, "$")
+
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return nil, nil, nil, errors.New("not an argon2id hash")
+	}
+
+	var version int
+
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, nil, nil, errors.New("unsupported argon2 version")
+	}
+
+	params := &Argon2Params{}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return nil, nil, nil, err
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+
+	return params, salt, key, nil
+}
@@ -0,0 +1,183 @@
+package password_test
+
+import (
+	"strings"
+	"testing"
+	"todo-app/pkg/password"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// fastArgon2Params keep the tests quick, the hashes are as valid as with the
+// default parameters.
+var fastArgon2Params = password.Argon2Params{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+func TestArgon2idRoundTrip(t *testing.T) {
+	h := password.NewArgon2idHasher(fastArgon2Params)
+
+	encoded, err := h.Hash("correct horse")
+
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(encoded, "$argon2id$v=19$m=64,t=1,p=1$"))
+	assert.True(t, h.Verify("correct horse", encoded))
+	assert.False(t, h.Verify("correct horsE", encoded))
+
+	// every hash has its own salt
+	other, _ := h.Hash("correct horse")
+
+	assert.NotEqual(t, encoded, other)
+}
+
+func TestArgon2idLongPassword(t *testing.T) {
+	h := password.NewArgon2idHasher(fastArgon2Params)
+	long := strings.Repeat("a", 100)
+
+	encoded, err := h.Hash(long)
+
+	assert.Nil(t, err)
+	assert.True(t, h.Verify(long, encoded))
+	assert.False(t, h.Verify(long[:password.BcryptMaxLength], encoded))
+}
+
+func TestBcryptRoundTrip(t *testing.T) {
+	h := password.NewBcryptHasher(bcrypt.MinCost)
+
+	encoded, err := h.Hash("correct horse")
+
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(encoded, "$2a$04$"))
+	assert.True(t, h.Verify("correct horse", encoded))
+	assert.False(t, h.Verify("correct horsE", encoded))
+}
+
+func TestBcryptTooLong(t *testing.T) {
+	h := password.NewBcryptHasher(bcrypt.MinCost)
+
+	encoded, err := h.Hash(strings.Repeat("a", password.BcryptMaxLength+1))
+
+	assert.Equal(t, "", encoded)
+	assert.NotNil(t, err)
+}
+
+func TestVerifyAcrossAlgorithms(t *testing.T) {
+	argon2id := password.NewArgon2idHasher(fastArgon2Params)
+	bcryptHasher := password.NewBcryptHasher(bcrypt.MinCost)
+
+	fromBcrypt, _ := bcryptHasher.Hash("correct horse")
+	fromArgon2id, _ := argon2id.Hash("correct horse")
+
+	assert.True(t, argon2id.Verify("correct horse", fromBcrypt))
+	assert.True(t, bcryptHasher.Verify("correct horse", fromArgon2id))
+	assert.False(t, argon2id.Verify("correct horse", "$argon2id$v=19$m=64,t=1,p=1$broken"))
+	assert.False(t, argon2id.Verify("correct horse", ""))
+}
+
+func TestNeedsRehash(t *testing.T) {
+	argon2id := password.NewArgon2idHasher(fastArgon2Params)
+	bcryptHasher := password.NewBcryptHasher(bcrypt.MinCost)
+
+	moreMemory := fastArgon2Params
+	moreMemory.Memory = 128
+
+	longerKey := fastArgon2Params
+	longerKey.KeyLength = 64
+
+	fromArgon2id, _ := argon2id.Hash("correct horse")
+	fromBcrypt, _ := bcryptHasher.Hash("correct horse")
+
+	tests := []struct {
+		name    string
+		hasher  password.Hasher
+		encoded string
+		rehash  bool
+	}{
+		{"same argon2id parameters", argon2id, fromArgon2id, false},
+		{"other argon2id memory", password.NewArgon2idHasher(moreMemory), fromArgon2id, true},
+		{"other argon2id key length", password.NewArgon2idHasher(longerKey), fromArgon2id, true},
+		{"bcrypt to argon2id", argon2id, fromBcrypt, true},
+		{"same bcrypt cost", bcryptHasher, fromBcrypt, false},
+		{"other bcrypt cost", password.NewBcryptHasher(bcrypt.MinCost + 1), fromBcrypt, true},
+		{"argon2id to bcrypt", bcryptHasher, fromArgon2id, true},
+		{"not a hash", argon2id, "plain", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.rehash, test.hasher.NeedsRehash(test.encoded))
+		})
+	}
+}
+
+func TestNewHasher(t *testing.T) {
+	tests := []struct {
+		name        string
+		algorithm   string
+		memoryKiB   int
+		iterations  int
+		parallelism int
+		bcryptCost  int
+		valid       bool
+	}{
+		{"argon2id defaults", "argon2id", 64 * 1024, 3, 2, 0, true},
+		{"bcrypt defaults", "bcrypt", 0, 0, 0, 10, true},
+		{"unknown algorithm", "md5", 64 * 1024, 3, 2, 10, false},
+		{"parallelism overflowing uint8", "argon2id", 64 * 1024, 3, 256, 10, false},
+		{"no parallelism", "argon2id", 64 * 1024, 3, 0, 10, false},
+		{"no iterations", "argon2id", 64 * 1024, 0, 2, 10, false},
+		{"negative iterations", "argon2id", 64 * 1024, -1, 2, 10, false},
+		{"memory below 8 KiB per lane", "argon2id", 15, 3, 2, 10, false},
+		{"bcrypt cost too low", "bcrypt", 0, 0, 0, bcrypt.MinCost - 1, false},
+		{"bcrypt cost too high", "bcrypt", 0, 0, 0, bcrypt.MaxCost + 1, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h, err := password.NewHasher(test.algorithm, test.memoryKiB, test.iterations, test.parallelism, test.bcryptCost)
+
+			if test.valid {
+				assert.Nil(t, err)
+				assert.NotNil(t, h)
+			} else {
+				assert.Nil(t, h)
+				assert.NotNil(t, err)
+			}
+		})
+	}
+}
+
+func TestPolicyCheck(t *testing.T) {
+	policy := password.NewPolicy(8, 20, []string{"Password1"})
+	policy.MaxBytes = password.BcryptMaxLength
+
+	bytesPolicy := password.NewPolicy(8, 100, nil)
+	bytesPolicy.MaxBytes = password.BcryptMaxLength
+
+	tests := []struct {
+		name     string
+		policy   *password.Policy
+		password string
+		valid    bool
+	}{
+		{"valid", policy, "correct horse", true},
+		{"too short", policy, "short", false},
+		{"too long", policy, strings.Repeat("a", 21), false},
+		{"characters not bytes", policy, strings.Repeat("é", 20), true},
+		{"blocked", policy, "password1", false},
+		{"within byte limit", bytesPolicy, strings.Repeat("a", password.BcryptMaxLength), true},
+		{"over byte limit", bytesPolicy, strings.Repeat("a", password.BcryptMaxLength+1), false},
+		{"multibyte over byte limit", bytesPolicy, strings.Repeat("é", 37), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.policy.Check(test.password)
+
+			if test.valid {
+				assert.Nil(t, err)
+			} else {
+				assert.NotNil(t, err)
+			}
+		})
+	}
+}
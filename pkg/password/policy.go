@@ -0,0 +1,82 @@
+package password
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// Policy is what a new password has to satisfy, lengths are counted in
+// characters and the blocklist is compared case insensitively. MaxBytes
+// limits the encoded length for hashers that truncate, like bcrypt.
+type Policy struct {
+	MinLength int
+	MaxLength int
+	MaxBytes  int
+	blocklist map[string]struct{}
+}
+
+func NewPolicy(minLength int, maxLength int, blocklist []string) *Policy {
+
+	p := &Policy{MinLength: minLength, MaxLength: maxLength, blocklist: map[string]struct{}{}}
+
+	for _, eachPassword := range blocklist {
+		p.blocklist[strings.ToLower(eachPassword)] = struct{}{}
+	}
+
+	return p
+}
+
+// LoadBlocklist reads one password per line, blank lines and lines starting
+// with # are skipped.
+func LoadBlocklist(path string) ([]string, error) {
+
+	f, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	passwords := []string{}
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		passwords = append(passwords, line)
+	}
+
+	return passwords, scanner.Err()
+}
+
+// Check returns why the password is rejected, or nil.
+func (p *Policy) Check(password string) error {
+
+	length := utf8.RuneCountInString(password)
+
+	if length < p.MinLength {
+		return fmt.Errorf("password must be at least %d characters", p.MinLength)
+	}
+
+	if p.MaxLength > 0 && length > p.MaxLength {
+		return fmt.Errorf("password must be at most %d characters", p.MaxLength)
+	}
+
+	if p.MaxBytes > 0 && len(password) > p.MaxBytes {
+		return fmt.Errorf("password must be at most %d bytes", p.MaxBytes)
+	}
+
+	if _, ok := p.blocklist[strings.ToLower(password)]; ok {
+		return fmt.Errorf("password is too common, choose another one")
+	}
+
+	return nil
+}
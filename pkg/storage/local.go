@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+type localStorage struct {
+	dir string
+}
+
+// NewLocalStorage keeps blobs as files below dir, which is created when it
+// doesn't exist yet.
+func NewLocalStorage(dir string) (Storage, error) {
+
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+
+	return &localStorage{dir: dir}, nil
+}
+
+// Put implements Storage. The blob is written to a temporary file first so a
+// failed upload never leaves a partial file under the key.
+func (ls *localStorage) Put(key string, r io.Reader, size int64, contentType string) error {
+
+	path, err := ls.path(key)
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// Get implements Storage.
+func (ls *localStorage) Get(key string) (io.ReadCloser, error) {
+
+	path, err := ls.path(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+
+	return f, err
+}
+
+// Delete implements Storage. Deleting a missing blob isn't an error.
+func (ls *localStorage) Delete(key string) error {
+
+	path, err := ls.path(key)
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// path maps a key to its file, keys escaping the directory are rejected.
+func (ls *localStorage) path(key string) (string, error) {
+
+	name := filepath.FromSlash(key)
+
+	if !filepath.IsLocal(name) {
+		return "", fmt.Errorf("invalid key %q", key)
+	}
+
+	return filepath.Join(ls.dir, name), nil
+}
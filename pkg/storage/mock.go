@@ -0,0 +1,31 @@
+package storage
+
+import "io"
+
+type storageMock struct {
+}
+
+var (
+	Put    func(key string, r io.Reader, size int64, contentType string) error
+	Get    func(key string) (io.ReadCloser, error)
+	Delete func(key string) error
+)
+
+func NewStorageMock() Storage {
+	return &storageMock{}
+}
+
+// Put implements Storage.
+func (sm *storageMock) Put(key string, r io.Reader, size int64, contentType string) error {
+	return Put(key, r, size, contentType)
+}
+
+// Get implements Storage.
+func (sm *storageMock) Get(key string) (io.ReadCloser, error) {
+	return Get(key)
+}
+
+// Delete implements Storage.
+func (sm *storageMock) Delete(key string) error {
+	return Delete(key)
+}
@@ -0,0 +1,276 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	amzDateFormat   = "20060102T150405Z"
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+type s3Storage struct {
+	client    *http.Client
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	pathStyle bool
+}
+
+// NewS3Storage keeps blobs in a bucket of an S3 compatible service. Requests
+// are signed with AWS signature version 4. MinIO and most other stand-ins
+// need pathStyle, which puts the bucket in the path instead of the host.
+func NewS3Storage(endpoint string, region string, bucket string, accessKey string, secretKey string, pathStyle bool) (Storage, error) {
+
+	u, err := url.Parse(endpoint)
+
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", endpoint)
+	}
+
+	if bucket == "" {
+		return nil, fmt.Errorf("S3 bucket can't be empty")
+	}
+
+	return &s3Storage{
+		client:    &http.Client{Timeout: 5 * time.Minute},
+		endpoint:  u,
+		region:    region,
+		bucket:    bucket,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		pathStyle: pathStyle,
+	}, nil
+}
+
+// Put implements Storage. The body is streamed without hashing it first, so
+// the payload is sent unsigned.
+func (ss *s3Storage) Put(key string, r io.Reader, size int64, contentType string) error {
+
+	req, err := http.NewRequest(http.MethodPut, ss.objectURL(key), r)
+
+	if err != nil {
+		return err
+	}
+
+	req.ContentLength = size
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	res, err := ss.do(req)
+
+	if err != nil {
+		return err
+	}
+
+	res.Body.Close()
+
+	return nil
+}
+
+// Get implements Storage.
+func (ss *s3Storage) Get(key string) (io.ReadCloser, error) {
+
+	req, err := http.NewRequest(http.MethodGet, ss.objectURL(key), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := ss.do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Body, nil
+}
+
+// Delete implements Storage. S3 reports success for missing objects as well.
+func (ss *s3Storage) Delete(key string) error {
+
+	req, err := http.NewRequest(http.MethodDelete, ss.objectURL(key), nil)
+
+	if err != nil {
+		return err
+	}
+
+	res, err := ss.do(req)
+
+	if err != nil && err != ErrNotFound {
+		return err
+	}
+
+	if res != nil {
+		res.Body.Close()
+	}
+
+	return nil
+}
+
+// do signs and sends the request, responses other than 2xx are turned into
+// errors.
+func (ss *s3Storage) do(req *http.Request) (*http.Response, error) {
+
+	ss.sign(req, unsignedPayload, time.Now())
+
+	res, err := ss.client.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return res, nil
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	detail, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+
+	return nil, fmt.Errorf("s3 %s %s: %s %s", req.Method, req.URL.Path, res.Status, strings.TrimSpace(string(detail)))
+}
+
+func (ss *s3Storage) objectURL(key string) string {
+
+	u := *ss.endpoint
+	path := strings.TrimSuffix(u.Path, "/") + "/" + key
+
+	if ss.pathStyle {
+		path = strings.TrimSuffix(u.Path, "/") + "/" + ss.bucket + "/" + key
+	} else {
+		u.Host = ss.bucket + "." + u.Host
+	}
+
+	u.Path, u.RawPath = path, escapePath(path)
+
+	return u.String()
+}
+
+// sign adds the AWS signature version 4 headers to the request. The host and
+// every header already set on the request are signed.
+func (ss *s3Storage) sign(req *http.Request, payloadHash string, now time.Time) {
+
+	amzDate := now.UTC().Format(amzDateFormat)
+	scope := strings.Join([]string{amzDate[:8], ss.region, "s3", "aws4_request"}, "/")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.Join(values, ",")
+	}
+
+	names := []string{}
+
+	for name := range headers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	canonicalHeaders := ""
+
+	for _, name := range names {
+		canonicalHeaders += name + ":" + strings.TrimSpace(headers[name]) + "\n"
+	}
+
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	hashed := sha256.Sum256([]byte(canonicalRequest))
+
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(hashed[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+ss.secretKey), amzDate[:8])
+	key = hmacSHA256(key, ss.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		ss.accessKey, scope, signedHeaders, signature))
+}
+
+func canonicalQuery(values url.Values) string {
+
+	pairs := []string{}
+
+	for name, eachValues := range values {
+		for _, value := range eachValues {
+			pairs = append(pairs, escape(name)+"="+escape(value))
+		}
+	}
+
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, "&")
+}
+
+// escapePath URI encodes every segment of the path the way S3 expects it.
+func escapePath(path string) string {
+
+	segments := strings.Split(path, "/")
+
+	for i, segment := range segments {
+		segments[i] = escape(segment)
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// escape percent encodes everything but the unreserved characters of
+// RFC 3986, which differs from url.QueryEscape for spaces and tildes.
+func escape(s string) string {
+
+	var b strings.Builder
+
+	for _, c := range []byte(s) {
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || strings.IndexByte("-._~", c) >= 0 {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
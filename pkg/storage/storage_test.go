@@ -0,0 +1,317 @@
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"todo-app/pkg/storage"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	region    = "eu-central-1"
+	accessKey = "AKIDEXAMPLE"
+	secretKey = "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"
+)
+
+// fakeS3 keeps objects in memory and only answers requests carrying a valid
+// signature version 4 for secretKey.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	hosts   []string
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *httptest.Server) {
+
+	fake := &fakeS3{objects: map[string][]byte{}}
+	server := httptest.NewServer(fake)
+
+	t.Cleanup(server.Close)
+
+	return fake, server
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.hosts = append(f.hosts, r.Host)
+
+	if !validSignature(r) {
+		http.Error(w, "SignatureDoesNotMatch", http.StatusForbidden)
+		return
+	}
+
+	path := r.URL.EscapedPath()
+
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		f.objects[path] = body
+	case http.MethodGet:
+		body, ok := f.objects[path]
+
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+
+		w.Write(body)
+	case http.MethodDelete:
+		delete(f.objects, path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// validSignature recomputes the signature of the request the way S3 does,
+// from the headers listed in the Authorization header.
+func validSignature(r *http.Request) bool {
+
+	fields := map[string]string{}
+
+	for _, field := range strings.Split(strings.TrimPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 "), ", ") {
+		name, value, _ := strings.Cut(field, "=")
+		fields[name] = value
+	}
+
+	amzDate := r.Header.Get("X-Amz-Date")
+
+	if len(amzDate) != len("20060102T150405Z") {
+		return false
+	}
+
+	scope := amzDate[:8] + "/" + region + "/s3/aws4_request"
+
+	if fields["Credential"] != accessKey+"/"+scope {
+		return false
+	}
+
+	signedHeaders := strings.Split(fields["SignedHeaders"], ";")
+
+	for _, required := range []string{"host", "x-amz-content-sha256", "x-amz-date"} {
+		if !contains(signedHeaders, required) {
+			return false
+		}
+	}
+
+	canonicalHeaders := ""
+
+	for _, name := range signedHeaders {
+		value := r.Header.Get(name)
+
+		if name == "host" {
+			value = r.Host
+		}
+
+		canonicalHeaders += name + ":" + value + "\n"
+	}
+
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		r.URL.EscapedPath(),
+		r.URL.RawQuery,
+		canonicalHeaders,
+		fields["SignedHeaders"],
+		r.Header.Get("X-Amz-Content-Sha256"),
+	}, "\n")
+
+	hashed := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hashed[:])
+
+	key := []byte("AWS4" + secretKey)
+
+	for _, part := range []string{amzDate[:8], region, "s3", "aws4_request", stringToSign} {
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(part))
+		key = mac.Sum(nil)
+	}
+
+	return hmac.Equal([]byte(hex.EncodeToString(key)), []byte(fields["Signature"]))
+}
+
+func contains(values []string, value string) bool {
+	for _, eachValue := range values {
+		if eachValue == value {
+			return true
+		}
+	}
+	return false
+}
+
+func get(t *testing.T, s storage.Storage, key string) string {
+
+	body, err := s.Get(key)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer body.Close()
+
+	content, _ := io.ReadAll(body)
+
+	return string(content)
+}
+
+func TestS3PathStyle(t *testing.T) {
+	fake, server := newFakeS3(t)
+
+	s, err := storage.NewS3Storage(server.URL, region, "attachments", accessKey, secretKey, true)
+
+	assert.Nil(t, err)
+
+	// the key is escaped segment by segment, and signed that way
+	key := "todos/1/buy milk (2)~.txt"
+
+	assert.Nil(t, s.Put(key, strings.NewReader("buy milk"), 8, "text/plain"))
+	assert.Contains(t, fake.objects, "/attachments/todos/1/buy%20milk%20%282%29~.txt")
+	assert.Equal(t, "buy milk", get(t, s, key))
+
+	assert.Nil(t, s.Delete(key))
+	assert.Empty(t, fake.objects)
+
+	for _, host := range fake.hosts {
+		assert.Equal(t, server.Listener.Addr().String(), host)
+	}
+}
+
+func TestS3VirtualHostStyle(t *testing.T) {
+	fake, server := newFakeS3(t)
+
+	// every host is dialed at the fake, the bucket only shows up in the
+	// Host header
+	transport := http.DefaultTransport
+
+	http.DefaultTransport = &http.Transport{
+		DialContext: func(ctx context.Context, network string, addr string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+		},
+	}
+
+	defer func() { http.DefaultTransport = transport }()
+
+	s, err := storage.NewS3Storage("http://s3.example.com/", region, "attachments", accessKey, secretKey, false)
+
+	assert.Nil(t, err)
+
+	assert.Nil(t, s.Put("todos/1/key", bytes.NewReader([]byte("buy milk")), 8, "text/plain"))
+	assert.Contains(t, fake.objects, "/todos/1/key")
+	assert.Equal(t, "buy milk", get(t, s, "todos/1/key"))
+	assert.Equal(t, []string{"attachments.s3.example.com", "attachments.s3.example.com"}, fake.hosts)
+}
+
+func TestS3NotFound(t *testing.T) {
+	_, server := newFakeS3(t)
+
+	s, _ := storage.NewS3Storage(server.URL, region, "attachments", accessKey, secretKey, true)
+
+	body, err := s.Get("todos/1/missing")
+
+	assert.Nil(t, body)
+	assert.Equal(t, storage.ErrNotFound, err)
+
+	// deleting a missing object isn't an error
+	assert.Nil(t, s.Delete("todos/1/missing"))
+}
+
+func TestS3WrongSecret(t *testing.T) {
+	_, server := newFakeS3(t)
+
+	s, _ := storage.NewS3Storage(server.URL, region, "attachments", accessKey, "other", true)
+
+	err := s.Put("todos/1/key", strings.NewReader("buy milk"), 8, "text/plain")
+
+	assert.NotNil(t, err)
+	assert.NotEqual(t, storage.ErrNotFound, err)
+	assert.Contains(t, err.Error(), "403")
+	assert.Contains(t, err.Error(), "SignatureDoesNotMatch")
+}
+
+func TestNewS3Storage(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		bucket   string
+		valid    bool
+	}{
+		{"valid", "https://s3.eu-central-1.amazonaws.com", "attachments", true},
+		{"without scheme", "s3.eu-central-1.amazonaws.com", "attachments", false},
+		{"not a url", "https://%zz", "attachments", false},
+		{"without bucket", "https://s3.eu-central-1.amazonaws.com", "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := storage.NewS3Storage(test.endpoint, region, test.bucket, accessKey, secretKey, false)
+
+			if test.valid {
+				assert.Nil(t, err)
+				assert.NotNil(t, s)
+			} else {
+				assert.Nil(t, s)
+				assert.NotNil(t, err)
+			}
+		})
+	}
+}
+
+func TestLocalStorage(t *testing.T) {
+	s, err := storage.NewLocalStorage(t.TempDir())
+
+	assert.Nil(t, err)
+
+	assert.Nil(t, s.Put("todos/1/key", strings.NewReader("buy milk"), 8, "text/plain"))
+	assert.Equal(t, "buy milk", get(t, s, "todos/1/key"))
+
+	// an upload replaces the blob under the key
+	assert.Nil(t, s.Put("todos/1/key", strings.NewReader("buy eggs"), 8, "text/plain"))
+	assert.Equal(t, "buy eggs", get(t, s, "todos/1/key"))
+
+	assert.Nil(t, s.Delete("todos/1/key"))
+	assert.Nil(t, s.Delete("todos/1/key"))
+
+	body, err := s.Get("todos/1/key")
+
+	assert.Nil(t, body)
+	assert.Equal(t, storage.ErrNotFound, err)
+}
+
+func TestLocalStorageKeysEscapingTheDirectory(t *testing.T) {
+	s, _ := storage.NewLocalStorage(t.TempDir())
+
+	tests := []struct {
+		name string
+		key  string
+	}{
+		{"parent", "../x"},
+		{"nested parent", "todos/../../x"},
+		{"absolute", "/etc/passwd"},
+		{"empty", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.NotNil(t, s.Put(test.key, strings.NewReader("buy milk"), 8, "text/plain"))
+
+			body, err := s.Get(test.key)
+
+			assert.Nil(t, body)
+			assert.NotNil(t, err)
+			assert.NotEqual(t, storage.ErrNotFound, err)
+
+			assert.NotNil(t, s.Delete(test.key))
+		})
+	}
+}
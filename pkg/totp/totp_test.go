@@ -0,0 +1,138 @@
+package totp_test
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+	"todo-app/pkg/totp"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rfcSecret is the SHA1 seed of RFC 6238 Appendix B, "12345678901234567890".
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCodeRFC6238(t *testing.T) {
+	// the RFC lists 8 digit codes, 6 digit codes are their last 6 digits
+	tests := []struct {
+		unix int64
+		rfc  string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.rfc, func(t *testing.T) {
+			code, err := totp.Code(rfcSecret, totp.Step(time.Unix(test.unix, 0)))
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.rfc[2:], code)
+		})
+	}
+}
+
+func TestCodeLowercaseSecret(t *testing.T) {
+	code, err := totp.Code("gezdgnbvgy3tqojqgezdgnbvgy3tqojq", 1)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "287082", code)
+}
+
+func TestCodeInvalidSecret(t *testing.T) {
+	code, err := totp.Code("not base32!", 1)
+
+	assert.Equal(t, "", code)
+	assert.NotNil(t, err)
+}
+
+func TestValidateSkew(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	current := totp.Step(now)
+
+	tests := []struct {
+		name  string
+		step  int64
+		skew  int
+		valid bool
+	}{
+		{"current step", current, 0, true},
+		{"previous step without skew", current - 1, 0, false},
+		{"previous step", current - 1, 1, true},
+		{"next step", current + 1, 1, true},
+		{"two steps behind", current - 2, 1, false},
+		{"two steps ahead", current + 2, 1, false},
+		{"two steps behind with wider skew", current - 2, 2, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			code, _ := totp.Code(rfcSecret, test.step)
+
+			step, ok := totp.Validate(rfcSecret, code, now, test.skew)
+
+			assert.Equal(t, test.valid, ok)
+
+			if test.valid {
+				assert.Equal(t, test.step, step)
+			}
+		})
+	}
+}
+
+func TestValidateWrongCode(t *testing.T) {
+	now := time.Unix(59, 0)
+
+	_, ok := totp.Validate(rfcSecret, "287083", now, 1)
+	assert.False(t, ok)
+
+	_, ok = totp.Validate(rfcSecret, "94287082", now, 1)
+	assert.False(t, ok)
+
+	_, ok = totp.Validate("not base32!", "287082", now, 1)
+	assert.False(t, ok)
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+
+	assert.Nil(t, err)
+	assert.Len(t, secret, 32)
+
+	other, _ := totp.GenerateSecret()
+
+	assert.NotEqual(t, secret, other)
+
+	_, err = totp.Code(secret, 1)
+
+	assert.Nil(t, err)
+}
+
+func TestURI(t *testing.T) {
+	uri := totp.URI("Weeekly Todo", "jihan+todo@weeekly.com?#/", "GEZDGNBVGY3TQOJQ")
+
+	assert.Equal(t, "otpauth://totp/Weeekly%20Todo:jihan+todo@weeekly.com%3F%23%2F?algorithm=SHA1&digits=6&issuer=Weeekly+Todo&period=30&secret=GEZDGNBVGY3TQOJQ", uri)
+
+	// the label and the parameters come back unchanged
+	u, err := url.Parse(uri)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, "/Weeekly Todo:jihan+todo@weeekly.com?#/", u.Path)
+	assert.Equal(t, "Weeekly Todo", u.Query().Get("issuer"))
+	assert.Equal(t, "GEZDGNBVGY3TQOJQ", u.Query().Get("secret"))
+	assert.Equal(t, "6", u.Query().Get("digits"))
+	assert.Equal(t, "30", u.Query().Get("period"))
+}
+
+func TestURIColonInLabel(t *testing.T) {
+	uri := totp.URI("Weeekly: Todo", "jihan@weeekly.com", "GEZDGNBVGY3TQOJQ")
+
+	assert.Equal(t, "otpauth://totp/Weeekly%3A%20Todo:jihan@weeekly.com?algorithm=SHA1&digits=6&issuer=Weeekly%3A+Todo&period=30&secret=GEZDGNBVGY3TQOJQ", uri)
+}
@@ -0,0 +1,82 @@
+package attachments_pg
+
+import (
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+	"todo-app/repo/attachments_repo"
+
+	"gorm.io/gorm"
+)
+
+type attachmentsPg struct {
+	db *gorm.DB
+}
+
+func NewAttachmentsRepo(db *gorm.DB) attachments_repo.AttachmentsRepo {
+	return &attachmentsPg{db: db}
+}
+
+// Add implements attachments_repo.AttachmentsRepo.
+func (pg *attachmentsPg) Add(attachment *entity.Attachment) errs.Error {
+
+	tx := pg.db.Begin()
+
+	if err := tx.Create(attachment).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
+
+// Fetch implements attachments_repo.AttachmentsRepo. Attachments are listed
+// oldest first.
+func (pg *attachmentsPg) Fetch(todoId uint) ([]*entity.Attachment, errs.Error) {
+
+	attachments := []*entity.Attachment{}
+
+	if err := pg.db.Where("todo_id = ?", todoId).Order("id").Find(&attachments).Error; err != nil {
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	return attachments, nil
+}
+
+// Detail implements attachments_repo.AttachmentsRepo.
+func (pg *attachmentsPg) Detail(attachmentId uint) (*entity.Attachment, errs.Error) {
+
+	attachment := entity.Attachment{}
+
+	if err := pg.db.First(&attachment, attachmentId).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errs.NewNotFoundError("attachment not found")
+		}
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	return &attachment, nil
+}
+
+// Delete implements attachments_repo.AttachmentsRepo. The row is deleted for
+// good since its blob is removed along with it.
+func (pg *attachmentsPg) Delete(attachmentId uint) errs.Error {
+
+	tx := pg.db.Begin()
+
+	if err := tx.Unscoped().Delete(&entity.Attachment{}, attachmentId).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
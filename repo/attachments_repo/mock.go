@@ -0,0 +1,40 @@
+package attachments_repo
+
+import (
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+)
+
+type repoMock struct {
+}
+
+var (
+	Add    func(attachment *entity.Attachment) errs.Error
+	Fetch  func(todoId uint) ([]*entity.Attachment, errs.Error)
+	Detail func(attachmentId uint) (*entity.Attachment, errs.Error)
+	Delete func(attachmentId uint) errs.Error
+)
+
+func NewRepoMock() AttachmentsRepo {
+	return &repoMock{}
+}
+
+// Add implements AttachmentsRepo.
+func (rm *repoMock) Add(attachment *entity.Attachment) errs.Error {
+	return Add(attachment)
+}
+
+// Fetch implements AttachmentsRepo.
+func (rm *repoMock) Fetch(todoId uint) ([]*entity.Attachment, errs.Error) {
+	return Fetch(todoId)
+}
+
+// Detail implements AttachmentsRepo.
+func (rm *repoMock) Detail(attachmentId uint) (*entity.Attachment, errs.Error) {
+	return Detail(attachmentId)
+}
+
+// Delete implements AttachmentsRepo.
+func (rm *repoMock) Delete(attachmentId uint) errs.Error {
+	return Delete(attachmentId)
+}
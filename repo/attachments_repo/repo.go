@@ -0,0 +1,13 @@
+package attachments_repo
+
+import (
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+)
+
+type AttachmentsRepo interface {
+	Add(attachment *entity.Attachment) errs.Error
+	Fetch(todoId uint) ([]*entity.Attachment, errs.Error)
+	Detail(attachmentId uint) (*entity.Attachment, errs.Error)
+	Delete(attachmentId uint) errs.Error
+}
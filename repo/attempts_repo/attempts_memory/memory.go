@@ -0,0 +1,76 @@
+package attempts_memory
+
+import (
+	"sync"
+	"time"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+	"todo-app/repo/attempts_repo"
+)
+
+// attemptsMemory keeps attempts in process, so each instance of the app
+// counts failures on its own.
+type attemptsMemory struct {
+	mu        sync.Mutex
+	attempts  map[string]entity.LoginAttempt
+	lastSweep time.Time
+}
+
+func NewAttemptsRepo() attempts_repo.AttemptsRepo {
+	return &attemptsMemory{attempts: map[string]entity.LoginAttempt{}, lastSweep: time.Now()}
+}
+
+// Fetch implements attempts_repo.AttemptsRepo.
+func (m *attemptsMemory) Fetch(key string) (*entity.LoginAttempt, errs.Error) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	attempt, ok := m.attempts[key]
+
+	if !ok {
+		return &entity.LoginAttempt{Key: key}, nil
+	}
+
+	return &attempt, nil
+}
+
+// Fail implements attempts_repo.AttemptsRepo.
+func (m *attemptsMemory) Fail(key string, maxFailures int, now time.Time) (*entity.LoginAttempt, errs.Error) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	lockout := entity.LoginLockout()
+
+	// attempts that are neither blocked nor remembered anymore are dropped
+	if now.Sub(m.lastSweep) >= lockout {
+		for k, eachAttempt := range m.attempts {
+			if now.Sub(eachAttempt.LastFailedAt) >= lockout && !now.Before(eachAttempt.BlockedUntil) {
+				delete(m.attempts, k)
+			}
+		}
+		m.lastSweep = now
+	}
+
+	attempt := m.attempts[key]
+	attempt.Key = key
+	attempt.Fail(now, maxFailures)
+
+	m.attempts[key] = attempt
+
+	return &attempt, nil
+}
+
+// Reset implements attempts_repo.AttemptsRepo.
+func (m *attemptsMemory) Reset(keys ...string) errs.Error {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, key := range keys {
+		delete(m.attempts, key)
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package attempts_pg
+
+import (
+	"time"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+	"todo-app/repo/attempts_repo"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// attemptsPg shares attempts between every instance of the app.
+type attemptsPg struct {
+	db *gorm.DB
+}
+
+func NewAttemptsRepo(db *gorm.DB) attempts_repo.AttemptsRepo {
+	return &attemptsPg{db: db}
+}
+
+// Fetch implements attempts_repo.AttemptsRepo.
+func (pg *attemptsPg) Fetch(key string) (*entity.LoginAttempt, errs.Error) {
+
+	attempt := entity.LoginAttempt{}
+
+	if err := pg.db.First(&attempt, "key = ?", key).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &entity.LoginAttempt{Key: key}, nil
+		}
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	return &attempt, nil
+}
+
+// Fail implements attempts_repo.AttemptsRepo.
+func (pg *attemptsPg) Fail(key string, maxFailures int, now time.Time) (*entity.LoginAttempt, errs.Error) {
+
+	tx := pg.db.Begin()
+
+	// the row is created first so concurrent failures of a new key all lock
+	// the same row
+	err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&entity.LoginAttempt{Key: key}).Error
+
+	if err != nil {
+		tx.Rollback()
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	attempt := entity.LoginAttempt{}
+
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&attempt, "key = ?", key).Error; err != nil {
+		tx.Rollback()
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	attempt.Fail(now, maxFailures)
+
+	if err := tx.Save(&attempt).Error; err != nil {
+		tx.Rollback()
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	return &attempt, nil
+}
+
+// Reset implements attempts_repo.AttemptsRepo.
+func (pg *attemptsPg) Reset(keys ...string) errs.Error {
+
+	tx := pg.db.Begin()
+
+	if err := tx.Delete(&entity.LoginAttempt{}, "key IN ?", keys).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package attempts_repo
+
+import (
+	"time"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+)
+
+type repoMock struct {
+}
+
+var (
+	Fetch func(key string) (*entity.LoginAttempt, errs.Error)
+	Fail  func(key string, maxFailures int, now time.Time) (*entity.LoginAttempt, errs.Error)
+	Reset func(keys ...string) errs.Error
+)
+
+func NewRepoMock() AttemptsRepo {
+	return &repoMock{}
+}
+
+// Fetch implements AttemptsRepo.
+func (rm *repoMock) Fetch(key string) (*entity.LoginAttempt, errs.Error) {
+	return Fetch(key)
+}
+
+// Fail implements AttemptsRepo.
+func (rm *repoMock) Fail(key string, maxFailures int, now time.Time) (*entity.LoginAttempt, errs.Error) {
+	return Fail(key, maxFailures, now)
+}
+
+// Reset implements AttemptsRepo.
+func (rm *repoMock) Reset(keys ...string) errs.Error {
+	return Reset(keys...)
+}
@@ -0,0 +1,13 @@
+package attempts_repo
+
+import (
+	"time"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+)
+
+type AttemptsRepo interface {
+	Fetch(key string) (*entity.LoginAttempt, errs.Error)
+	Fail(key string, maxFailures int, now time.Time) (*entity.LoginAttempt, errs.Error)
+	Reset(keys ...string) errs.Error
+}
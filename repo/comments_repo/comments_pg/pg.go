@@ -0,0 +1,135 @@
+package comments_pg
+
+import (
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+	"todo-app/repo/comments_repo"
+
+	"gorm.io/gorm"
+)
+
+type commentsPg struct {
+	db *gorm.DB
+}
+
+func NewCommentsRepo(db *gorm.DB) comments_repo.CommentsRepo {
+	return &commentsPg{db: db}
+}
+
+// Add implements comments_repo.CommentsRepo. The mentions of the comment are
+// created along with it.
+func (pg *commentsPg) Add(comment *entity.Comment) errs.Error {
+
+	tx := pg.db.Begin()
+
+	if err := tx.Omit("User").Create(comment).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
+
+// Fetch implements comments_repo.CommentsRepo. Comments are listed oldest
+// first, starting after the comment afterId when it isn't 0.
+func (pg *commentsPg) Fetch(todoId uint, afterId uint, limit int) ([]*entity.Comment, errs.Error) {
+
+	comments := []*entity.Comment{}
+
+	query := pg.db.
+		Preload("User").
+		Preload("Mentions").
+		Where("todo_id = ?", todoId)
+
+	if afterId != 0 {
+		query = query.Where("id > ?", afterId)
+	}
+
+	err := query.
+		Order("id").
+		Limit(limit).
+		Find(&comments).Error
+
+	if err != nil {
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	return comments, nil
+}
+
+// Detail implements comments_repo.CommentsRepo.
+func (pg *commentsPg) Detail(commentId uint) (*entity.Comment, errs.Error) {
+
+	comment := entity.Comment{}
+
+	if err := pg.db.Preload("User").Preload("Mentions").First(&comment, commentId).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errs.NewNotFoundError("comment not found")
+		}
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	return &comment, nil
+}
+
+// Modify implements comments_repo.CommentsRepo. The mentions of the comment
+// are replaced by the ones of its new body.
+func (pg *commentsPg) Modify(comment *entity.Comment) errs.Error {
+
+	tx := pg.db.Begin()
+
+	err := tx.Model(&entity.Comment{}).
+		Where("id = ?", comment.ID).
+		Updates(map[string]any{"body": comment.Body, "edited_at": comment.EditedAt}).Error
+
+	if err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Where("comment_id = ?", comment.ID).Delete(&entity.CommentMention{}).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	for i := range comment.Mentions {
+		comment.Mentions[i].ID, comment.Mentions[i].CommentID = 0, comment.ID
+	}
+
+	if len(comment.Mentions) > 0 {
+		if err := tx.Create(&comment.Mentions).Error; err != nil {
+			tx.Rollback()
+			return errs.NewInternalServerError("something went wrong")
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
+
+// Delete implements comments_repo.CommentsRepo.
+func (pg *commentsPg) Delete(commentId uint) errs.Error {
+
+	tx := pg.db.Begin()
+
+	if err := tx.Delete(&entity.Comment{}, commentId).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package comments_repo
+
+import (
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+)
+
+type repoMock struct {
+}
+
+var (
+	Add    func(comment *entity.Comment) errs.Error
+	Fetch  func(todoId uint, afterId uint, limit int) ([]*entity.Comment, errs.Error)
+	Detail func(commentId uint) (*entity.Comment, errs.Error)
+	Modify func(comment *entity.Comment) errs.Error
+	Delete func(commentId uint) errs.Error
+)
+
+func NewRepoMock() CommentsRepo {
+	return &repoMock{}
+}
+
+// Add implements CommentsRepo.
+func (rm *repoMock) Add(comment *entity.Comment) errs.Error {
+	return Add(comment)
+}
+
+// Fetch implements CommentsRepo.
+func (rm *repoMock) Fetch(todoId uint, afterId uint, limit int) ([]*entity.Comment, errs.Error) {
+	return Fetch(todoId, afterId, limit)
+}
+
+// Detail implements CommentsRepo.
+func (rm *repoMock) Detail(commentId uint) (*entity.Comment, errs.Error) {
+	return Detail(commentId)
+}
+
+// Modify implements CommentsRepo.
+func (rm *repoMock) Modify(comment *entity.Comment) errs.Error {
+	return Modify(comment)
+}
+
+// Delete implements CommentsRepo.
+func (rm *repoMock) Delete(commentId uint) errs.Error {
+	return Delete(commentId)
+}
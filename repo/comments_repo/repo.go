@@ -0,0 +1,14 @@
+package comments_repo
+
+import (
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+)
+
+type CommentsRepo interface {
+	Add(comment *entity.Comment) errs.Error
+	Fetch(todoId uint, afterId uint, limit int) ([]*entity.Comment, errs.Error)
+	Detail(commentId uint) (*entity.Comment, errs.Error)
+	Modify(comment *entity.Comment) errs.Error
+	Delete(commentId uint) errs.Error
+}
@@ -0,0 +1,164 @@
+package mfa_pg
+
+import (
+	"time"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+	"todo-app/repo/mfa_repo"
+
+	"gorm.io/gorm"
+)
+
+type mfaPg struct {
+	db *gorm.DB
+}
+
+func NewMFARepo(db *gorm.DB) mfa_repo.MFARepo {
+	return &mfaPg{db: db}
+}
+
+// SetSecret implements mfa_repo.MFARepo.
+func (pg *mfaPg) SetSecret(userId uint, secret string) errs.Error {
+
+	tx := pg.db.Begin()
+
+	result := tx.Model(&entity.User{}).
+		Where("id = ? AND totp_enabled_at IS NULL", userId).
+		Update("totp_secret", secret)
+
+	if result.Error != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return errs.NewConflictError("two factor authentication is already enabled")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
+
+// Enable implements mfa_repo.MFARepo.
+func (pg *mfaPg) Enable(userId uint, step int64, codes []*entity.RecoveryCode) errs.Error {
+
+	tx := pg.db.Begin()
+
+	result := tx.Model(&entity.User{}).
+		Where("id = ? AND totp_enabled_at IS NULL", userId).
+		Updates(map[string]any{"totp_enabled_at": time.Now(), "totp_last_step": step})
+
+	if result.Error != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return errs.NewConflictError("two factor authentication is already enabled")
+	}
+
+	if err := tx.Unscoped().Delete(&entity.RecoveryCode{}, "user_id = ?", userId).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Create(codes).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
+
+// Disable implements mfa_repo.MFARepo.
+func (pg *mfaPg) Disable(userId uint) errs.Error {
+
+	tx := pg.db.Begin()
+
+	err := tx.Model(&entity.User{}).
+		Where("id = ?", userId).
+		Updates(map[string]any{"totp_secret": "", "totp_enabled_at": nil, "totp_last_step": 0}).Error
+
+	if err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Unscoped().Delete(&entity.RecoveryCode{}, "user_id = ?", userId).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
+
+// UseStep implements mfa_repo.MFARepo.
+func (pg *mfaPg) UseStep(userId uint, step int64) errs.Error {
+
+	tx := pg.db.Begin()
+
+	// a code is only accepted for a step later than the last used one
+	result := tx.Model(&entity.User{}).
+		Where("id = ? AND totp_last_step < ?", userId, step).
+		Update("totp_last_step", step)
+
+	if result.Error != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return errs.NewConflictError("code has been used")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
+
+// UseRecoveryCode implements mfa_repo.MFARepo.
+func (pg *mfaPg) UseRecoveryCode(userId uint, codeHash string) errs.Error {
+
+	tx := pg.db.Begin()
+
+	result := tx.Model(&entity.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userId, codeHash).
+		Update("used_at", time.Now())
+
+	if result.Error != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return errs.NewNotFoundError("recovery code not found")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
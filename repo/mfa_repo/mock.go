@@ -0,0 +1,46 @@
+package mfa_repo
+
+import (
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+)
+
+type repoMock struct {
+}
+
+var (
+	SetSecret       func(userId uint, secret string) errs.Error
+	Enable          func(userId uint, step int64, codes []*entity.RecoveryCode) errs.Error
+	Disable         func(userId uint) errs.Error
+	UseStep         func(userId uint, step int64) errs.Error
+	UseRecoveryCode func(userId uint, codeHash string) errs.Error
+)
+
+func NewRepoMock() MFARepo {
+	return &repoMock{}
+}
+
+// SetSecret implements MFARepo.
+func (rm *repoMock) SetSecret(userId uint, secret string) errs.Error {
+	return SetSecret(userId, secret)
+}
+
+// Enable implements MFARepo.
+func (rm *repoMock) Enable(userId uint, step int64, codes []*entity.RecoveryCode) errs.Error {
+	return Enable(userId, step, codes)
+}
+
+// Disable implements MFARepo.
+func (rm *repoMock) Disable(userId uint) errs.Error {
+	return Disable(userId)
+}
+
+// UseStep implements MFARepo.
+func (rm *repoMock) UseStep(userId uint, step int64) errs.Error {
+	return UseStep(userId, step)
+}
+
+// UseRecoveryCode implements MFARepo.
+func (rm *repoMock) UseRecoveryCode(userId uint, codeHash string) errs.Error {
+	return UseRecoveryCode(userId, codeHash)
+}
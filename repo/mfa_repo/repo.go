@@ -0,0 +1,14 @@
+package mfa_repo
+
+import (
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+)
+
+type MFARepo interface {
+	SetSecret(userId uint, secret string) errs.Error
+	Enable(userId uint, step int64, codes []*entity.RecoveryCode) errs.Error
+	Disable(userId uint) errs.Error
+	UseStep(userId uint, step int64) errs.Error
+	UseRecoveryCode(userId uint, codeHash string) errs.Error
+}
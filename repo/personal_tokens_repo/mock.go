@@ -0,0 +1,53 @@
+package personal_tokens_repo
+
+import (
+	"time"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+)
+
+type repoMock struct {
+}
+
+var (
+	Add         func(token *entity.PersonalToken) errs.Error
+	FetchByHash func(tokenHash string) (*entity.PersonalToken, errs.Error)
+	FetchByUser func(userId uint) ([]*entity.PersonalToken, errs.Error)
+	Touch       func(tokenHash string, usedAt time.Time) errs.Error
+	Revoke      func(userId uint, tokenId uint) errs.Error
+	RevokeAll   func(userId uint) errs.Error
+)
+
+func NewRepoMock() PersonalTokensRepo {
+	return &repoMock{}
+}
+
+// Add implements PersonalTokensRepo.
+func (rm *repoMock) Add(token *entity.PersonalToken) errs.Error {
+	return Add(token)
+}
+
+// FetchByHash implements PersonalTokensRepo.
+func (rm *repoMock) FetchByHash(tokenHash string) (*entity.PersonalToken, errs.Error) {
+	return FetchByHash(tokenHash)
+}
+
+// FetchByUser implements PersonalTokensRepo.
+func (rm *repoMock) FetchByUser(userId uint) ([]*entity.PersonalToken, errs.Error) {
+	return FetchByUser(userId)
+}
+
+// Touch implements PersonalTokensRepo.
+func (rm *repoMock) Touch(tokenHash string, usedAt time.Time) errs.Error {
+	return Touch(tokenHash, usedAt)
+}
+
+// Revoke implements PersonalTokensRepo.
+func (rm *repoMock) Revoke(userId uint, tokenId uint) errs.Error {
+	return Revoke(userId, tokenId)
+}
+
+// RevokeAll implements PersonalTokensRepo.
+func (rm *repoMock) RevokeAll(userId uint) errs.Error {
+	return RevokeAll(userId)
+}
@@ -0,0 +1,170 @@
+package personal_tokens_pg
+
+import (
+	"time"
+	"todo-app/entity"
+	"todo-app/pkg/cache"
+	"todo-app/pkg/errs"
+	"todo-app/repo/personal_tokens_repo"
+
+	"gorm.io/gorm"
+)
+
+type personalTokensPg struct {
+	db *gorm.DB
+
+	// tokens are cached by hash like sessions, every request made with a
+	// personal token looks it up
+	cache *cache.Cache[string, entity.PersonalToken]
+}
+
+func NewPersonalTokensRepo(db *gorm.DB, cacheTTL time.Duration) personal_tokens_repo.PersonalTokensRepo {
+	return &personalTokensPg{db: db, cache: cache.New[string, entity.PersonalToken](cacheTTL)}
+}
+
+// Add implements personal_tokens_repo.PersonalTokensRepo.
+func (pg *personalTokensPg) Add(token *entity.PersonalToken) errs.Error {
+
+	tx := pg.db.Begin()
+
+	if err := tx.Create(token).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
+
+// FetchByHash implements personal_tokens_repo.PersonalTokensRepo.
+func (pg *personalTokensPg) FetchByHash(tokenHash string) (*entity.PersonalToken, errs.Error) {
+
+	if token, ok := pg.cache.Get(tokenHash); ok {
+		return &token, nil
+	}
+
+	token := entity.PersonalToken{}
+
+	if err := pg.db.First(&token, "token_hash = ?", tokenHash).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errs.NewNotFoundError("personal token not found")
+		}
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	pg.cache.Set(tokenHash, token)
+
+	return &token, nil
+}
+
+// FetchByUser implements personal_tokens_repo.PersonalTokensRepo.
+func (pg *personalTokensPg) FetchByUser(userId uint) ([]*entity.PersonalToken, errs.Error) {
+
+	tokens := []*entity.PersonalToken{}
+
+	err := pg.db.
+		Order("created_at DESC").
+		Find(&tokens, "user_id = ? AND revoked_at IS NULL", userId).Error
+
+	if err != nil {
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	return tokens, nil
+}
+
+// Touch implements personal_tokens_repo.PersonalTokensRepo.
+func (pg *personalTokensPg) Touch(tokenHash string, usedAt time.Time) errs.Error {
+
+	tx := pg.db.Begin()
+
+	if err := tx.Model(&entity.PersonalToken{}).Where("token_hash = ?", tokenHash).Update("last_used_at", usedAt).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	pg.cache.Update(tokenHash, func(token entity.PersonalToken) entity.PersonalToken {
+		token.LastUsedAt = &usedAt
+		return token
+	})
+
+	return nil
+}
+
+// Revoke implements personal_tokens_repo.PersonalTokensRepo.
+func (pg *personalTokensPg) Revoke(userId uint, tokenId uint) errs.Error {
+
+	token := entity.PersonalToken{}
+
+	if err := pg.db.First(&token, "id = ? AND user_id = ? AND revoked_at IS NULL", tokenId, userId).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errs.NewNotFoundError("personal token not found")
+		}
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	tx := pg.db.Begin()
+
+	if err := tx.Model(&token).Update("revoked_at", time.Now()).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	pg.cache.Delete(token.TokenHash)
+
+	return nil
+}
+
+// RevokeAll implements personal_tokens_repo.PersonalTokensRepo.
+func (pg *personalTokensPg) RevokeAll(userId uint) errs.Error {
+
+	hashes := []string{}
+
+	err := pg.db.Model(&entity.PersonalToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userId).
+		Pluck("token_hash", &hashes).Error
+
+	if err != nil {
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	tx := pg.db.Begin()
+
+	err = tx.Model(&entity.PersonalToken{}).
+		Where("token_hash IN ? AND revoked_at IS NULL", hashes).
+		Update("revoked_at", time.Now()).Error
+
+	if err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	for _, tokenHash := range hashes {
+		pg.cache.Delete(tokenHash)
+	}
+
+	return nil
+}
@@ -0,0 +1,16 @@
+package personal_tokens_repo
+
+import (
+	"time"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+)
+
+type PersonalTokensRepo interface {
+	Add(token *entity.PersonalToken) errs.Error
+	FetchByHash(tokenHash string) (*entity.PersonalToken, errs.Error)
+	FetchByUser(userId uint) ([]*entity.PersonalToken, errs.Error)
+	Touch(tokenHash string, usedAt time.Time) errs.Error
+	Revoke(userId uint, tokenId uint) errs.Error
+	RevokeAll(userId uint) errs.Error
+}
@@ -0,0 +1,46 @@
+package projects_repo
+
+import (
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+)
+
+type repoMock struct {
+}
+
+var (
+	Add    func(project *entity.Project) errs.Error
+	Delete func(projectId uint, withTodos bool) errs.Error
+	Detail func(projectId uint) (*entity.Project, errs.Error)
+	Fetch  func(userId uint, workspaceId uint, archived bool) ([]*entity.Project, errs.Error)
+	Modify func(projectId uint, project *entity.Project) errs.Error
+)
+
+func NewRepoMock() ProjectRepo {
+	return &repoMock{}
+}
+
+// Add implements ProjectRepo.
+func (rm *repoMock) Add(project *entity.Project) errs.Error {
+	return Add(project)
+}
+
+// Delete implements ProjectRepo.
+func (rm *repoMock) Delete(projectId uint, withTodos bool) errs.Error {
+	return Delete(projectId, withTodos)
+}
+
+// Detail implements ProjectRepo.
+func (rm *repoMock) Detail(projectId uint) (*entity.Project, errs.Error) {
+	return Detail(projectId)
+}
+
+// Fetch implements ProjectRepo.
+func (rm *repoMock) Fetch(userId uint, workspaceId uint, archived bool) ([]*entity.Project, errs.Error) {
+	return Fetch(userId, workspaceId, archived)
+}
+
+// Modify implements ProjectRepo.
+func (rm *repoMock) Modify(projectId uint, project *entity.Project) errs.Error {
+	return Modify(projectId, project)
+}
@@ -0,0 +1,129 @@
+package projects_pg
+
+import (
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+	"todo-app/repo/projects_repo"
+
+	"gorm.io/gorm"
+)
+
+type projectPg struct {
+	db *gorm.DB
+}
+
+func NewProjectRepo(db *gorm.DB) projects_repo.ProjectRepo {
+	return &projectPg{db: db}
+}
+
+// Add implements projects_repo.ProjectRepo.
+func (pg *projectPg) Add(project *entity.Project) errs.Error {
+
+	tx := pg.db.Begin()
+
+	if err := tx.Create(project).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
+
+// Delete implements projects_repo.ProjectRepo.
+func (pg *projectPg) Delete(projectId uint, withTodos bool) errs.Error {
+
+	tx := pg.db.Begin()
+
+	var err error
+
+	if withTodos {
+		err = tx.Delete(&entity.Todo{}, "project_id = ?", projectId).Error
+	} else {
+		// todos without project are shown in the inbox
+		err = tx.Model(&entity.Todo{}).Where("project_id = ?", projectId).Update("project_id", nil).Error
+	}
+
+	if err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Delete(&entity.Project{}, "id = ?", projectId).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
+
+// Detail implements projects_repo.ProjectRepo.
+func (pg *projectPg) Detail(projectId uint) (*entity.Project, errs.Error) {
+
+	project := entity.Project{}
+
+	if err := pg.db.First(&project, "id = ?", projectId).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errs.NewNotFoundError("project not found")
+		}
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	return &project, nil
+}
+
+// Fetch implements projects_repo.ProjectRepo.
+func (pg *projectPg) Fetch(userId uint, workspaceId uint, archived bool) ([]*entity.Project, errs.Error) {
+
+	projects := []*entity.Project{}
+
+	query := pg.db.Where("archived = ?", archived)
+
+	if workspaceId != 0 {
+		query = query.Where("workspace_id = ?", workspaceId)
+	} else {
+		query = query.Where("user_id = ? AND workspace_id IS NULL", userId)
+	}
+
+	err := query.
+		Order("name, id").
+		Find(&projects).Error
+
+	if err != nil {
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	return projects, nil
+}
+
+// Modify implements projects_repo.ProjectRepo.
+func (pg *projectPg) Modify(projectId uint, project *entity.Project) errs.Error {
+
+	tx := pg.db.Begin()
+
+	err := tx.Model(&entity.Project{}).
+		Where("id = ?", projectId).
+		Select("name", "color", "archived").
+		Updates(project).Error
+
+	if err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
@@ -0,0 +1,14 @@
+package projects_repo
+
+import (
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+)
+
+type ProjectRepo interface {
+	Add(project *entity.Project) errs.Error
+	Fetch(userId uint, workspaceId uint, archived bool) ([]*entity.Project, errs.Error)
+	Detail(projectId uint) (*entity.Project, errs.Error)
+	Modify(projectId uint, project *entity.Project) errs.Error
+	Delete(projectId uint, withTodos bool) errs.Error
+}
@@ -0,0 +1,34 @@
+package resets_repo
+
+import (
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+)
+
+type repoMock struct {
+}
+
+var (
+	Add         func(reset *entity.PasswordReset) errs.Error
+	FetchByHash func(tokenHash string) (*entity.PasswordReset, errs.Error)
+	Consume     func(resetId uint, userId uint, password string) errs.Error
+)
+
+func NewRepoMock() ResetsRepo {
+	return &repoMock{}
+}
+
+// Add implements ResetsRepo.
+func (rm *repoMock) Add(reset *entity.PasswordReset) errs.Error {
+	return Add(reset)
+}
+
+// FetchByHash implements ResetsRepo.
+func (rm *repoMock) FetchByHash(tokenHash string) (*entity.PasswordReset, errs.Error) {
+	return FetchByHash(tokenHash)
+}
+
+// Consume implements ResetsRepo.
+func (rm *repoMock) Consume(resetId uint, userId uint, password string) errs.Error {
+	return Consume(resetId, userId, password)
+}
@@ -0,0 +1,12 @@
+package resets_repo
+
+import (
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+)
+
+type ResetsRepo interface {
+	Add(reset *entity.PasswordReset) errs.Error
+	FetchByHash(tokenHash string) (*entity.PasswordReset, errs.Error)
+	Consume(resetId uint, userId uint, password string) errs.Error
+}
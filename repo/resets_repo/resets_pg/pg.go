@@ -0,0 +1,93 @@
+package resets_pg
+
+import (
+	"time"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+	"todo-app/repo/resets_repo"
+
+	"gorm.io/gorm"
+)
+
+type resetsPg struct {
+	db *gorm.DB
+}
+
+func NewResetsRepo(db *gorm.DB) resets_repo.ResetsRepo {
+	return &resetsPg{db: db}
+}
+
+// Add implements resets_repo.ResetsRepo.
+func (pg *resetsPg) Add(reset *entity.PasswordReset) errs.Error {
+
+	tx := pg.db.Begin()
+
+	// only the latest mailed token of a user can be used
+	err := tx.Model(&entity.PasswordReset{}).
+		Where("user_id = ? AND used_at IS NULL", reset.UserID).
+		Update("used_at", time.Now()).Error
+
+	if err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Create(reset).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
+
+// FetchByHash implements resets_repo.ResetsRepo.
+func (pg *resetsPg) FetchByHash(tokenHash string) (*entity.PasswordReset, errs.Error) {
+
+	reset := entity.PasswordReset{}
+
+	if err := pg.db.First(&reset, "token_hash = ?", tokenHash).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errs.NewNotFoundError("password reset not found")
+		}
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	return &reset, nil
+}
+
+// Consume implements resets_repo.ResetsRepo.
+func (pg *resetsPg) Consume(resetId uint, userId uint, password string) errs.Error {
+
+	tx := pg.db.Begin()
+
+	result := tx.Model(&entity.PasswordReset{}).
+		Where("id = ? AND used_at IS NULL", resetId).
+		Update("used_at", time.Now())
+
+	if result.Error != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return errs.NewConflictError("password reset has been used")
+	}
+
+	if err := tx.Model(&entity.User{}).Where("id = ?", userId).Update("password", password).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
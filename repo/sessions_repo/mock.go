@@ -0,0 +1,59 @@
+package sessions_repo
+
+import (
+	"time"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+)
+
+type repoMock struct {
+}
+
+var (
+	Add         func(session *entity.Session) errs.Error
+	FetchById   func(sessionId uint) (*entity.Session, errs.Error)
+	FetchByJti  func(jti string) (*entity.Session, errs.Error)
+	FetchByUser func(userId uint) ([]*entity.Session, errs.Error)
+	Touch       func(jti string, seenAt time.Time) errs.Error
+	Revoke      func(jti string) errs.Error
+	RevokeAll   func(userId uint, exceptJti string) errs.Error
+)
+
+func NewRepoMock() SessionsRepo {
+	return &repoMock{}
+}
+
+// Add implements SessionsRepo.
+func (rm *repoMock) Add(session *entity.Session) errs.Error {
+	return Add(session)
+}
+
+// FetchById implements SessionsRepo.
+func (rm *repoMock) FetchById(sessionId uint) (*entity.Session, errs.Error) {
+	return FetchById(sessionId)
+}
+
+// FetchByJti implements SessionsRepo.
+func (rm *repoMock) FetchByJti(jti string) (*entity.Session, errs.Error) {
+	return FetchByJti(jti)
+}
+
+// FetchByUser implements SessionsRepo.
+func (rm *repoMock) FetchByUser(userId uint) ([]*entity.Session, errs.Error) {
+	return FetchByUser(userId)
+}
+
+// Touch implements SessionsRepo.
+func (rm *repoMock) Touch(jti string, seenAt time.Time) errs.Error {
+	return Touch(jti, seenAt)
+}
+
+// Revoke implements SessionsRepo.
+func (rm *repoMock) Revoke(jti string) errs.Error {
+	return Revoke(jti)
+}
+
+// RevokeAll implements SessionsRepo.
+func (rm *repoMock) RevokeAll(userId uint, exceptJti string) errs.Error {
+	return RevokeAll(userId, exceptJti)
+}
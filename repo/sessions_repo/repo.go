@@ -0,0 +1,17 @@
+package sessions_repo
+
+import (
+	"time"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+)
+
+type SessionsRepo interface {
+	Add(session *entity.Session) errs.Error
+	FetchById(sessionId uint) (*entity.Session, errs.Error)
+	FetchByJti(jti string) (*entity.Session, errs.Error)
+	FetchByUser(userId uint) ([]*entity.Session, errs.Error)
+	Touch(jti string, seenAt time.Time) errs.Error
+	Revoke(jti string) errs.Error
+	RevokeAll(userId uint, exceptJti string) errs.Error
+}
@@ -0,0 +1,179 @@
+package sessions_pg
+
+import (
+	"time"
+	"todo-app/entity"
+	"todo-app/pkg/cache"
+	"todo-app/pkg/errs"
+	"todo-app/repo/sessions_repo"
+
+	"gorm.io/gorm"
+)
+
+type sessionsPg struct {
+	db *gorm.DB
+
+	// sessions are cached by jti since every authenticated request looks
+	// its session up, revocations made through this repo evict them right
+	// away while other instances see them once the entry expires
+	cache *cache.Cache[string, entity.Session]
+}
+
+func NewSessionsRepo(db *gorm.DB, cacheTTL time.Duration) sessions_repo.SessionsRepo {
+	return &sessionsPg{db: db, cache: cache.New[string, entity.Session](cacheTTL)}
+}
+
+// Add implements sessions_repo.SessionsRepo.
+func (pg *sessionsPg) Add(session *entity.Session) errs.Error {
+
+	tx := pg.db.Begin()
+
+	if err := tx.Create(session).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
+
+// FetchById implements sessions_repo.SessionsRepo.
+func (pg *sessionsPg) FetchById(sessionId uint) (*entity.Session, errs.Error) {
+
+	session := entity.Session{}
+
+	if err := pg.db.First(&session, "id = ?", sessionId).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errs.NewNotFoundError("session not found")
+		}
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	return &session, nil
+}
+
+// FetchByJti implements sessions_repo.SessionsRepo.
+func (pg *sessionsPg) FetchByJti(jti string) (*entity.Session, errs.Error) {
+
+	if session, ok := pg.cache.Get(jti); ok {
+		return &session, nil
+	}
+
+	session := entity.Session{}
+
+	if err := pg.db.First(&session, "jti = ?", jti).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errs.NewNotFoundError("session not found")
+		}
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	pg.cache.Set(jti, session)
+
+	return &session, nil
+}
+
+// FetchByUser implements sessions_repo.SessionsRepo.
+func (pg *sessionsPg) FetchByUser(userId uint) ([]*entity.Session, errs.Error) {
+
+	sessions := []*entity.Session{}
+
+	err := pg.db.
+		Order("last_seen_at DESC").
+		Find(&sessions, "user_id = ? AND revoked_at IS NULL", userId).Error
+
+	if err != nil {
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	return sessions, nil
+}
+
+// Touch implements sessions_repo.SessionsRepo.
+func (pg *sessionsPg) Touch(jti string, seenAt time.Time) errs.Error {
+
+	tx := pg.db.Begin()
+
+	if err := tx.Model(&entity.Session{}).Where("jti = ?", jti).Update("last_seen_at", seenAt).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	pg.cache.Update(jti, func(session entity.Session) entity.Session {
+		session.LastSeenAt = seenAt
+		return session
+	})
+
+	return nil
+}
+
+// Revoke implements sessions_repo.SessionsRepo.
+func (pg *sessionsPg) Revoke(jti string) errs.Error {
+	return pg.revoke([]string{jti})
+}
+
+// RevokeAll implements sessions_repo.SessionsRepo.
+func (pg *sessionsPg) RevokeAll(userId uint, exceptJti string) errs.Error {
+
+	jtis := []string{}
+
+	err := pg.db.Model(&entity.Session{}).
+		Where("user_id = ? AND jti <> ? AND revoked_at IS NULL", userId, exceptJti).
+		Pluck("jti", &jtis).Error
+
+	if err != nil {
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if len(jtis) == 0 {
+		return nil
+	}
+
+	return pg.revoke(jtis)
+}
+
+// revoke revokes the sessions together with their refresh tokens.
+func (pg *sessionsPg) revoke(jtis []string) errs.Error {
+
+	tx := pg.db.Begin()
+
+	now := time.Now()
+
+	err := tx.Model(&entity.Session{}).
+		Where("jti IN ? AND revoked_at IS NULL", jtis).
+		Update("revoked_at", now).Error
+
+	if err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	err = tx.Model(&entity.RefreshToken{}).
+		Where("family_id IN ? AND revoked_at IS NULL", jtis).
+		Update("revoked_at", now).Error
+
+	if err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	for _, jti := range jtis {
+		pg.cache.Delete(jti)
+	}
+
+	return nil
+}
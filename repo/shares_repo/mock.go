@@ -0,0 +1,76 @@
+package shares_repo
+
+import (
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+)
+
+type repoMock struct {
+}
+
+var (
+	Add              func(share *entity.Share) errs.Error
+	Detail           func(shareId uint) (*entity.Share, errs.Error)
+	FetchByTodo      func(todoId uint) ([]*entity.Share, errs.Error)
+	FetchByProject   func(projectId uint) ([]*entity.Share, errs.Error)
+	FetchInvitations func(userId uint) ([]*entity.Share, errs.Error)
+	Role             func(userId uint, todoId uint, projectId uint) (string, errs.Error)
+	Access           func(userId uint, todo *entity.Todo) (*TodoAccess, errs.Error)
+	Accept           func(shareId uint) errs.Error
+	ModifyRole       func(shareId uint, role string) errs.Error
+	Delete           func(shareId uint) errs.Error
+)
+
+func NewRepoMock() SharesRepo {
+	return &repoMock{}
+}
+
+// Add implements SharesRepo.
+func (rm *repoMock) Add(share *entity.Share) errs.Error {
+	return Add(share)
+}
+
+// Detail implements SharesRepo.
+func (rm *repoMock) Detail(shareId uint) (*entity.Share, errs.Error) {
+	return Detail(shareId)
+}
+
+// FetchByTodo implements SharesRepo.
+func (rm *repoMock) FetchByTodo(todoId uint) ([]*entity.Share, errs.Error) {
+	return FetchByTodo(todoId)
+}
+
+// FetchByProject implements SharesRepo.
+func (rm *repoMock) FetchByProject(projectId uint) ([]*entity.Share, errs.Error) {
+	return FetchByProject(projectId)
+}
+
+// FetchInvitations implements SharesRepo.
+func (rm *repoMock) FetchInvitations(userId uint) ([]*entity.Share, errs.Error) {
+	return FetchInvitations(userId)
+}
+
+// Role implements SharesRepo.
+func (rm *repoMock) Role(userId uint, todoId uint, projectId uint) (string, errs.Error) {
+	return Role(userId, todoId, projectId)
+}
+
+// Access implements SharesRepo.
+func (rm *repoMock) Access(userId uint, todo *entity.Todo) (*TodoAccess, errs.Error) {
+	return Access(userId, todo)
+}
+
+// Accept implements SharesRepo.
+func (rm *repoMock) Accept(shareId uint) errs.Error {
+	return Accept(shareId)
+}
+
+// ModifyRole implements SharesRepo.
+func (rm *repoMock) ModifyRole(shareId uint, role string) errs.Error {
+	return ModifyRole(shareId, role)
+}
+
+// Delete implements SharesRepo.
+func (rm *repoMock) Delete(shareId uint) errs.Error {
+	return Delete(shareId)
+}
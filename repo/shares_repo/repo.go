@@ -0,0 +1,33 @@
+package shares_repo
+
+import (
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+)
+
+type SharesRepo interface {
+	Add(share *entity.Share) errs.Error
+	Detail(shareId uint) (*entity.Share, errs.Error)
+	FetchByTodo(todoId uint) ([]*entity.Share, errs.Error)
+	FetchByProject(projectId uint) ([]*entity.Share, errs.Error)
+	FetchInvitations(userId uint) ([]*entity.Share, errs.Error)
+	Role(userId uint, todoId uint, projectId uint) (string, errs.Error)
+	Access(userId uint, todo *entity.Todo) (*TodoAccess, errs.Error)
+	Accept(shareId uint) errs.Error
+	ModifyRole(shareId uint, role string) errs.Error
+	Delete(shareId uint) errs.Error
+}
+
+// TodoAccess is how a user reaches a todo. Todos of a workspace are reached
+// through an accepted membership, WorkspaceRole is its role. Other todos are
+// reached by their owner, whose ShareRole is entity.ShareOwner, or through
+// the accepted shares of the todo or its project.
+type TodoAccess struct {
+	WorkspaceRole string
+	ShareRole     string
+}
+
+// Visible reports whether the user can see the todo at all.
+func (ta *TodoAccess) Visible() bool {
+	return ta.WorkspaceRole != "" || ta.ShareRole != ""
+}
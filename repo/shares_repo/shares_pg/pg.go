@@ -0,0 +1,215 @@
+package shares_pg
+
+import (
+	"errors"
+	"time"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+	"todo-app/repo/shares_repo"
+
+	"gorm.io/gorm"
+)
+
+type sharesPg struct {
+	db *gorm.DB
+}
+
+func NewSharesRepo(db *gorm.DB) shares_repo.SharesRepo {
+	return &sharesPg{db: db}
+}
+
+// Add implements shares_repo.SharesRepo.
+func (pg *sharesPg) Add(share *entity.Share) errs.Error {
+
+	tx := pg.db.Begin()
+
+	if err := tx.Omit("User").Create(share).Error; err != nil {
+		tx.Rollback()
+
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errs.NewConflictError("already shared with this user")
+		}
+
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
+
+// Detail implements shares_repo.SharesRepo.
+func (pg *sharesPg) Detail(shareId uint) (*entity.Share, errs.Error) {
+
+	share := entity.Share{}
+
+	if err := pg.db.Preload("User").First(&share, shareId).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errs.NewNotFoundError("share not found")
+		}
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	return &share, nil
+}
+
+// FetchByTodo implements shares_repo.SharesRepo.
+func (pg *sharesPg) FetchByTodo(todoId uint) ([]*entity.Share, errs.Error) {
+	return pg.fetch("todo_id = ?", todoId)
+}
+
+// FetchByProject implements shares_repo.SharesRepo.
+func (pg *sharesPg) FetchByProject(projectId uint) ([]*entity.Share, errs.Error) {
+	return pg.fetch("project_id = ?", projectId)
+}
+
+// FetchInvitations implements shares_repo.SharesRepo.
+func (pg *sharesPg) FetchInvitations(userId uint) ([]*entity.Share, errs.Error) {
+	return pg.fetch("user_id = ? AND accepted_at IS NULL", userId)
+}
+
+func (pg *sharesPg) fetch(condition string, args ...any) ([]*entity.Share, errs.Error) {
+
+	shares := []*entity.Share{}
+
+	if err := pg.db.Preload("User").Order("created_at").Where(condition, args...).Find(&shares).Error; err != nil {
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	return shares, nil
+}
+
+// Role implements shares_repo.SharesRepo.
+func (pg *sharesPg) Role(userId uint, todoId uint, projectId uint) (string, errs.Error) {
+
+	roles := []string{}
+
+	err := pg.db.Model(&entity.Share{}).
+		Where("user_id = ? AND accepted_at IS NOT NULL", userId).
+		Where("todo_id = ? OR project_id = ?", todoId, projectId).
+		Pluck("role", &roles).Error
+
+	if err != nil {
+		return "", errs.NewInternalServerError("something went wrong")
+	}
+
+	// a todo can be shared on its own and through its project, the higher
+	// role wins
+	role := ""
+
+	for _, eachRole := range roles {
+		role = entity.HigherRole(role, eachRole)
+	}
+
+	return role, nil
+}
+
+// Access implements shares_repo.SharesRepo.
+func (pg *sharesPg) Access(userId uint, todo *entity.Todo) (*shares_repo.TodoAccess, errs.Error) {
+
+	access := &shares_repo.TodoAccess{}
+
+	if todo.WorkspaceID != nil {
+		roles := []string{}
+
+		err := pg.db.Model(&entity.WorkspaceMember{}).
+			Where("workspace_id = ? AND user_id = ? AND accepted_at IS NOT NULL", *todo.WorkspaceID, userId).
+			Pluck("role", &roles).Error
+
+		if err != nil {
+			return nil, errs.NewInternalServerError("something went wrong")
+		}
+
+		if len(roles) > 0 {
+			access.WorkspaceRole = roles[0]
+		}
+
+		return access, nil
+	}
+
+	if todo.UserID == userId {
+		access.ShareRole = entity.ShareOwner
+		return access, nil
+	}
+
+	projectId := uint(0)
+
+	if todo.ProjectID != nil {
+		projectId = *todo.ProjectID
+	}
+
+	role, err := pg.Role(userId, todo.ID, projectId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	access.ShareRole = role
+
+	return access, nil
+}
+
+// Accept implements shares_repo.SharesRepo.
+func (pg *sharesPg) Accept(shareId uint) errs.Error {
+
+	tx := pg.db.Begin()
+
+	result := tx.Model(&entity.Share{}).Where("id = ? AND accepted_at IS NULL", shareId).Update("accepted_at", time.Now())
+
+	if result.Error != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return errs.NewConflictError("invitation has been accepted")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
+
+// ModifyRole implements shares_repo.SharesRepo.
+func (pg *sharesPg) ModifyRole(shareId uint, role string) errs.Error {
+
+	tx := pg.db.Begin()
+
+	if err := tx.Model(&entity.Share{}).Where("id = ?", shareId).Update("role", role).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
+
+// Delete implements shares_repo.SharesRepo.
+func (pg *sharesPg) Delete(shareId uint) errs.Error {
+
+	tx := pg.db.Begin()
+
+	// shares are removed for good so the user can be invited again
+	if err := tx.Unscoped().Delete(&entity.Share{}, shareId).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
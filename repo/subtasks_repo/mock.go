@@ -0,0 +1,52 @@
+package subtasks_repo
+
+import (
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+)
+
+type repoMock struct {
+}
+
+var (
+	Add     func(subtask *entity.Subtask) errs.Error
+	Delete  func(subtaskId uint) errs.Error
+	Detail  func(subtaskId uint) (*entity.Subtask, errs.Error)
+	Fetch   func(todoId uint) ([]*entity.Subtask, errs.Error)
+	Modify  func(subtaskId uint, subtask *entity.Subtask) errs.Error
+	Reorder func(todoId uint, subtaskIds []uint) errs.Error
+)
+
+func NewRepoMock() SubtaskRepo {
+	return &repoMock{}
+}
+
+// Add implements SubtaskRepo.
+func (rm *repoMock) Add(subtask *entity.Subtask) errs.Error {
+	return Add(subtask)
+}
+
+// Delete implements SubtaskRepo.
+func (rm *repoMock) Delete(subtaskId uint) errs.Error {
+	return Delete(subtaskId)
+}
+
+// Detail implements SubtaskRepo.
+func (rm *repoMock) Detail(subtaskId uint) (*entity.Subtask, errs.Error) {
+	return Detail(subtaskId)
+}
+
+// Fetch implements SubtaskRepo.
+func (rm *repoMock) Fetch(todoId uint) ([]*entity.Subtask, errs.Error) {
+	return Fetch(todoId)
+}
+
+// Modify implements SubtaskRepo.
+func (rm *repoMock) Modify(subtaskId uint, subtask *entity.Subtask) errs.Error {
+	return Modify(subtaskId, subtask)
+}
+
+// Reorder implements SubtaskRepo.
+func (rm *repoMock) Reorder(todoId uint, subtaskIds []uint) errs.Error {
+	return Reorder(todoId, subtaskIds)
+}
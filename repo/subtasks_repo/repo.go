@@ -0,0 +1,15 @@
+package subtasks_repo
+
+import (
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+)
+
+type SubtaskRepo interface {
+	Add(subtask *entity.Subtask) errs.Error
+	Fetch(todoId uint) ([]*entity.Subtask, errs.Error)
+	Detail(subtaskId uint) (*entity.Subtask, errs.Error)
+	Modify(subtaskId uint, subtask *entity.Subtask) errs.Error
+	Delete(subtaskId uint) errs.Error
+	Reorder(todoId uint, subtaskIds []uint) errs.Error
+}
@@ -0,0 +1,151 @@
+package subtasks_pg
+
+import (
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+	"todo-app/repo/subtasks_repo"
+
+	"gorm.io/gorm"
+)
+
+type subtaskPg struct {
+	db *gorm.DB
+}
+
+func NewSubtaskRepo(db *gorm.DB) subtasks_repo.SubtaskRepo {
+	return &subtaskPg{db: db}
+}
+
+// Add implements subtasks_repo.SubtaskRepo.
+func (pg *subtaskPg) Add(subtask *entity.Subtask) errs.Error {
+
+	tx := pg.db.Begin()
+
+	err := tx.Model(&entity.Subtask{}).
+		Select("COALESCE(MAX(position), 0) + 1").
+		Where("todo_id = ?", subtask.TodoID).
+		Scan(&subtask.Position).Error
+
+	if err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Create(subtask).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
+
+// Delete implements subtasks_repo.SubtaskRepo.
+func (pg *subtaskPg) Delete(subtaskId uint) errs.Error {
+
+	tx := pg.db.Begin()
+
+	if err := tx.Delete(&entity.Subtask{}, "id = ?", subtaskId).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
+
+// Detail implements subtasks_repo.SubtaskRepo.
+func (pg *subtaskPg) Detail(subtaskId uint) (*entity.Subtask, errs.Error) {
+
+	subtask := entity.Subtask{}
+
+	if err := pg.db.First(&subtask, "id = ?", subtaskId).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errs.NewNotFoundError("subtask not found")
+		}
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	return &subtask, nil
+}
+
+// Fetch implements subtasks_repo.SubtaskRepo.
+func (pg *subtaskPg) Fetch(todoId uint) ([]*entity.Subtask, errs.Error) {
+
+	subtasks := []*entity.Subtask{}
+
+	if err := pg.db.Order("position, id").Find(&subtasks, "todo_id = ?", todoId).Error; err != nil {
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	return subtasks, nil
+}
+
+// Modify implements subtasks_repo.SubtaskRepo.
+func (pg *subtaskPg) Modify(subtaskId uint, subtask *entity.Subtask) errs.Error {
+
+	tx := pg.db.Begin()
+
+	err := tx.Model(&entity.Subtask{}).
+		Where("id = ?", subtaskId).
+		Select("title", "done").
+		Updates(subtask).Error
+
+	if err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
+
+// Reorder implements subtasks_repo.SubtaskRepo.
+func (pg *subtaskPg) Reorder(todoId uint, subtaskIds []uint) errs.Error {
+
+	tx := pg.db.Begin()
+
+	for i, subtaskId := range subtaskIds {
+		result := tx.Model(&entity.Subtask{}).
+			Where("id = ? AND todo_id = ?", subtaskId, todoId).
+			Update("position", i+1)
+
+		if result.Error != nil {
+			tx.Rollback()
+			return errs.NewInternalServerError("something went wrong")
+		}
+
+		if result.RowsAffected == 0 {
+			tx.Rollback()
+			return errs.NewNotFoundError("subtask not found")
+		}
+	}
+
+	err := tx.Model(&entity.Subtask{}).
+		Where("todo_id = ? AND id NOT IN ?", todoId, subtaskIds).
+		Update("position", gorm.Expr("position + ?", len(subtaskIds))).Error
+
+	if err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package tags_repo
+
+import (
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+)
+
+type repoMock struct {
+}
+
+var (
+	Add        func(tag *entity.Tag) errs.Error
+	Delete     func(tagId uint) errs.Error
+	Detail     func(tagId uint) (*entity.Tag, errs.Error)
+	Fetch      func(userId uint) ([]*entity.Tag, errs.Error)
+	FetchByIds func(userId uint, tagIds []uint) ([]*entity.Tag, errs.Error)
+	Modify     func(tagId uint, tag *entity.Tag) errs.Error
+)
+
+func NewRepoMock() TagRepo {
+	return &repoMock{}
+}
+
+// Add implements TagRepo.
+func (rm *repoMock) Add(tag *entity.Tag) errs.Error {
+	return Add(tag)
+}
+
+// Delete implements TagRepo.
+func (rm *repoMock) Delete(tagId uint) errs.Error {
+	return Delete(tagId)
+}
+
+// Detail implements TagRepo.
+func (rm *repoMock) Detail(tagId uint) (*entity.Tag, errs.Error) {
+	return Detail(tagId)
+}
+
+// Fetch implements TagRepo.
+func (rm *repoMock) Fetch(userId uint) ([]*entity.Tag, errs.Error) {
+	return Fetch(userId)
+}
+
+// FetchByIds implements TagRepo.
+func (rm *repoMock) FetchByIds(userId uint, tagIds []uint) ([]*entity.Tag, errs.Error) {
+	return FetchByIds(userId, tagIds)
+}
+
+// Modify implements TagRepo.
+func (rm *repoMock) Modify(tagId uint, tag *entity.Tag) errs.Error {
+	return Modify(tagId, tag)
+}
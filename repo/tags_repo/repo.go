@@ -0,0 +1,15 @@
+package tags_repo
+
+import (
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+)
+
+type TagRepo interface {
+	Add(tag *entity.Tag) errs.Error
+	Fetch(userId uint) ([]*entity.Tag, errs.Error)
+	FetchByIds(userId uint, tagIds []uint) ([]*entity.Tag, errs.Error)
+	Detail(tagId uint) (*entity.Tag, errs.Error)
+	Modify(tagId uint, tag *entity.Tag) errs.Error
+	Delete(tagId uint) errs.Error
+}
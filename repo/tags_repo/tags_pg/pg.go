@@ -0,0 +1,131 @@
+package tags_pg
+
+import (
+	"errors"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+	"todo-app/repo/tags_repo"
+
+	"gorm.io/gorm"
+)
+
+type tagPg struct {
+	db *gorm.DB
+}
+
+func NewTagRepo(db *gorm.DB) tags_repo.TagRepo {
+	return &tagPg{db: db}
+}
+
+// Add implements tags_repo.TagRepo.
+func (pg *tagPg) Add(tag *entity.Tag) errs.Error {
+
+	tx := pg.db.Begin()
+
+	if err := tx.Create(tag).Error; err != nil {
+		tx.Rollback()
+
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errs.NewConflictError("tag name has been used")
+		}
+
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
+
+// Delete implements tags_repo.TagRepo.
+func (pg *tagPg) Delete(tagId uint) errs.Error {
+
+	tx := pg.db.Begin()
+
+	if err := tx.Exec("DELETE FROM todo_tags WHERE tag_id = ?", tagId).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Delete(&entity.Tag{}, "id = ?", tagId).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
+
+// Detail implements tags_repo.TagRepo.
+func (pg *tagPg) Detail(tagId uint) (*entity.Tag, errs.Error) {
+
+	tag := entity.Tag{}
+
+	if err := pg.db.First(&tag, "id = ?", tagId).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errs.NewNotFoundError("tag not found")
+		}
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	return &tag, nil
+}
+
+// Fetch implements tags_repo.TagRepo.
+func (pg *tagPg) Fetch(userId uint) ([]*entity.Tag, errs.Error) {
+
+	tags := []*entity.Tag{}
+
+	if err := pg.db.Order("name").Find(&tags, "user_id = ?", userId).Error; err != nil {
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	return tags, nil
+}
+
+// FetchByIds implements tags_repo.TagRepo.
+func (pg *tagPg) FetchByIds(userId uint, tagIds []uint) ([]*entity.Tag, errs.Error) {
+
+	tags := []*entity.Tag{}
+
+	if err := pg.db.Find(&tags, "user_id = ? AND id IN ?", userId, tagIds).Error; err != nil {
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	return tags, nil
+}
+
+// Modify implements tags_repo.TagRepo.
+func (pg *tagPg) Modify(tagId uint, tag *entity.Tag) errs.Error {
+
+	tx := pg.db.Begin()
+
+	err := tx.Model(&entity.Tag{}).
+		Where("id = ?", tagId).
+		Select("name", "color").
+		Updates(tag).Error
+
+	if err != nil {
+		tx.Rollback()
+
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errs.NewConflictError("tag name has been used")
+		}
+
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package todos_repo
 
 import (
+	"time"
 	"todo-app/entity"
 	"todo-app/pkg/errs"
 )
@@ -9,11 +10,21 @@ type repoMock struct {
 }
 
 var (
-	Add    func(todo *entity.Todo) errs.Error
-	Delete func(todoId uint) errs.Error
-	Detail func(todoId uint) (*entity.Todo, errs.Error)
-	Fetch  func(userId uint) ([]*entity.Todo, errs.Error)
-	Modify func(todoId uint, todo *entity.Todo) errs.Error
+	Add            func(todo *entity.Todo) errs.Error
+	Delete         func(todoId uint) errs.Error
+	Detail         func(todoId uint) (*entity.Todo, errs.Error)
+	Fetch          func(filter *TodoFilter) ([]*entity.Todo, errs.Error)
+	Search         func(userId uint, workspaceId uint, tsQuery string, limit int) ([]*TodoMatch, errs.Error)
+	FetchByProject func(projectId uint) ([]*entity.Todo, errs.Error)
+	Modify         func(todoId uint, todo *entity.Todo, changes *TodoChanges) errs.Error
+	Assign         func(todoId uint, assigneeId *uint) errs.Error
+	Reorder        func(userId uint, todoIds []uint) errs.Error
+	FetchTrash     func(userId uint, workspaceId uint) ([]*entity.Todo, errs.Error)
+	DetailTrash    func(todoId uint) (*entity.Todo, errs.Error)
+	Restore        func(todoId uint) errs.Error
+	Purge          func(todoId uint) ([]string, errs.Error)
+	PurgeTrash     func(deletedBefore time.Time) (int64, []string, errs.Error)
+	Bulk           func(userId uint, todoIds []uint, action *BulkAction, allOrNothing bool) ([]*BulkResult, errs.Error)
 )
 
 func NewRepoMock() TodoRepo {
@@ -36,11 +47,61 @@ func (rm *repoMock) Detail(todoId uint) (*entity.Todo, errs.Error) {
 }
 
 // Fetch implements TodoRepo.
-func (rm *repoMock) Fetch(userId uint) ([]*entity.Todo, errs.Error) {
-	return Fetch(userId)
+func (rm *repoMock) Fetch(filter *TodoFilter) ([]*entity.Todo, errs.Error) {
+	return Fetch(filter)
+}
+
+// Search implements TodoRepo.
+func (rm *repoMock) Search(userId uint, workspaceId uint, tsQuery string, limit int) ([]*TodoMatch, errs.Error) {
+	return Search(userId, workspaceId, tsQuery, limit)
+}
+
+// FetchByProject implements TodoRepo.
+func (rm *repoMock) FetchByProject(projectId uint) ([]*entity.Todo, errs.Error) {
+	return FetchByProject(projectId)
 }
 
 // Modify implements TodoRepo.
-func (rm *repoMock) Modify(todoId uint, todo *entity.Todo) errs.Error {
-	return Modify(todoId, todo)
+func (rm *repoMock) Modify(todoId uint, todo *entity.Todo, changes *TodoChanges) errs.Error {
+	return Modify(todoId, todo, changes)
+}
+
+// Assign implements TodoRepo.
+func (rm *repoMock) Assign(todoId uint, assigneeId *uint) errs.Error {
+	return Assign(todoId, assigneeId)
+}
+
+// Reorder implements TodoRepo.
+func (rm *repoMock) Reorder(userId uint, todoIds []uint) errs.Error {
+	return Reorder(userId, todoIds)
+}
+
+// FetchTrash implements TodoRepo.
+func (rm *repoMock) FetchTrash(userId uint, workspaceId uint) ([]*entity.Todo, errs.Error) {
+	return FetchTrash(userId, workspaceId)
+}
+
+// DetailTrash implements TodoRepo.
+func (rm *repoMock) DetailTrash(todoId uint) (*entity.Todo, errs.Error) {
+	return DetailTrash(todoId)
+}
+
+// Restore implements TodoRepo.
+func (rm *repoMock) Restore(todoId uint) errs.Error {
+	return Restore(todoId)
+}
+
+// Purge implements TodoRepo.
+func (rm *repoMock) Purge(todoId uint) ([]string, errs.Error) {
+	return Purge(todoId)
+}
+
+// PurgeTrash implements TodoRepo.
+func (rm *repoMock) PurgeTrash(deletedBefore time.Time) (int64, []string, errs.Error) {
+	return PurgeTrash(deletedBefore)
+}
+
+// Bulk implements TodoRepo.
+func (rm *repoMock) Bulk(userId uint, todoIds []uint, action *BulkAction, allOrNothing bool) ([]*BulkResult, errs.Error) {
+	return Bulk(userId, todoIds, action, allOrNothing)
 }
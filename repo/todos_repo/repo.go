@@ -1,14 +1,125 @@
 package todos_repo
 
 import (
+	"time"
 	"todo-app/entity"
 	"todo-app/pkg/errs"
 )
 
+// TodoFilter narrows the todos returned by Fetch, zero values are ignored.
+type TodoFilter struct {
+	UserID       uint
+	WorkspaceID  uint
+	Search       string
+	Status       *bool
+	AssigneeID   uint
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time
+	UpdatedFrom  *time.Time
+	UpdatedTo    *time.Time
+	DueFrom      *time.Time
+	DueTo        *time.Time
+	OverdueAt    *time.Time
+	TagIds       []uint
+	MatchAllTags bool
+	Sort         string
+	Descending   bool
+	After        *TodoCursor
+	Limit        int
+}
+
+// TodoCursor holds the sort values of the last todo of a page, the next page
+// starts right after it.
+type TodoCursor struct {
+	Sort       string    `json:"sort"`
+	Descending bool      `json:"desc"`
+	Position   int       `json:"position"`
+	Priority   int       `json:"priority"`
+	DueAt      time.Time `json:"due_at"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	ID         uint      `json:"id"`
+}
+
+// NoDueAt is used in place of a missing due date when sorting by due date,
+// so todos without one are placed after every dated todo.
+var NoDueAt = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+func NewTodoCursor(sort string, descending bool, todo *entity.Todo) *TodoCursor {
+
+	cursor := &TodoCursor{
+		Sort:       sort,
+		Descending: descending,
+		Position:   todo.Position,
+		Priority:   int(todo.Priority),
+		DueAt:      NoDueAt,
+		CreatedAt:  todo.CreatedAt,
+		UpdatedAt:  todo.UpdatedAt,
+		ID:         todo.ID,
+	}
+
+	if todo.DueAt != nil {
+		cursor.DueAt = *todo.DueAt
+	}
+
+	return cursor
+}
+
+// TodoMatch is a full text search result, Snippet highlights the matching
+// words with <mark> tags.
+type TodoMatch struct {
+	Todo    *entity.Todo
+	Rank    float64
+	Snippet string
+}
+
+const (
+	BulkComplete = "complete"
+	BulkReopen   = "reopen"
+	BulkDelete   = "delete"
+	BulkMove     = "move"
+	BulkTag      = "tag"
+)
+
+// BulkAction is applied to every todo of a bulk request, ProjectID is used
+// by move (nil moves to the inbox) and TagID by tag.
+type BulkAction struct {
+	Name      string
+	ProjectID *uint
+	TagID     uint
+}
+
+// BulkResult reports the outcome for one todo, Err is nil on success.
+type BulkResult struct {
+	TodoID uint
+	Err    errs.Error
+}
+
+// TodoChanges are applied by Modify in the same transaction as the todo, so
+// completing a recurring todo never loses its schedule halfway. Tags replace
+// the tags of the todo when ReplaceTags is set, and Next is the occurrence
+// to add when a recurring todo is completed.
+type TodoChanges struct {
+	ReplaceTags      bool
+	Tags             []entity.Tag
+	CompleteSubtasks bool
+	Next             *entity.Todo
+}
+
 type TodoRepo interface {
 	Add(todo *entity.Todo) errs.Error
-	Fetch(userId uint) ([]*entity.Todo, errs.Error)
+	Fetch(filter *TodoFilter) ([]*entity.Todo, errs.Error)
+	Search(userId uint, workspaceId uint, tsQuery string, limit int) ([]*TodoMatch, errs.Error)
+	FetchByProject(projectId uint) ([]*entity.Todo, errs.Error)
 	Detail(todoId uint) (*entity.Todo, errs.Error)
-	Modify(todoId uint, todo *entity.Todo) errs.Error
+	Modify(todoId uint, todo *entity.Todo, changes *TodoChanges) errs.Error
+	Assign(todoId uint, assigneeId *uint) errs.Error
 	Delete(todoId uint) errs.Error
+	Reorder(userId uint, todoIds []uint) errs.Error
+	FetchTrash(userId uint, workspaceId uint) ([]*entity.Todo, errs.Error)
+	DetailTrash(todoId uint) (*entity.Todo, errs.Error)
+	Restore(todoId uint) errs.Error
+	Purge(todoId uint) ([]string, errs.Error)
+	PurgeTrash(deletedBefore time.Time) (int64, []string, errs.Error)
+	Bulk(userId uint, todoIds []uint, action *BulkAction, allOrNothing bool) ([]*BulkResult, errs.Error)
 }
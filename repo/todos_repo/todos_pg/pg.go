@@ -1,6 +1,8 @@
 package todos_pg
 
 import (
+	"fmt"
+	"time"
 	"todo-app/entity"
 	"todo-app/pkg/errs"
 	"todo-app/repo/todos_repo"
@@ -12,6 +14,15 @@ type todoPg struct {
 	db *gorm.DB
 }
 
+// withCommentCount selects the todo columns along with the number of
+// comments of each todo
+const withCommentCount = "todos.*, (SELECT COUNT(*) FROM comments WHERE comments.todo_id = todos.id AND comments.deleted_at IS NULL) AS comment_count"
+
+// allDayEnd is the end of the due day of all day todos, the day is added in
+// the todo timezone so it lasts 23 or 25 hours across DST changes just like
+// entity.Todo.DueEnd.
+const allDayEnd = "((due_at AT TIME ZONE COALESCE(NULLIF(time_zone, ''), 'UTC')) + interval '1 day') AT TIME ZONE COALESCE(NULLIF(time_zone, ''), 'UTC')"
+
 func NewTodoRepo(db *gorm.DB) todos_repo.TodoRepo {
 	return &todoPg{db: db}
 }
@@ -20,7 +31,7 @@ func NewTodoRepo(db *gorm.DB) todos_repo.TodoRepo {
 func (pg *todoPg) Add(todo *entity.Todo) errs.Error {
 	tx := pg.db.Begin()
 
-	if err := tx.Create(todo).Error; err != nil {
+	if err := add(tx, todo); err != nil {
 		tx.Rollback()
 		return errs.NewInternalServerError("something went wrong")
 	}
@@ -33,6 +44,35 @@ func (pg *todoPg) Add(todo *entity.Todo) errs.Error {
 	return nil
 }
 
+func add(tx *gorm.DB, todo *entity.Todo) error {
+
+	// new todos are placed at the bottom of the list
+	if todo.Position == 0 {
+		err := tx.Model(&entity.Todo{}).
+			Select("COALESCE(MAX(position), 0) + 1").
+			Where("user_id = ?", todo.UserID).
+			Scan(&todo.Position).Error
+
+		if err != nil {
+			return err
+		}
+	}
+
+	// todos are indexed with the language of their owner
+	if todo.SearchLanguage == "" {
+		err := tx.Model(&entity.User{}).
+			Select("search_language").
+			Where("id = ?", todo.UserID).
+			Scan(&todo.SearchLanguage).Error
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Omit("Tags.*").Create(todo).Error
+}
+
 // Delete implements todos_repo.TodoRepo.
 func (pg *todoPg) Delete(todoId uint) errs.Error {
 
@@ -56,7 +96,7 @@ func (pg *todoPg) Detail(todoId uint) (*entity.Todo, errs.Error) {
 
 	todo := entity.Todo{}
 
-	if err := pg.db.First(&todo, "id = ?", todoId).Error; err != nil {
+	if err := pg.db.Select(withCommentCount).Preload("Tags").Preload("Subtasks").First(&todo, "id = ?", todoId).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, errs.NewNotFoundError("todo not found")
 		}
@@ -67,31 +107,588 @@ func (pg *todoPg) Detail(todoId uint) (*entity.Todo, errs.Error) {
 }
 
 // Fetch implements todos_repo.TodoRepo.
-func (pg *todoPg) Fetch(userId uint) ([]*entity.Todo, errs.Error) {
+func (pg *todoPg) Fetch(filter *todos_repo.TodoFilter) ([]*entity.Todo, errs.Error) {
+
+	todos := []*entity.Todo{}
+
+	query := pg.db.Select(withCommentCount).Preload("Tags").Preload("Subtasks")
+
+	if filter.WorkspaceID != 0 {
+		query = query.Where("workspace_id = ?", filter.WorkspaceID)
+	} else {
+		spaces := pg.personalTodos(filter.UserID)
+
+		// todos assigned to the user are listed from all their workspaces
+		if filter.AssigneeID != 0 {
+			workspaces := pg.db.Model(&entity.WorkspaceMember{}).Select("workspace_id").Where("user_id = ? AND accepted_at IS NOT NULL", filter.UserID)
+			spaces = spaces.Or("workspace_id IN (?)", workspaces)
+		}
+
+		query = query.Where(spaces)
+	}
+
+	if filter.AssigneeID != 0 {
+		query = query.Where("assignee_id = ?", filter.AssigneeID)
+	}
+
+	if filter.Search != "" {
+		query = query.Where("todos ILIKE ?", "%"+likeEscaper.Replace(filter.Search)+"%")
+	}
+
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at < ?", *filter.CreatedTo)
+	}
+
+	if filter.UpdatedFrom != nil {
+		query = query.Where("updated_at >= ?", *filter.UpdatedFrom)
+	}
+
+	if filter.UpdatedTo != nil {
+		query = query.Where("updated_at < ?", *filter.UpdatedTo)
+	}
+
+	if filter.DueFrom != nil {
+		query = query.Where("due_at >= ?", *filter.DueFrom)
+	}
+
+	if filter.DueTo != nil {
+		query = query.Where("due_at < ?", *filter.DueTo)
+	}
+
+	if filter.OverdueAt != nil {
+		query = query.
+			Where("status = ? AND due_at IS NOT NULL", false).
+			Where("(due_all_day = ? AND due_at <= ?) OR (due_all_day = ? AND "+allDayEnd+" <= ?)", false, *filter.OverdueAt, true, *filter.OverdueAt)
+	}
+
+	if len(filter.TagIds) > 0 {
+		taggedTodos := pg.db.Table("todo_tags").Select("todo_id").Where("tag_id IN ?", filter.TagIds)
+
+		if filter.MatchAllTags {
+			taggedTodos = taggedTodos.Group("todo_id").Having("COUNT(DISTINCT tag_id) = ?", len(filter.TagIds))
+		}
+
+		query = query.Where("id IN (?)", taggedTodos)
+	}
+
+	keys := sortKeys(filter.Sort, filter.Descending)
+
+	if filter.After != nil {
+		condition, args := afterCursor(keys, filter.After)
+		query = query.Where(condition, args...)
+	}
+
+	for _, key := range keys {
+		query = query.Order(key.order())
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	if err := query.Find(&todos).Error; err != nil {
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	return todos, nil
+}
+
+// personalTodos scopes todos outside of workspaces to what the user sees,
+// besides their own todos the todos shared with them, on their own or
+// through a project, once they accepted the invitation.
+func (pg *todoPg) personalTodos(userId uint) *gorm.DB {
+
+	sharedTodos := pg.db.Model(&entity.Share{}).Select("todo_id").Where("user_id = ? AND todo_id IS NOT NULL AND accepted_at IS NOT NULL", userId)
+	sharedProjects := pg.db.Model(&entity.Share{}).Select("project_id").Where("user_id = ? AND project_id IS NOT NULL AND accepted_at IS NOT NULL", userId)
+
+	return pg.db.
+		Where("todos.workspace_id IS NULL").
+		Where("todos.user_id = ? OR todos.id IN (?) OR todos.project_id IN (?)", userId, sharedTodos, sharedProjects)
+}
+
+// Search implements todos_repo.TodoRepo.
+func (pg *todoPg) Search(userId uint, workspaceId uint, tsQuery string, limit int) ([]*todos_repo.TodoMatch, errs.Error) {
+
+	ranked := []struct {
+		ID      uint
+		Rank    float64
+		Snippet string
+	}{}
+
+	// the query is parsed with the language each todo was indexed with
+	search := pg.db.
+		Table("todos, to_tsquery(todos.search_language, ?) AS query", tsQuery).
+		Select("todos.id, ts_rank(todos.search_vector, query) AS rank, ts_headline(todos.search_language, todos.todos, query, ?) AS snippet", headlineOptions).
+		Where("todos.search_vector @@ query AND todos.deleted_at IS NULL")
+
+	if workspaceId != 0 {
+		search = search.Where("todos.workspace_id = ?", workspaceId)
+	} else {
+		search = search.Where(pg.personalTodos(userId))
+	}
+
+	err := search.
+		Order("rank DESC, todos.id").
+		Limit(limit).
+		Scan(&ranked).Error
+
+	if err != nil {
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	matches := []*todos_repo.TodoMatch{}
+
+	if len(ranked) == 0 {
+		return matches, nil
+	}
+
+	ids := []uint{}
+
+	for _, eachRanked := range ranked {
+		ids = append(ids, eachRanked.ID)
+	}
+
+	todos := []*entity.Todo{}
+
+	if err := pg.db.Select(withCommentCount).Preload("Tags").Preload("Subtasks").Find(&todos, ids).Error; err != nil {
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	todosById := map[uint]*entity.Todo{}
+
+	for _, eachTodo := range todos {
+		todosById[eachTodo.ID] = eachTodo
+	}
+
+	for _, eachRanked := range ranked {
+		if todo, ok := todosById[eachRanked.ID]; ok {
+			matches = append(matches, &todos_repo.TodoMatch{
+				Todo:    todo,
+				Rank:    eachRanked.Rank,
+				Snippet: eachRanked.Snippet,
+			})
+		}
+	}
+
+	return matches, nil
+}
+
+// FetchByProject implements todos_repo.TodoRepo.
+func (pg *todoPg) FetchByProject(projectId uint) ([]*entity.Todo, errs.Error) {
+
+	todos := []*entity.Todo{}
+
+	err := pg.db.
+		Select(withCommentCount).
+		Preload("Tags").
+		Preload("Subtasks").
+		Where("project_id = ?", projectId).
+		Order("position, priority DESC, id").
+		Find(&todos).Error
+
+	if err != nil {
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	return todos, nil
+}
+
+// Modify implements todos_repo.TodoRepo. changes may be nil.
+func (pg *todoPg) Modify(todoId uint, todo *entity.Todo, changes *todos_repo.TodoChanges) errs.Error {
+
+	tx := pg.db.Begin()
+
+	err := tx.Model(&entity.Todo{}).
+		Where("id = ?", todoId).
+		Select("todos", "status", "due_at", "due_all_day", "time_zone", "priority", "project_id",
+			"recurrence", "repeat_from_completion", "occurrence").
+		Updates(todo).Error
+
+	if err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if changes != nil {
+		if err := applyChanges(tx, todoId, changes); err != nil {
+			tx.Rollback()
+			return errs.NewInternalServerError("something went wrong")
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
+
+func applyChanges(tx *gorm.DB, todoId uint, changes *todos_repo.TodoChanges) error {
+
+	if changes.ReplaceTags {
+		todo := &entity.Todo{}
+		todo.ID = todoId
+
+		if err := tx.Model(todo).Omit("Tags.*").Association("Tags").Replace(changes.Tags); err != nil {
+			return err
+		}
+	}
+
+	if changes.CompleteSubtasks {
+		if err := tx.Model(&entity.Subtask{}).Where("todo_id = ?", todoId).Update("done", true).Error; err != nil {
+			return err
+		}
+	}
+
+	if changes.Next != nil {
+		return add(tx, changes.Next)
+	}
+
+	return nil
+}
+
+// Assign implements todos_repo.TodoRepo. A nil assigneeId unassigns the todo.
+func (pg *todoPg) Assign(todoId uint, assigneeId *uint) errs.Error {
+
+	tx := pg.db.Begin()
+
+	if err := tx.Model(&entity.Todo{}).Where("id = ?", todoId).Update("assignee_id", assigneeId).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
+
+// Reorder implements todos_repo.TodoRepo.
+func (pg *todoPg) Reorder(userId uint, todoIds []uint) errs.Error {
+
+	tx := pg.db.Begin()
+
+	for i, todoId := range todoIds {
+		result := tx.Model(&entity.Todo{}).
+			Where("id = ? AND user_id = ? AND workspace_id IS NULL", todoId, userId).
+			Update("position", i+1)
+
+		if result.Error != nil {
+			tx.Rollback()
+			return errs.NewInternalServerError("something went wrong")
+		}
+
+		if result.RowsAffected == 0 {
+			tx.Rollback()
+			return errs.NewNotFoundError("todo not found")
+		}
+	}
+
+	// todos missing from the request keep their relative order after the reordered ones
+	err := tx.Model(&entity.Todo{}).
+		Where("user_id = ? AND workspace_id IS NULL AND id NOT IN ?", userId, todoIds).
+		Update("position", gorm.Expr("position + ?", len(todoIds))).Error
+
+	if err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
+
+// FetchTrash implements todos_repo.TodoRepo.
+func (pg *todoPg) FetchTrash(userId uint, workspaceId uint) ([]*entity.Todo, errs.Error) {
 
 	todos := []*entity.Todo{}
 
-	if err := pg.db.Find(&todos, "user_id = ?", userId).Error; err != nil {
+	query := pg.db.
+		Unscoped().
+		Select(withCommentCount).
+		Preload("Tags").
+		Preload("Subtasks").
+		Where("user_id = ? AND deleted_at IS NOT NULL", userId)
+
+	if workspaceId != 0 {
+		query = query.Where("workspace_id = ?", workspaceId)
+	} else {
+		query = query.Where("workspace_id IS NULL")
+	}
+
+	err := query.
+		Order("deleted_at DESC, id").
+		Find(&todos).Error
+
+	if err != nil {
 		return nil, errs.NewInternalServerError("something went wrong")
 	}
 
 	return todos, nil
 }
 
-// Modify implements todos_repo.TodoRepo.
-func (pg *todoPg) Modify(todoId uint, todo *entity.Todo) errs.Error {
+// DetailTrash implements todos_repo.TodoRepo.
+func (pg *todoPg) DetailTrash(todoId uint) (*entity.Todo, errs.Error) {
+
+	todo := entity.Todo{}
+
+	if err := pg.db.Unscoped().First(&todo, "id = ? AND deleted_at IS NOT NULL", todoId).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errs.NewNotFoundError("todo not found in trash")
+		}
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	return &todo, nil
+}
+
+// Restore implements todos_repo.TodoRepo.
+func (pg *todoPg) Restore(todoId uint) errs.Error {
 
 	tx := pg.db.Begin()
 
-	if err := tx.Model(&entity.Todo{}).Where("id = ?", todoId).Updates(todo).Error; err != nil {
+	// todos of a deleted project are restored to the inbox
+	err := tx.Unscoped().
+		Model(&entity.Todo{}).
+		Where("id = ?", todoId).
+		Updates(map[string]any{
+			"deleted_at": nil,
+			"project_id": gorm.Expr("(SELECT id FROM projects WHERE id = todos.project_id AND deleted_at IS NULL)"),
+		}).Error
+
+	if err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
 		tx.Rollback()
 		return errs.NewInternalServerError("something went wrong")
 	}
 
+	return nil
+}
+
+// Purge implements todos_repo.TodoRepo.
+func (pg *todoPg) Purge(todoId uint) ([]string, errs.Error) {
+
+	tx := pg.db.Begin()
+
+	keys, err := purge(tx, []uint{todoId})
+
+	if err != nil {
+		tx.Rollback()
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	return keys, nil
+}
+
+// PurgeTrash implements todos_repo.TodoRepo.
+func (pg *todoPg) PurgeTrash(deletedBefore time.Time) (int64, []string, errs.Error) {
+
+	tx := pg.db.Begin()
+
+	expired := []uint{}
+
+	err := tx.Unscoped().
+		Model(&entity.Todo{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore).
+		Pluck("id", &expired).Error
+
+	if err != nil {
+		tx.Rollback()
+		return 0, nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	keys := []string{}
+
+	if len(expired) > 0 {
+		keys, err = purge(tx, expired)
+
+		if err != nil {
+			tx.Rollback()
+			return 0, nil, errs.NewInternalServerError("something went wrong")
+		}
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		tx.Rollback()
+		return 0, nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	return int64(len(expired)), keys, nil
+}
+
+// purge hard deletes todos together with their subtasks, tag links, shares,
+// comments and attachments. The storage keys of the attachments are returned
+// since their blobs aren't part of the transaction.
+func purge(tx *gorm.DB, todoIds []uint) ([]string, error) {
+
+	if err := tx.Exec("DELETE FROM todo_tags WHERE todo_id IN ?", todoIds).Error; err != nil {
+		return nil, err
+	}
+
+	if err := tx.Unscoped().Where("todo_id IN ?", todoIds).Delete(&entity.Subtask{}).Error; err != nil {
+		return nil, err
+	}
+
+	if err := tx.Unscoped().Where("todo_id IN ?", todoIds).Delete(&entity.Share{}).Error; err != nil {
+		return nil, err
+	}
+
+	comments := tx.Unscoped().Model(&entity.Comment{}).Select("id").Where("todo_id IN ?", todoIds)
+
+	if err := tx.Where("comment_id IN (?)", comments).Delete(&entity.CommentMention{}).Error; err != nil {
+		return nil, err
+	}
+
+	if err := tx.Unscoped().Where("todo_id IN ?", todoIds).Delete(&entity.Comment{}).Error; err != nil {
+		return nil, err
+	}
+
+	keys := []string{}
+
+	if err := tx.Unscoped().Model(&entity.Attachment{}).Where("todo_id IN ?", todoIds).Pluck("storage_key", &keys).Error; err != nil {
+		return nil, err
+	}
+
+	if err := tx.Unscoped().Where("todo_id IN ?", todoIds).Delete(&entity.Attachment{}).Error; err != nil {
+		return nil, err
+	}
+
+	return keys, tx.Unscoped().Where("id IN ?", todoIds).Delete(&entity.Todo{}).Error
+}
+
+// Bulk implements todos_repo.TodoRepo.
+func (pg *todoPg) Bulk(userId uint, todoIds []uint, action *todos_repo.BulkAction, allOrNothing bool) ([]*todos_repo.BulkResult, errs.Error) {
+
+	tx := pg.db.Begin()
+
+	results := []*todos_repo.BulkResult{}
+	failed := false
+
+	for i, todoId := range todoIds {
+		// every todo runs in its own savepoint so a failing one doesn't
+		// abort the transaction for the others
+		savepoint := fmt.Sprintf("bulk_%d", i)
+
+		if err := tx.SavePoint(savepoint).Error; err != nil {
+			tx.Rollback()
+			return nil, errs.NewInternalServerError("something went wrong")
+		}
+
+		err := bulkApply(tx, userId, todoId, action)
+
+		if err != nil {
+			failed = true
+
+			if err := tx.RollbackTo(savepoint).Error; err != nil {
+				tx.Rollback()
+				return nil, errs.NewInternalServerError("something went wrong")
+			}
+		}
+
+		results = append(results, &todos_repo.BulkResult{TodoID: todoId, Err: err})
+	}
+
+	if failed && allOrNothing {
+		tx.Rollback()
+		return results, nil
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	return results, nil
+}
+
+func bulkApply(tx *gorm.DB, userId uint, todoId uint, action *todos_repo.BulkAction) errs.Error {
+
+	todo := &entity.Todo{}
+
+	if err := tx.Preload("Tags").Preload("Subtasks").First(todo, "id = ?", todoId).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errs.NewNotFoundError("todo not found")
+		}
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	// bulk actions stay in the personal space, workspace todos are changed
+	// one at a time through the workspace checks of authorization
+	if todo.UserID != userId || todo.WorkspaceID != nil {
+		return errs.NewUnathorizedError("you're not authorized to access this todo")
+	}
+
+	var err error
+
+	switch action.Name {
+	case todos_repo.BulkComplete:
+		err = bulkComplete(tx, todo)
+	case todos_repo.BulkReopen:
+		err = tx.Model(todo).Update("status", false).Error
+	case todos_repo.BulkDelete:
+		err = tx.Delete(todo).Error
+	case todos_repo.BulkMove:
+		err = tx.Model(todo).Update("project_id", action.ProjectID).Error
+	case todos_repo.BulkTag:
+		tag := &entity.Tag{}
+		tag.ID = action.TagID
+		err = tx.Model(todo).Omit("Tags.*").Association("Tags").Append(tag)
+	}
+
+	if err != nil {
 		return errs.NewInternalServerError("something went wrong")
 	}
 
 	return nil
 }
+
+// bulkComplete completes the todo and, like a single completion, hands its
+// recurrence over to the next occurrence.
+func bulkComplete(tx *gorm.DB, todo *entity.Todo) error {
+
+	if todo.Status {
+		return nil
+	}
+
+	next := todo.NextOccurrence(time.Now())
+
+	if next == nil {
+		return tx.Model(todo).Update("status", true).Error
+	}
+
+	err := tx.Model(todo).Updates(map[string]any{
+		"status":                 true,
+		"recurrence":             "",
+		"repeat_from_completion": false,
+	}).Error
+
+	if err != nil {
+		return err
+	}
+
+	return add(tx, next)
+}
@@ -0,0 +1,87 @@
+package todos_pg
+
+import (
+	"strings"
+	"todo-app/repo/todos_repo"
+)
+
+const headlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MinWords=5, MaxWords=20"
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+type sortKey struct {
+	column     string
+	descending bool
+	value      func(cursor *todos_repo.TodoCursor) any
+}
+
+func (k sortKey) order() string {
+
+	if k.descending {
+		return k.column + " DESC"
+	}
+
+	return k.column
+}
+
+// todos without a due date are sorted as todos_repo.NoDueAt
+var sortColumns = map[string]sortKey{
+	"position":   {column: "position", value: func(c *todos_repo.TodoCursor) any { return c.Position }},
+	"priority":   {column: "priority", descending: true, value: func(c *todos_repo.TodoCursor) any { return c.Priority }},
+	"due_at":     {column: "COALESCE(due_at, '9999-12-31 00:00:00+00')", value: func(c *todos_repo.TodoCursor) any { return c.DueAt }},
+	"created_at": {column: "created_at", value: func(c *todos_repo.TodoCursor) any { return c.CreatedAt }},
+	"updated_at": {column: "updated_at", value: func(c *todos_repo.TodoCursor) any { return c.UpdatedAt }},
+	"id":         {column: "id", value: func(c *todos_repo.TodoCursor) any { return c.ID }},
+}
+
+// sortKeys returns the sort field followed by the default list order of
+// position, priority and id as tie breakers, so every page is stable.
+func sortKeys(sort string, descending bool) []sortKey {
+
+	if _, ok := sortColumns[sort]; !ok {
+		sort = "position"
+	}
+
+	primary := sortColumns[sort]
+	primary.descending = descending
+
+	keys := []sortKey{primary}
+
+	for _, tieBreaker := range []string{"position", "priority", "id"} {
+		if tieBreaker != sort {
+			keys = append(keys, sortColumns[tieBreaker])
+		}
+	}
+
+	return keys
+}
+
+// afterCursor builds the keyset condition selecting the rows sorted after
+// the cursor: (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ...
+func afterCursor(keys []sortKey, cursor *todos_repo.TodoCursor) (string, []any) {
+
+	conditions := []string{}
+	args := []any{}
+
+	for i, key := range keys {
+		condition := []string{}
+
+		for _, equalKey := range keys[:i] {
+			condition = append(condition, equalKey.column+" = ?")
+			args = append(args, equalKey.value(cursor))
+		}
+
+		operator := " > ?"
+
+		if key.descending {
+			operator = " < ?"
+		}
+
+		condition = append(condition, key.column+operator)
+		args = append(args, key.value(cursor))
+
+		conditions = append(conditions, "("+strings.Join(condition, " AND ")+")")
+	}
+
+	return "(" + strings.Join(conditions, " OR ") + ")", args
+}
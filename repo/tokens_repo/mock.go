@@ -0,0 +1,34 @@
+package tokens_repo
+
+import (
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+)
+
+type repoMock struct {
+}
+
+var (
+	Add         func(token *entity.RefreshToken) errs.Error
+	FetchByHash func(tokenHash string) (*entity.RefreshToken, errs.Error)
+	Rotate      func(tokenId uint, next *entity.RefreshToken) errs.Error
+)
+
+func NewRepoMock() TokensRepo {
+	return &repoMock{}
+}
+
+// Add implements TokensRepo.
+func (rm *repoMock) Add(token *entity.RefreshToken) errs.Error {
+	return Add(token)
+}
+
+// FetchByHash implements TokensRepo.
+func (rm *repoMock) FetchByHash(tokenHash string) (*entity.RefreshToken, errs.Error) {
+	return FetchByHash(tokenHash)
+}
+
+// Rotate implements TokensRepo.
+func (rm *repoMock) Rotate(tokenId uint, next *entity.RefreshToken) errs.Error {
+	return Rotate(tokenId, next)
+}
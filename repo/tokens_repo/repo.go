@@ -0,0 +1,12 @@
+package tokens_repo
+
+import (
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+)
+
+type TokensRepo interface {
+	Add(token *entity.RefreshToken) errs.Error
+	FetchByHash(tokenHash string) (*entity.RefreshToken, errs.Error)
+	Rotate(tokenId uint, next *entity.RefreshToken) errs.Error
+}
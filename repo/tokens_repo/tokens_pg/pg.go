@@ -0,0 +1,85 @@
+package tokens_pg
+
+import (
+	"time"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+	"todo-app/repo/tokens_repo"
+
+	"gorm.io/gorm"
+)
+
+type tokensPg struct {
+	db *gorm.DB
+}
+
+func NewTokensRepo(db *gorm.DB) tokens_repo.TokensRepo {
+	return &tokensPg{db: db}
+}
+
+// Add implements tokens_repo.TokensRepo.
+func (pg *tokensPg) Add(token *entity.RefreshToken) errs.Error {
+
+	tx := pg.db.Begin()
+
+	if err := tx.Create(token).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
+
+// FetchByHash implements tokens_repo.TokensRepo.
+func (pg *tokensPg) FetchByHash(tokenHash string) (*entity.RefreshToken, errs.Error) {
+
+	token := entity.RefreshToken{}
+
+	if err := pg.db.First(&token, "token_hash = ?", tokenHash).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errs.NewNotFoundError("refresh token not found")
+		}
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	return &token, nil
+}
+
+// Rotate implements tokens_repo.TokensRepo.
+func (pg *tokensPg) Rotate(tokenId uint, next *entity.RefreshToken) errs.Error {
+
+	tx := pg.db.Begin()
+
+	// the token is only marked as used when no concurrent request did it
+	// first, so each token can be exchanged exactly once
+	result := tx.Model(&entity.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", tokenId).
+		Update("used_at", time.Now())
+
+	if result.Error != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return errs.NewConflictError("refresh token has been used")
+	}
+
+	if err := tx.Create(next).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
@@ -9,7 +9,7 @@ type repoMock struct {
 }
 
 var (
-	Add          func(user *entity.User) errs.Error
+	Add          func(user *entity.User, verification *entity.EmailVerification) errs.Error
 	FetchByEmail func(email string) (*entity.User, errs.Error)
 	FetchById    func(userId uint) (*entity.User, errs.Error)
 	Modify       func(userId uint, user *entity.User) errs.Error
@@ -20,8 +20,8 @@ func NewRepoMock() UsersRepo {
 }
 
 // Add implements UsersRepo.
-func (rm *repoMock) Add(user *entity.User) errs.Error {
-	return Add(user)
+func (rm *repoMock) Add(user *entity.User, verification *entity.EmailVerification) errs.Error {
+	return Add(user, verification)
 }
 
 // FetchByEmail implements UsersRepo.
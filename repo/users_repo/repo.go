@@ -6,7 +6,7 @@ import (
 )
 
 type UsersRepo interface {
-	Add(user *entity.User) errs.Error
+	Add(user *entity.User, verification *entity.EmailVerification) errs.Error
 	FetchById(userId uint) (*entity.User, errs.Error)
 	FetchByEmail(email string) (*entity.User, errs.Error)
 	Modify(userId uint, user *entity.User) errs.Error
@@ -16,8 +16,9 @@ func NewUsersRepo(db *gorm.DB) users_repo.UsersRepo {
 	return &usersPg{db: db}
 }
 
-// Add implements users_repo.UsersRepo.
-func (pg *usersPg) Add(user *entity.User) errs.Error {
+// Add implements users_repo.UsersRepo. The verification of the email is
+// created in the same transaction, so no user is left without one.
+func (pg *usersPg) Add(user *entity.User, verification *entity.EmailVerification) errs.Error {
 
 	tx := pg.db.Begin()
 
@@ -31,6 +32,13 @@ func (pg *usersPg) Add(user *entity.User) errs.Error {
 		return errs.NewInternalServerError("something went wrong")
 	}
 
+	verification.UserID = user.ID
+
+	if err := tx.Create(verification).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		tx.Rollback()
 		return errs.NewInternalServerError("something went wrong")
@@ -79,6 +87,18 @@ func (pg *usersPg) Modify(userId uint, user *entity.User) errs.Error {
 		return errs.NewInternalServerError("something went wrong")
 	}
 
+	// todos are indexed with the language of their owner
+	if user.SearchLanguage != "" {
+		err := tx.Model(&entity.Todo{}).
+			Where("user_id = ?", userId).
+			Update("search_language", user.SearchLanguage).Error
+
+		if err != nil {
+			tx.Rollback()
+			return errs.NewInternalServerError("something went wrong")
+		}
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		tx.Rollback()
 		return errs.NewInternalServerError("something went wrong")
@@ -0,0 +1,34 @@
+package verifications_repo
+
+import (
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+)
+
+type repoMock struct {
+}
+
+var (
+	Add         func(verification *entity.EmailVerification) errs.Error
+	FetchByHash func(tokenHash string) (*entity.EmailVerification, errs.Error)
+	Consume     func(verification *entity.EmailVerification) errs.Error
+)
+
+func NewRepoMock() VerificationsRepo {
+	return &repoMock{}
+}
+
+// Add implements VerificationsRepo.
+func (rm *repoMock) Add(verification *entity.EmailVerification) errs.Error {
+	return Add(verification)
+}
+
+// FetchByHash implements VerificationsRepo.
+func (rm *repoMock) FetchByHash(tokenHash string) (*entity.EmailVerification, errs.Error) {
+	return FetchByHash(tokenHash)
+}
+
+// Consume implements VerificationsRepo.
+func (rm *repoMock) Consume(verification *entity.EmailVerification) errs.Error {
+	return Consume(verification)
+}
@@ -0,0 +1,12 @@
+package verifications_repo
+
+import (
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+)
+
+type VerificationsRepo interface {
+	Add(verification *entity.EmailVerification) errs.Error
+	FetchByHash(tokenHash string) (*entity.EmailVerification, errs.Error)
+	Consume(verification *entity.EmailVerification) errs.Error
+}
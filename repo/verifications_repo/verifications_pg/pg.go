@@ -0,0 +1,114 @@
+package verifications_pg
+
+import (
+	"time"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+	"todo-app/repo/verifications_repo"
+
+	"gorm.io/gorm"
+)
+
+type verificationsPg struct {
+	db *gorm.DB
+}
+
+func NewVerificationsRepo(db *gorm.DB) verifications_repo.VerificationsRepo {
+	return &verificationsPg{db: db}
+}
+
+// Add implements verifications_repo.VerificationsRepo.
+func (pg *verificationsPg) Add(verification *entity.EmailVerification) errs.Error {
+
+	tx := pg.db.Begin()
+
+	// only the latest mailed token of an address can be used
+	err := tx.Model(&entity.EmailVerification{}).
+		Where("user_id = ? AND email = ? AND used_at IS NULL", verification.UserID, verification.Email).
+		Update("used_at", time.Now()).Error
+
+	if err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Create(verification).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
+
+// FetchByHash implements verifications_repo.VerificationsRepo.
+func (pg *verificationsPg) FetchByHash(tokenHash string) (*entity.EmailVerification, errs.Error) {
+
+	verification := entity.EmailVerification{}
+
+	if err := pg.db.First(&verification, "token_hash = ?", tokenHash).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errs.NewNotFoundError("email verification not found")
+		}
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	return &verification, nil
+}
+
+// Consume implements verifications_repo.VerificationsRepo.
+func (pg *verificationsPg) Consume(verification *entity.EmailVerification) errs.Error {
+
+	tx := pg.db.Begin()
+
+	now := time.Now()
+
+	result := tx.Model(&entity.EmailVerification{}).
+		Where("id = ? AND used_at IS NULL", verification.ID).
+		Update("used_at", now)
+
+	if result.Error != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return errs.NewNotFoundError("email verification not found")
+	}
+
+	// the address is only applied while it is still the email or the
+	// pending email of the user, so a stale token can't revert a change
+	result = tx.Model(&entity.User{}).
+		Where("id = ? AND (email = ? OR pending_email = ?)", verification.UserID, verification.Email, verification.Email).
+		Updates(map[string]any{
+			"email":             verification.Email,
+			"email_verified_at": now,
+			"pending_email":     gorm.Expr("CASE WHEN pending_email = ? THEN '' ELSE pending_email END", verification.Email),
+		})
+
+	if result.Error != nil {
+		tx.Rollback()
+
+		if result.Error == gorm.ErrDuplicatedKey {
+			return errs.NewConflictError("email has been used")
+		}
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return errs.NewNotFoundError("email verification not found")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package workspaces_repo
+
+import (
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+)
+
+type repoMock struct {
+}
+
+var (
+	Add          func(workspace *entity.Workspace) errs.Error
+	Fetch        func(userId uint) ([]*entity.WorkspaceMember, errs.Error)
+	Detail       func(workspaceId uint) (*entity.Workspace, errs.Error)
+	Modify       func(workspaceId uint, workspace *entity.Workspace) errs.Error
+	AddMember    func(member *entity.WorkspaceMember) errs.Error
+	Member       func(workspaceId uint, userId uint) (*entity.WorkspaceMember, errs.Error)
+	MemberDetail func(memberId uint) (*entity.WorkspaceMember, errs.Error)
+	Members      func(workspaceId uint) ([]*entity.WorkspaceMember, errs.Error)
+	Invitations  func(userId uint) ([]*entity.WorkspaceMember, errs.Error)
+	AcceptMember func(memberId uint) errs.Error
+	ModifyMember func(memberId uint, role string) errs.Error
+	DeleteMember func(memberId uint) errs.Error
+	Transfer     func(workspaceId uint, fromUserId uint, toUserId uint) errs.Error
+)
+
+func NewRepoMock() WorkspacesRepo {
+	return &repoMock{}
+}
+
+// Add implements WorkspacesRepo.
+func (rm *repoMock) Add(workspace *entity.Workspace) errs.Error {
+	return Add(workspace)
+}
+
+// Fetch implements WorkspacesRepo.
+func (rm *repoMock) Fetch(userId uint) ([]*entity.WorkspaceMember, errs.Error) {
+	return Fetch(userId)
+}
+
+// Detail implements WorkspacesRepo.
+func (rm *repoMock) Detail(workspaceId uint) (*entity.Workspace, errs.Error) {
+	return Detail(workspaceId)
+}
+
+// Modify implements WorkspacesRepo.
+func (rm *repoMock) Modify(workspaceId uint, workspace *entity.Workspace) errs.Error {
+	return Modify(workspaceId, workspace)
+}
+
+// AddMember implements WorkspacesRepo.
+func (rm *repoMock) AddMember(member *entity.WorkspaceMember) errs.Error {
+	return AddMember(member)
+}
+
+// Member implements WorkspacesRepo.
+func (rm *repoMock) Member(workspaceId uint, userId uint) (*entity.WorkspaceMember, errs.Error) {
+	return Member(workspaceId, userId)
+}
+
+// MemberDetail implements WorkspacesRepo.
+func (rm *repoMock) MemberDetail(memberId uint) (*entity.WorkspaceMember, errs.Error) {
+	return MemberDetail(memberId)
+}
+
+// Members implements WorkspacesRepo.
+func (rm *repoMock) Members(workspaceId uint) ([]*entity.WorkspaceMember, errs.Error) {
+	return Members(workspaceId)
+}
+
+// Invitations implements WorkspacesRepo.
+func (rm *repoMock) Invitations(userId uint) ([]*entity.WorkspaceMember, errs.Error) {
+	return Invitations(userId)
+}
+
+// AcceptMember implements WorkspacesRepo.
+func (rm *repoMock) AcceptMember(memberId uint) errs.Error {
+	return AcceptMember(memberId)
+}
+
+// ModifyMember implements WorkspacesRepo.
+func (rm *repoMock) ModifyMember(memberId uint, role string) errs.Error {
+	return ModifyMember(memberId, role)
+}
+
+// DeleteMember implements WorkspacesRepo.
+func (rm *repoMock) DeleteMember(memberId uint) errs.Error {
+	return DeleteMember(memberId)
+}
+
+// Transfer implements WorkspacesRepo.
+func (rm *repoMock) Transfer(workspaceId uint, fromUserId uint, toUserId uint) errs.Error {
+	return Transfer(workspaceId, fromUserId, toUserId)
+}
@@ -0,0 +1,22 @@
+package workspaces_repo
+
+import (
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+)
+
+type WorkspacesRepo interface {
+	Add(workspace *entity.Workspace) errs.Error
+	Fetch(userId uint) ([]*entity.WorkspaceMember, errs.Error)
+	Detail(workspaceId uint) (*entity.Workspace, errs.Error)
+	Modify(workspaceId uint, workspace *entity.Workspace) errs.Error
+	AddMember(member *entity.WorkspaceMember) errs.Error
+	Member(workspaceId uint, userId uint) (*entity.WorkspaceMember, errs.Error)
+	MemberDetail(memberId uint) (*entity.WorkspaceMember, errs.Error)
+	Members(workspaceId uint) ([]*entity.WorkspaceMember, errs.Error)
+	Invitations(userId uint) ([]*entity.WorkspaceMember, errs.Error)
+	AcceptMember(memberId uint) errs.Error
+	ModifyMember(memberId uint, role string) errs.Error
+	DeleteMember(memberId uint) errs.Error
+	Transfer(workspaceId uint, fromUserId uint, toUserId uint) errs.Error
+}
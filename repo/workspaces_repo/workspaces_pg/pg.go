@@ -0,0 +1,270 @@
+package workspaces_pg
+
+import (
+	"errors"
+	"time"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+	"todo-app/repo/workspaces_repo"
+
+	"gorm.io/gorm"
+)
+
+type workspacesPg struct {
+	db *gorm.DB
+}
+
+func NewWorkspacesRepo(db *gorm.DB) workspaces_repo.WorkspacesRepo {
+	return &workspacesPg{db: db}
+}
+
+// Add implements workspaces_repo.WorkspacesRepo. The owner is added as the
+// first member of the workspace.
+func (pg *workspacesPg) Add(workspace *entity.Workspace) errs.Error {
+
+	tx := pg.db.Begin()
+
+	if err := tx.Create(workspace).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	now := time.Now()
+
+	owner := &entity.WorkspaceMember{
+		WorkspaceID: workspace.ID,
+		UserID:      workspace.OwnerID,
+		Role:        entity.WorkspaceRoleOwner,
+		InvitedBy:   workspace.OwnerID,
+		AcceptedAt:  &now,
+	}
+
+	if err := tx.Omit("Workspace", "User").Create(owner).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
+
+// Fetch implements workspaces_repo.WorkspacesRepo.
+func (pg *workspacesPg) Fetch(userId uint) ([]*entity.WorkspaceMember, errs.Error) {
+	return pg.members("user_id = ? AND accepted_at IS NOT NULL", userId)
+}
+
+// Detail implements workspaces_repo.WorkspacesRepo.
+func (pg *workspacesPg) Detail(workspaceId uint) (*entity.Workspace, errs.Error) {
+
+	workspace := entity.Workspace{}
+
+	if err := pg.db.First(&workspace, workspaceId).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errs.NewNotFoundError("workspace not found")
+		}
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	return &workspace, nil
+}
+
+// Modify implements workspaces_repo.WorkspacesRepo.
+func (pg *workspacesPg) Modify(workspaceId uint, workspace *entity.Workspace) errs.Error {
+
+	tx := pg.db.Begin()
+
+	if err := tx.Model(&entity.Workspace{}).Where("id = ?", workspaceId).Update("name", workspace.Name).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
+
+// AddMember implements workspaces_repo.WorkspacesRepo.
+func (pg *workspacesPg) AddMember(member *entity.WorkspaceMember) errs.Error {
+
+	tx := pg.db.Begin()
+
+	if err := tx.Omit("Workspace", "User").Create(member).Error; err != nil {
+		tx.Rollback()
+
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errs.NewConflictError("user has been invited to this workspace")
+		}
+
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
+
+// Member implements workspaces_repo.WorkspacesRepo.
+func (pg *workspacesPg) Member(workspaceId uint, userId uint) (*entity.WorkspaceMember, errs.Error) {
+	return pg.member("workspace_id = ? AND user_id = ?", workspaceId, userId)
+}
+
+// MemberDetail implements workspaces_repo.WorkspacesRepo.
+func (pg *workspacesPg) MemberDetail(memberId uint) (*entity.WorkspaceMember, errs.Error) {
+	return pg.member("id = ?", memberId)
+}
+
+func (pg *workspacesPg) member(condition string, args ...any) (*entity.WorkspaceMember, errs.Error) {
+
+	member := entity.WorkspaceMember{}
+
+	if err := pg.db.Preload("User").Where(condition, args...).First(&member).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errs.NewNotFoundError("member not found")
+		}
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	return &member, nil
+}
+
+// Members implements workspaces_repo.WorkspacesRepo.
+func (pg *workspacesPg) Members(workspaceId uint) ([]*entity.WorkspaceMember, errs.Error) {
+	return pg.members("workspace_id = ?", workspaceId)
+}
+
+// Invitations implements workspaces_repo.WorkspacesRepo.
+func (pg *workspacesPg) Invitations(userId uint) ([]*entity.WorkspaceMember, errs.Error) {
+	return pg.members("user_id = ? AND accepted_at IS NULL", userId)
+}
+
+func (pg *workspacesPg) members(condition string, args ...any) ([]*entity.WorkspaceMember, errs.Error) {
+
+	members := []*entity.WorkspaceMember{}
+
+	err := pg.db.
+		Preload("Workspace").
+		Preload("User").
+		Where(condition, args...).
+		Order("created_at, id").
+		Find(&members).Error
+
+	if err != nil {
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	return members, nil
+}
+
+// AcceptMember implements workspaces_repo.WorkspacesRepo.
+func (pg *workspacesPg) AcceptMember(memberId uint) errs.Error {
+
+	tx := pg.db.Begin()
+
+	result := tx.Model(&entity.WorkspaceMember{}).Where("id = ? AND accepted_at IS NULL", memberId).Update("accepted_at", time.Now())
+
+	if result.Error != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return errs.NewConflictError("invitation has been accepted")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
+
+// ModifyMember implements workspaces_repo.WorkspacesRepo.
+func (pg *workspacesPg) ModifyMember(memberId uint, role string) errs.Error {
+
+	tx := pg.db.Begin()
+
+	if err := tx.Model(&entity.WorkspaceMember{}).Where("id = ?", memberId).Update("role", role).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
+
+// DeleteMember implements workspaces_repo.WorkspacesRepo.
+func (pg *workspacesPg) DeleteMember(memberId uint) errs.Error {
+
+	tx := pg.db.Begin()
+
+	// memberships are removed for good so the user can be invited again
+	if err := tx.Unscoped().Delete(&entity.WorkspaceMember{}, memberId).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
+
+// Transfer implements workspaces_repo.WorkspacesRepo. The new owner must be
+// a member already, the previous owner stays on as an admin.
+func (pg *workspacesPg) Transfer(workspaceId uint, fromUserId uint, toUserId uint) errs.Error {
+
+	tx := pg.db.Begin()
+
+	result := tx.Model(&entity.WorkspaceMember{}).
+		Where("workspace_id = ? AND user_id = ? AND accepted_at IS NOT NULL", workspaceId, toUserId).
+		Update("role", entity.WorkspaceRoleOwner)
+
+	if result.Error != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return errs.NewNotFoundError("member not found")
+	}
+
+	err := tx.Model(&entity.WorkspaceMember{}).
+		Where("workspace_id = ? AND user_id = ?", workspaceId, fromUserId).
+		Update("role", entity.WorkspaceRoleAdmin).Error
+
+	if err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Model(&entity.Workspace{}).Where("id = ?", workspaceId).Update("owner_id", toUserId).Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package attachments_service
+
+import (
+	"todo-app/dto"
+	"todo-app/pkg/errs"
+)
+
+type serviceMock struct {
+}
+
+var (
+	Upload   func(userId uint, todoId uint, payload *dto.UploadAttachment) (*dto.AttachmentResponse, errs.Error)
+	Fetch    func(todoId uint) (*dto.AttachmentResponse, errs.Error)
+	Detail   func(todoId uint, attachmentId uint) (*dto.AttachmentResponse, errs.Error)
+	Delete   func(userId uint, todoId uint, attachmentId uint) (*dto.AttachmentResponse, errs.Error)
+	Download func(attachmentId uint, query *dto.DownloadQuery) (*dto.AttachmentDownload, errs.Error)
+)
+
+func NewServiceMock() AttachmentService {
+	return &serviceMock{}
+}
+
+// Upload implements AttachmentService.
+func (sm *serviceMock) Upload(userId uint, todoId uint, payload *dto.UploadAttachment) (*dto.AttachmentResponse, errs.Error) {
+	return Upload(userId, todoId, payload)
+}
+
+// Fetch implements AttachmentService.
+func (sm *serviceMock) Fetch(todoId uint) (*dto.AttachmentResponse, errs.Error) {
+	return Fetch(todoId)
+}
+
+// Detail implements AttachmentService.
+func (sm *serviceMock) Detail(todoId uint, attachmentId uint) (*dto.AttachmentResponse, errs.Error) {
+	return Detail(todoId, attachmentId)
+}
+
+// Delete implements AttachmentService.
+func (sm *serviceMock) Delete(userId uint, todoId uint, attachmentId uint) (*dto.AttachmentResponse, errs.Error) {
+	return Delete(userId, todoId, attachmentId)
+}
+
+// Download implements AttachmentService.
+func (sm *serviceMock) Download(attachmentId uint, query *dto.DownloadQuery) (*dto.AttachmentDownload, errs.Error) {
+	return Download(attachmentId, query)
+}
@@ -0,0 +1,345 @@
+package attachments_service
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+	"todo-app/dto"
+	"todo-app/entity"
+	"todo-app/infra/config"
+	"todo-app/pkg/errs"
+	"todo-app/pkg/storage"
+	"todo-app/repo/attachments_repo"
+	"todo-app/repo/todos_repo"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+)
+
+// sniffLen is how much of a file http.DetectContentType looks at.
+const sniffLen = 512
+
+type attachmentService struct {
+	ar     attachments_repo.AttachmentsRepo
+	tr     todos_repo.TodoRepo
+	blobs  storage.Storage
+	secret []byte
+}
+
+type AttachmentService interface {
+	Upload(userId uint, todoId uint, payload *dto.UploadAttachment) (*dto.AttachmentResponse, errs.Error)
+	Fetch(todoId uint) (*dto.AttachmentResponse, errs.Error)
+	Detail(todoId uint, attachmentId uint) (*dto.AttachmentResponse, errs.Error)
+	Delete(userId uint, todoId uint, attachmentId uint) (*dto.AttachmentResponse, errs.Error)
+	Download(attachmentId uint, query *dto.DownloadQuery) (*dto.AttachmentDownload, errs.Error)
+}
+
+// NewAttachmentService keeps the files in blobs, download URLs are signed
+// with secret.
+func NewAttachmentService(attachmentRepo attachments_repo.AttachmentsRepo, todoRepo todos_repo.TodoRepo, blobs storage.Storage, secret []byte) AttachmentService {
+	return &attachmentService{ar: attachmentRepo, tr: todoRepo, blobs: blobs, secret: secret}
+}
+
+// Upload implements AttachmentService. The type of the file is sniffed from
+// its content rather than trusted from the client, and has to be one of
+// ATTACHMENT_TYPES.
+func (as *attachmentService) Upload(userId uint, todoId uint, payload *dto.UploadAttachment) (*dto.AttachmentResponse, errs.Error) {
+
+	maxBytes := int64(config.AppConfig().AttachmentMaxBytes)
+
+	if payload.Size > maxBytes {
+		return nil, errs.NewRequestEntityTooLargeError(fmt.Sprintf("attachments are limited to %d bytes", maxBytes))
+	}
+
+	if payload.Size == 0 {
+		return nil, errs.NewBadRequestError("attachment can't be empty")
+	}
+
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(payload.File, head)
+
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, errs.NewBadRequestError("attachment can't be read")
+	}
+
+	head = head[:n]
+	contentType := http.DetectContentType(head)
+
+	if !allowedType(contentType) {
+		return nil, errs.NewUnsupportedMediaTypeError(fmt.Sprintf("attachments of type %s aren't allowed", mediaType(contentType)))
+	}
+
+	name, nameErr := randomName()
+
+	if nameErr != nil {
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	attachment := &entity.Attachment{
+		TodoID:      todoId,
+		UserID:      userId,
+		Filename:    cleanFilename(payload.Filename),
+		ContentType: contentType,
+		Size:        payload.Size,
+		StorageKey:  entity.AttachmentKey(todoId, name),
+	}
+
+	hash := sha256.New()
+	file := io.TeeReader(io.LimitReader(io.MultiReader(bytes.NewReader(head), payload.File), payload.Size), hash)
+
+	if err := as.blobs.Put(attachment.StorageKey, file, payload.Size, contentType); err != nil {
+		log.Errorf("error while storing attachment: %s", err.Error())
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	attachment.Checksum = hex.EncodeToString(hash.Sum(nil))
+
+	if err := as.ar.Add(attachment); err != nil {
+		as.deleteBlob(attachment.StorageKey)
+		return nil, err
+	}
+
+	return &dto.AttachmentResponse{
+		Status:  fiber.StatusCreated,
+		Message: "attachment successfully uploaded",
+		Data:    as.signed(attachment),
+	}, nil
+}
+
+// Fetch implements AttachmentService. Attachments of trashed todos aren't
+// listed.
+func (as *attachmentService) Fetch(todoId uint) (*dto.AttachmentResponse, errs.Error) {
+
+	if _, err := as.tr.Detail(todoId); err != nil {
+		return nil, err
+	}
+
+	a, err := as.ar.Fetch(todoId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	attachments := []*dto.Attachment{}
+
+	for _, eachAttachment := range a {
+		attachments = append(attachments, as.signed(eachAttachment))
+	}
+
+	return &dto.AttachmentResponse{
+		Status:  fiber.StatusOK,
+		Message: "attachments successfully fetched",
+		Data:    attachments,
+	}, nil
+}
+
+// Detail implements AttachmentService. Every call signs a fresh download URL.
+func (as *attachmentService) Detail(todoId uint, attachmentId uint) (*dto.AttachmentResponse, errs.Error) {
+
+	_, attachment, err := as.todoAttachment(todoId, attachmentId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.AttachmentResponse{
+		Status:  fiber.StatusOK,
+		Message: "attachment successfully fetched",
+		Data:    as.signed(attachment),
+	}, nil
+}
+
+// Delete implements AttachmentService. Attachments are deleted by their
+// uploader or by the owner of the todo.
+func (as *attachmentService) Delete(userId uint, todoId uint, attachmentId uint) (*dto.AttachmentResponse, errs.Error) {
+
+	todo, attachment, err := as.todoAttachment(todoId, attachmentId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if attachment.UserID != userId && todo.UserID != userId {
+		return nil, errs.NewUnathorizedError("you're not authorized to delete this attachment")
+	}
+
+	if err := as.ar.Delete(attachment.ID); err != nil {
+		return nil, err
+	}
+
+	as.deleteBlob(attachment.StorageKey)
+
+	return &dto.AttachmentResponse{
+		Status:  fiber.StatusOK,
+		Message: "attachment successfully deleted",
+		Data:    nil,
+	}, nil
+}
+
+// Download implements AttachmentService. The signature of the URL stands in
+// for authentication, so it is checked before anything is looked up. Links
+// stop working once the todo is trashed.
+func (as *attachmentService) Download(attachmentId uint, query *dto.DownloadQuery) (*dto.AttachmentDownload, errs.Error) {
+
+	expected := as.signature(attachmentId, query.Expires)
+
+	if !hmac.Equal([]byte(query.Signature), []byte(expected)) {
+		return nil, errs.NewUnathorizedError("invalid download signature")
+	}
+
+	if time.Now().Unix() > query.Expires {
+		return nil, errs.NewUnathorizedError("download link has expired")
+	}
+
+	attachment, err := as.ar.Detail(attachmentId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := as.tr.Detail(attachment.TodoID); err != nil {
+		return nil, err
+	}
+
+	body, blobErr := as.blobs.Get(attachment.StorageKey)
+
+	if blobErr == storage.ErrNotFound {
+		return nil, errs.NewNotFoundError("attachment not found")
+	}
+
+	if blobErr != nil {
+		log.Errorf("error while reading attachment: %s", blobErr.Error())
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	return &dto.AttachmentDownload{
+		Filename:    attachment.Filename,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		Body:        body,
+	}, nil
+}
+
+// todoAttachment looks up an attachment of the todo in the path along with
+// the todo, attachments of other or trashed todos are reported as not found.
+func (as *attachmentService) todoAttachment(todoId uint, attachmentId uint) (*entity.Todo, *entity.Attachment, errs.Error) {
+
+	todo, err := as.tr.Detail(todoId)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attachment, err := as.ar.Detail(attachmentId)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if attachment.TodoID != todoId {
+		return nil, nil, errs.NewNotFoundError("attachment not found")
+	}
+
+	return todo, attachment, nil
+}
+
+// signed converts the attachment with a download URL that expires after
+// ATTACHMENT_URL_TTL_MINUTES.
+func (as *attachmentService) signed(attachment *entity.Attachment) *dto.Attachment {
+
+	cfg := config.AppConfig()
+	expiresAt := time.Now().Add(time.Duration(cfg.AttachmentURLTTLMinutes) * time.Minute).Truncate(time.Second)
+
+	a := dto.EntityToAttachment(attachment)
+	a.URL = fmt.Sprintf("%s/api/v1/attachments/%d/download?expires=%d&signature=%s",
+		strings.TrimSuffix(cfg.ApiURL, "/"), attachment.ID, expiresAt.Unix(), as.signature(attachment.ID, expiresAt.Unix()))
+	a.URLExpiresAt = expiresAt
+
+	return a
+}
+
+func (as *attachmentService) signature(attachmentId uint, expires int64) string {
+	mac := hmac.New(sha256.New, as.secret)
+	fmt.Fprintf(mac, "%d:%d", attachmentId, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// deleteBlob removes the file of an attachment whose row is gone, a failure
+// only leaves an orphaned blob behind and is logged.
+func (as *attachmentService) deleteBlob(key string) {
+	if err := as.blobs.Delete(key); err != nil {
+		log.Errorf("error while deleting attachment %s: %s", key, err.Error())
+	}
+}
+
+// allowedType reports whether the media type is listed in ATTACHMENT_TYPES,
+// entries like image/* allow every subtype.
+func allowedType(contentType string) bool {
+
+	mt := mediaType(contentType)
+
+	for _, eachType := range strings.Split(config.AppConfig().AttachmentTypes, ",") {
+		eachType = strings.ToLower(strings.TrimSpace(eachType))
+
+		if eachType == mt || strings.HasSuffix(eachType, "/*") && strings.HasPrefix(mt, strings.TrimSuffix(eachType, "*")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func mediaType(contentType string) string {
+
+	mt, _, err := mime.ParseMediaType(contentType)
+
+	if err != nil {
+		return contentType
+	}
+
+	return mt
+}
+
+// cleanFilename keeps the base name of the uploaded file without control
+// characters, since it ends up in the Content-Disposition of downloads.
+func cleanFilename(filename string) string {
+
+	name := path.Base(strings.ReplaceAll(filename, "\\", "/"))
+
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, name)
+
+	if len(name) > 255 {
+		name = strings.ToValidUTF8(name[:255], "")
+	}
+
+	if name == "" || name == "." || name == "/" {
+		return "attachment"
+	}
+
+	return name
+}
+
+func randomName() (string, error) {
+
+	b := make([]byte, 16)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,333 @@
+package attachments_service_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"todo-app/dto"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+	"todo-app/pkg/storage"
+	"todo-app/repo/attachments_repo"
+	"todo-app/repo/todos_repo"
+	"todo-app/service/attachments_service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var repoMock = attachments_repo.NewRepoMock()
+var todoRepoMock = todos_repo.NewRepoMock()
+var storageMock = storage.NewStorageMock()
+var service = attachments_service.NewAttachmentService(repoMock, todoRepoMock, storageMock, []byte("secret"))
+
+var todoId = 1
+var attachmentId = 1
+var userId = 1
+
+var png = append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 600)...)
+
+func upload(content []byte) *dto.UploadAttachment {
+	return &dto.UploadAttachment{Filename: "../receipt.png", Size: int64(len(content)), File: bytes.NewReader(content)}
+}
+
+func mockAttachment(uploaderId uint) {
+	todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
+		return &entity.Todo{Model: gorm.Model{ID: todoId}, UserID: uint(userId)}, nil
+	}
+
+	attachments_repo.Detail = func(attachmentId uint) (*entity.Attachment, errs.Error) {
+		return &entity.Attachment{
+			Model:       gorm.Model{ID: attachmentId},
+			TodoID:      uint(todoId),
+			UserID:      uploaderId,
+			Filename:    "receipt.png",
+			ContentType: "image/png",
+			Size:        int64(len(png)),
+			StorageKey:  "todos/1/key",
+		}, nil
+	}
+}
+
+func TestUploadSuccess(t *testing.T) {
+	stored := &bytes.Buffer{}
+
+	storage.Put = func(key string, r io.Reader, size int64, contentType string) error {
+		assert.True(t, strings.HasPrefix(key, "todos/1/"))
+		assert.Equal(t, int64(len(png)), size)
+		assert.Equal(t, "image/png", contentType)
+		_, err := io.Copy(stored, r)
+		return err
+	}
+
+	attachments_repo.Add = func(attachment *entity.Attachment) errs.Error {
+		attachment.ID = uint(attachmentId)
+		return nil
+	}
+
+	ar, err := service.Upload(uint(userId), uint(todoId), upload(png))
+
+	sum := sha256.Sum256(png)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ar)
+	assert.Equal(t, fiber.StatusCreated, ar.Status)
+	assert.Equal(t, png, stored.Bytes())
+
+	attachment := ar.Data.(*dto.Attachment)
+
+	assert.Equal(t, "receipt.png", attachment.Filename)
+	assert.Equal(t, hex.EncodeToString(sum[:]), attachment.Checksum)
+	assert.Contains(t, attachment.URL, "/api/v1/attachments/1/download?expires=")
+}
+
+func TestUploadTooLarge(t *testing.T) {
+	ar, err := service.Upload(uint(userId), uint(todoId), &dto.UploadAttachment{Filename: "movie.png", Size: 11 << 20, File: bytes.NewReader(png)})
+
+	assert.Nil(t, ar)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusRequestEntityTooLarge, err.Status())
+}
+
+func TestUploadUnsupportedType(t *testing.T) {
+	// the extension doesn't matter, the type is sniffed from the content
+	ar, err := service.Upload(uint(userId), uint(todoId), &dto.UploadAttachment{
+		Filename: "page.png",
+		Size:     30,
+		File:     strings.NewReader("<html><script></script></html>"),
+	})
+
+	assert.Nil(t, ar)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusUnsupportedMediaType, err.Status())
+}
+
+func TestUploadServerErrorDeletesBlob(t *testing.T) {
+	storage.Put = func(key string, r io.Reader, size int64, contentType string) error {
+		_, err := io.Copy(io.Discard, r)
+		return err
+	}
+
+	deleted := ""
+
+	storage.Delete = func(key string) error {
+		deleted = key
+		return nil
+	}
+
+	attachments_repo.Add = func(attachment *entity.Attachment) errs.Error {
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	ar, err := service.Upload(uint(userId), uint(todoId), upload(png))
+
+	assert.Nil(t, ar)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, err.Status())
+	assert.True(t, strings.HasPrefix(deleted, "todos/1/"))
+}
+
+func TestDownloadSignedURLSuccess(t *testing.T) {
+	mockAttachment(uint(userId))
+
+	ar, err := service.Detail(uint(todoId), uint(attachmentId))
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ar)
+
+	u, _ := url.Parse(ar.Data.(*dto.Attachment).URL)
+	expires, _ := strconv.ParseInt(u.Query().Get("expires"), 10, 64)
+
+	storage.Get = func(key string) (io.ReadCloser, error) {
+		assert.Equal(t, "todos/1/key", key)
+		return io.NopCloser(bytes.NewReader(png)), nil
+	}
+
+	d, err := service.Download(uint(attachmentId), &dto.DownloadQuery{Expires: expires, Signature: u.Query().Get("signature")})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, d)
+	assert.Equal(t, "receipt.png", d.Filename)
+	assert.Equal(t, "image/png", d.ContentType)
+}
+
+func TestDownloadInvalidSignature(t *testing.T) {
+	mockAttachment(uint(userId))
+
+	ar, _ := service.Detail(uint(todoId), uint(attachmentId))
+
+	u, _ := url.Parse(ar.Data.(*dto.Attachment).URL)
+	expires, _ := strconv.ParseInt(u.Query().Get("expires"), 10, 64)
+
+	// the signature of one attachment doesn't open another
+	d, err := service.Download(2, &dto.DownloadQuery{Expires: expires, Signature: u.Query().Get("signature")})
+
+	assert.Nil(t, d)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusForbidden, err.Status())
+
+	d, err = service.Download(uint(attachmentId), &dto.DownloadQuery{Expires: expires + 3600, Signature: u.Query().Get("signature")})
+
+	assert.Nil(t, d)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusForbidden, err.Status())
+}
+
+func TestDownloadExpired(t *testing.T) {
+	t.Setenv("ATTACHMENT_URL_TTL_MINUTES", "-1")
+
+	mockAttachment(uint(userId))
+
+	ar, _ := service.Detail(uint(todoId), uint(attachmentId))
+
+	u, _ := url.Parse(ar.Data.(*dto.Attachment).URL)
+	expires, _ := strconv.ParseInt(u.Query().Get("expires"), 10, 64)
+
+	d, err := service.Download(uint(attachmentId), &dto.DownloadQuery{Expires: expires, Signature: u.Query().Get("signature")})
+
+	assert.Nil(t, d)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusForbidden, err.Status())
+	assert.Equal(t, "download link has expired", err.Message())
+}
+
+func TestDownloadMissingBlob(t *testing.T) {
+	mockAttachment(uint(userId))
+
+	ar, _ := service.Detail(uint(todoId), uint(attachmentId))
+
+	u, _ := url.Parse(ar.Data.(*dto.Attachment).URL)
+	expires, _ := strconv.ParseInt(u.Query().Get("expires"), 10, 64)
+
+	storage.Get = func(key string) (io.ReadCloser, error) {
+		return nil, storage.ErrNotFound
+	}
+
+	d, err := service.Download(uint(attachmentId), &dto.DownloadQuery{Expires: expires, Signature: u.Query().Get("signature")})
+
+	assert.Nil(t, d)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusNotFound, err.Status())
+}
+
+func TestDownloadOfTrashedTodo(t *testing.T) {
+	mockAttachment(uint(userId))
+
+	ar, _ := service.Detail(uint(todoId), uint(attachmentId))
+
+	u, _ := url.Parse(ar.Data.(*dto.Attachment).URL)
+	expires, _ := strconv.ParseInt(u.Query().Get("expires"), 10, 64)
+
+	todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
+		return nil, errs.NewNotFoundError("todo not found")
+	}
+
+	storage.Get = func(key string) (io.ReadCloser, error) {
+		t.Fatal("attachments of trashed todos shouldn't be read")
+		return nil, nil
+	}
+
+	d, err := service.Download(uint(attachmentId), &dto.DownloadQuery{Expires: expires, Signature: u.Query().Get("signature")})
+
+	assert.Nil(t, d)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusNotFound, err.Status())
+}
+
+func TestFetchAttachmentsSuccess(t *testing.T) {
+	mockAttachment(uint(userId))
+
+	attachments_repo.Fetch = func(todoId uint) ([]*entity.Attachment, errs.Error) {
+		return []*entity.Attachment{{Model: gorm.Model{ID: uint(attachmentId)}, TodoID: todoId}}, nil
+	}
+
+	ar, err := service.Fetch(uint(todoId))
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ar)
+	assert.Len(t, ar.Data.([]*dto.Attachment), 1)
+}
+
+func TestFetchAttachmentsOfTrashedTodo(t *testing.T) {
+	todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
+		return nil, errs.NewNotFoundError("todo not found")
+	}
+
+	ar, err := service.Fetch(uint(todoId))
+
+	assert.Nil(t, ar)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusNotFound, err.Status())
+}
+
+func TestDetailAttachmentOfTrashedTodo(t *testing.T) {
+	mockAttachment(uint(userId))
+
+	todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
+		return nil, errs.NewNotFoundError("todo not found")
+	}
+
+	ar, err := service.Detail(uint(todoId), uint(attachmentId))
+
+	assert.Nil(t, ar)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusNotFound, err.Status())
+}
+
+func TestDetailAttachmentOfOtherTodo(t *testing.T) {
+	mockAttachment(uint(userId))
+
+	ar, err := service.Detail(2, uint(attachmentId))
+
+	assert.Nil(t, ar)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusNotFound, err.Status())
+}
+
+func TestDeleteAttachmentByTodoOwnerSuccess(t *testing.T) {
+	mockAttachment(2)
+
+	todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
+		return &entity.Todo{Model: gorm.Model{ID: todoId}, UserID: uint(userId)}, nil
+	}
+
+	attachments_repo.Delete = func(attachmentId uint) errs.Error {
+		return nil
+	}
+
+	deleted := ""
+
+	// the row is gone already, so a storage failure doesn't fail the request
+	storage.Delete = func(key string) error {
+		deleted = key
+		return errors.New("connection refused")
+	}
+
+	ar, err := service.Delete(uint(userId), uint(todoId), uint(attachmentId))
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ar)
+	assert.Equal(t, fiber.StatusOK, ar.Status)
+	assert.Equal(t, "todos/1/key", deleted)
+}
+
+func TestDeleteAttachmentByOtherUser(t *testing.T) {
+	mockAttachment(2)
+
+	todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
+		return &entity.Todo{Model: gorm.Model{ID: todoId}, UserID: uint(userId)}, nil
+	}
+
+	ar, err := service.Delete(3, uint(todoId), uint(attachmentId))
+
+	assert.Nil(t, ar)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusForbidden, err.Status())
+}
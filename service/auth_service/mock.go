@@ -6,8 +6,17 @@ type authMock struct {
 }
 
 var (
-	Authentication func() fiber.Handler
-	Authorization  func() fiber.Handler
+	Authentication         func() fiber.Handler
+	Scope                  func(scope string) fiber.Handler
+	Verified               func() fiber.Handler
+	Role                   func(role string) fiber.Handler
+	Assignee               func() fiber.Handler
+	Authorization          func() fiber.Handler
+	TagAuthorization       func() fiber.Handler
+	ProjectAuthorization   func() fiber.Handler
+	TrashAuthorization     func() fiber.Handler
+	Workspace              func() fiber.Handler
+	WorkspaceAuthorization func(role string) fiber.Handler
 )
 
 func NewAuthMock() AuthService {
@@ -23,3 +32,48 @@ func (a *authMock) Authentication() fiber.Handler {
 func (a *authMock) Authorization() fiber.Handler {
 	return Authorization()
 }
+
+// TagAuthorization implements AuthService.
+func (a *authMock) TagAuthorization() fiber.Handler {
+	return TagAuthorization()
+}
+
+// ProjectAuthorization implements AuthService.
+func (a *authMock) ProjectAuthorization() fiber.Handler {
+	return ProjectAuthorization()
+}
+
+// TrashAuthorization implements AuthService.
+func (a *authMock) TrashAuthorization() fiber.Handler {
+	return TrashAuthorization()
+}
+
+// Verified implements AuthService.
+func (a *authMock) Verified() fiber.Handler {
+	return Verified()
+}
+
+// Scope implements AuthService.
+func (a *authMock) Scope(scope string) fiber.Handler {
+	return Scope(scope)
+}
+
+// Role implements AuthService.
+func (a *authMock) Role(role string) fiber.Handler {
+	return Role(role)
+}
+
+// Assignee implements AuthService.
+func (a *authMock) Assignee() fiber.Handler {
+	return Assignee()
+}
+
+// Workspace implements AuthService.
+func (a *authMock) Workspace() fiber.Handler {
+	return Workspace()
+}
+
+// WorkspaceAuthorization implements AuthService.
+func (a *authMock) WorkspaceAuthorization(role string) fiber.Handler {
+	return WorkspaceAuthorization(role)
+}
@@ -1,27 +1,56 @@
 package auth_service
 
 import (
+	"net/http"
 	"strconv"
+	"strings"
+	"time"
 	"todo-app/entity"
+	"todo-app/infra/config"
 	"todo-app/pkg/errs"
+	"todo-app/repo/personal_tokens_repo"
+	"todo-app/repo/projects_repo"
+	"todo-app/repo/sessions_repo"
+	"todo-app/repo/shares_repo"
+	"todo-app/repo/tags_repo"
 	"todo-app/repo/todos_repo"
 	"todo-app/repo/users_repo"
+	"todo-app/repo/workspaces_repo"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 type authService struct {
-	ur users_repo.UsersRepo
-	tr todos_repo.TodoRepo
+	ur  users_repo.UsersRepo
+	sr  sessions_repo.SessionsRepo
+	ptr personal_tokens_repo.PersonalTokensRepo
+	tr  todos_repo.TodoRepo
+	tgr tags_repo.TagRepo
+	pr  projects_repo.ProjectRepo
+	shr shares_repo.SharesRepo
+	wr  workspaces_repo.WorkspacesRepo
 }
 
 type AuthService interface {
 	Authentication() fiber.Handler
+	Scope(scope string) fiber.Handler
+	Verified() fiber.Handler
+	Role(role string) fiber.Handler
+	Assignee() fiber.Handler
 	Authorization() fiber.Handler
+	TagAuthorization() fiber.Handler
+	ProjectAuthorization() fiber.Handler
+	TrashAuthorization() fiber.Handler
+	Workspace() fiber.Handler
+	WorkspaceAuthorization(role string) fiber.Handler
 }
 
-func NewAuthService(userRepo users_repo.UsersRepo, todoRepo todos_repo.TodoRepo) AuthService {
-	return &authService{ur: userRepo, tr: todoRepo}
+// lastSeenInterval throttles how often the last seen time of a session is
+// written, so authenticated requests mostly stay off the database.
+const lastSeenInterval = time.Minute
+
+func NewAuthService(userRepo users_repo.UsersRepo, sessionRepo sessions_repo.SessionsRepo, personalTokenRepo personal_tokens_repo.PersonalTokensRepo, todoRepo todos_repo.TodoRepo, tagRepo tags_repo.TagRepo, projectRepo projects_repo.ProjectRepo, shareRepo shares_repo.SharesRepo, workspaceRepo workspaces_repo.WorkspacesRepo) AuthService {
+	return &authService{ur: userRepo, sr: sessionRepo, ptr: personalTokenRepo, tr: todoRepo, tgr: tagRepo, pr: projectRepo, shr: shareRepo, wr: workspaceRepo}
 }
 
 // Authentication implements AuthService.
@@ -29,6 +58,11 @@ func (as *authService) Authentication() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 
 		bearerToken := c.Get("Authorization")
+
+		if strings.HasPrefix(bearerToken, "Bearer "+entity.PersonalTokenPrefix) {
+			return as.personalTokenAuthentication(c, strings.TrimPrefix(bearerToken, "Bearer "))
+		}
+
 		user := entity.User{}
 
 		err := user.ValidateToken(bearerToken)
@@ -37,19 +71,152 @@ func (as *authService) Authentication() fiber.Handler {
 			return c.Status(err.Status()).JSON(err)
 		}
 
-		_, err = as.ur.FetchByEmail(user.Email)
+		// users are looked up by id since their email changes once a new
+		// one is verified
+		u, err := as.ur.FetchById(user.ID)
 
 		if err != nil {
 			errUnauthenticated := errs.NewUnauthenticatedError("invalid user")
 			return c.Status(errUnauthenticated.Status()).JSON(errUnauthenticated)
 		}
 
-		c.Locals("user", user)
+		session, err := as.sr.FetchByJti(user.SessionID)
+
+		if err != nil && err.Status() != http.StatusNotFound {
+			return c.Status(err.Status()).JSON(err)
+		}
+
+		if err != nil || session.UserID != user.ID || session.IsRevoked() {
+			errUnauthenticated := errs.NewUnauthenticatedError("session has been revoked")
+			return c.Status(errUnauthenticated.Status()).JSON(errUnauthenticated)
+		}
+
+		if now := time.Now(); now.Sub(session.LastSeenAt) >= lastSeenInterval {
+			// a failed write only leaves the last seen time stale
+			_ = as.sr.Touch(session.Jti, now)
+		}
+
+		u.SessionID = user.SessionID
+
+		c.Locals("user", *u)
 
 		return c.Next()
 	}
 }
 
+// personalTokenAuthentication authenticates a request made with a personal
+// token, which only reaches routes registered with a scope it was granted.
+func (as *authService) personalTokenAuthentication(c *fiber.Ctx, rawToken string) error {
+
+	token, err := as.ptr.FetchByHash(entity.HashToken(rawToken))
+
+	if err != nil && err.Status() != http.StatusNotFound {
+		return c.Status(err.Status()).JSON(err)
+	}
+
+	now := time.Now()
+
+	if err != nil || !token.IsActive(now) {
+		errUnauthenticated := errs.NewUnauthenticatedError("invalid token")
+		return c.Status(errUnauthenticated.Status()).JSON(errUnauthenticated)
+	}
+
+	u, err := as.ur.FetchById(token.UserID)
+
+	if err != nil {
+		errUnauthenticated := errs.NewUnauthenticatedError("invalid user")
+		return c.Status(errUnauthenticated.Status()).JSON(errUnauthenticated)
+	}
+
+	// an account that can't log in can't use the tokens it created either
+	if !u.IsVerified() && config.AppConfig().UnverifiedAccountPolicy == config.PolicyDeny {
+		errUnauthorizedError := errs.NewUnathorizedError("email address hasn't been verified")
+		return c.Status(errUnauthorizedError.Status()).JSON(errUnauthorizedError)
+	}
+
+	u.Scopes = token.ScopeList()
+
+	scope, _ := c.Locals("scope").(string)
+
+	if scope == "" || !u.HasScope(scope) {
+		errUnauthorizedError := errs.NewUnathorizedError("personal token isn't allowed to access this route")
+		return c.Status(errUnauthorizedError.Status()).JSON(errUnauthorizedError)
+	}
+
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= lastSeenInterval {
+		// a failed write only leaves the last used time stale
+		_ = as.ptr.Touch(token.TokenHash, now)
+	}
+
+	c.Locals("user", *u)
+
+	return c.Next()
+}
+
+// Scope implements AuthService. It is registered before Authentication and
+// names the scope a personal token needs for the route, routes without one
+// are only reachable by logged in users.
+func (as *authService) Scope(scope string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		c.Locals("scope", scope)
+
+		return c.Next()
+	}
+}
+
+// Verified implements AuthService.
+func (as *authService) Verified() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		user := c.Locals("user").(entity.User)
+
+		if !user.IsVerified() && config.AppConfig().UnverifiedAccountPolicy != config.PolicyAllow {
+			errUnauthorizedError := errs.NewUnathorizedError("verify your email address to use this feature")
+			return c.Status(errUnauthorizedError.Status()).JSON(errUnauthorizedError)
+		}
+
+		return c.Next()
+	}
+}
+
+// Role implements AuthService. It is registered before Authorization or
+// ProjectAuthorization and names the share role the route needs, without it
+// reading needs a viewer and everything else an editor.
+func (as *authService) Role(role string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		c.Locals("role", role)
+
+		return c.Next()
+	}
+}
+
+// Assignee implements AuthService. It is registered before Authorization on
+// the routes open to the assignee of a todo, who then only needs to be able
+// to read it.
+func (as *authService) Assignee() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		c.Locals("assignee", true)
+
+		return c.Next()
+	}
+}
+
+func requiredRole(c *fiber.Ctx) string {
+
+	if role, ok := c.Locals("role").(string); ok {
+		return role
+	}
+
+	if c.Method() == fiber.MethodGet {
+		return entity.ShareViewer
+	}
+
+	return entity.ShareEditor
+}
+
 // Authorization implements AuthService.
 func (as *authService) Authorization() fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -63,11 +230,223 @@ func (as *authService) Authorization() fiber.Handler {
 			return c.Status(err.Status()).JSON(err)
 		}
 
+		required := requiredRole(c)
+
+		if assignee, _ := c.Locals("assignee").(bool); assignee && t.AssigneeID != nil && *t.AssigneeID == user.ID {
+			required = entity.ShareViewer
+		}
+
+		access, err := as.shr.Access(user.ID, t)
+
+		if err != nil {
+			return c.Status(err.Status()).JSON(err)
+		}
+
+		if t.WorkspaceID != nil {
+			if err := workspaceRoleAllows(access.WorkspaceRole, workspaceRole(required, t.UserID == user.ID)); err != nil {
+				return c.Status(err.Status()).JSON(err)
+			}
+
+			return c.Next()
+		}
+
+		if !entity.RoleAllows(access.ShareRole, required) {
+			errUnauthorizedError := errs.NewUnathorizedError("you're not authorized to access this todo")
+			return c.Status(errUnauthorizedError.Status()).JSON(errUnauthorizedError)
+		}
+
+		return c.Next()
+	}
+}
+
+// TagAuthorization implements AuthService.
+func (as *authService) TagAuthorization() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		user := c.Locals("user").(entity.User)
+		tagId, _ := strconv.Atoi(c.Params("tagId"))
+
+		t, err := as.tgr.Detail(uint(tagId))
+
+		if err != nil {
+			return c.Status(err.Status()).JSON(err)
+		}
+
+		if t.UserID != user.ID {
+			errUnauthorizedError := errs.NewUnathorizedError("you're not authorized to access this tag")
+			return c.Status(errUnauthorizedError.Status()).JSON(errUnauthorizedError)
+		}
+
+		return c.Next()
+	}
+}
+
+// ProjectAuthorization implements AuthService.
+func (as *authService) ProjectAuthorization() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		user := c.Locals("user").(entity.User)
+		projectId, _ := strconv.Atoi(c.Params("projectId"))
+
+		p, err := as.pr.Detail(uint(projectId))
+
+		if err != nil {
+			return c.Status(err.Status()).JSON(err)
+		}
+
+		if p.WorkspaceID != nil {
+			if err := as.workspaceAccess(user.ID, *p.WorkspaceID, workspaceRole(requiredRole(c), p.UserID == user.ID)); err != nil {
+				return c.Status(err.Status()).JSON(err)
+			}
+
+			return c.Next()
+		}
+
+		if p.UserID == user.ID {
+			return c.Next()
+		}
+
+		role, err := as.shr.Role(user.ID, 0, p.ID)
+
+		if err != nil {
+			return c.Status(err.Status()).JSON(err)
+		}
+
+		if !entity.RoleAllows(role, requiredRole(c)) {
+			errUnauthorizedError := errs.NewUnathorizedError("you're not authorized to access this project")
+			return c.Status(errUnauthorizedError.Status()).JSON(errUnauthorizedError)
+		}
+
+		return c.Next()
+	}
+}
+
+// TrashAuthorization implements AuthService.
+func (as *authService) TrashAuthorization() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		user := c.Locals("user").(entity.User)
+		todoId, _ := strconv.Atoi(c.Params("todoId"))
+
+		t, err := as.tr.DetailTrash(uint(todoId))
+
+		if err != nil {
+			return c.Status(err.Status()).JSON(err)
+		}
+
 		if t.UserID != user.ID {
 			errUnauthorizedError := errs.NewUnathorizedError("you're not authorized to access this todo")
 			return c.Status(errUnauthorizedError.Status()).JSON(errUnauthorizedError)
 		}
 
+		// users who left a workspace lose their trashed todos there as well
+		if t.WorkspaceID != nil {
+			if err := as.workspaceAccess(user.ID, *t.WorkspaceID, entity.WorkspaceRoleMember); err != nil {
+				return c.Status(err.Status()).JSON(err)
+			}
+		}
+
 		return c.Next()
 	}
 }
+
+// Workspace implements AuthService. It is registered after Authentication
+// and selects the workspace named by the X-Workspace-ID header for the
+// request, without it the request stays in the personal space of the user.
+// Guests may only read, writing needs a member.
+func (as *authService) Workspace() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		header := c.Get(entity.WorkspaceHeader)
+
+		if header == "" {
+			return c.Next()
+		}
+
+		user := c.Locals("user").(entity.User)
+		workspaceId, convErr := strconv.ParseUint(header, 10, 32)
+
+		if convErr != nil || workspaceId == 0 {
+			errBadRequest := errs.NewBadRequestError("invalid workspace id")
+			return c.Status(errBadRequest.Status()).JSON(errBadRequest)
+		}
+
+		required := entity.WorkspaceRoleMember
+
+		if c.Method() == fiber.MethodGet {
+			required = entity.WorkspaceRoleGuest
+		}
+
+		if err := as.workspaceAccess(user.ID, uint(workspaceId), required); err != nil {
+			return c.Status(err.Status()).JSON(err)
+		}
+
+		user.WorkspaceID = uint(workspaceId)
+
+		c.Locals("user", user)
+
+		return c.Next()
+	}
+}
+
+// WorkspaceAuthorization implements AuthService.
+func (as *authService) WorkspaceAuthorization(role string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		user := c.Locals("user").(entity.User)
+		workspaceId, _ := strconv.Atoi(c.Params("workspaceId"))
+
+		if err := as.workspaceAccess(user.ID, uint(workspaceId), role); err != nil {
+			return c.Status(err.Status()).JSON(err)
+		}
+
+		return c.Next()
+	}
+}
+
+// workspaceAccess makes sure the user has accepted their membership of the
+// workspace and that their role grants at least the required one.
+func (as *authService) workspaceAccess(userId uint, workspaceId uint, required string) errs.Error {
+
+	member, err := as.wr.Member(workspaceId, userId)
+
+	if err != nil && err.Status() != http.StatusNotFound {
+		return err
+	}
+
+	if err != nil || !member.IsAccepted() {
+		return workspaceRoleAllows("", required)
+	}
+
+	return workspaceRoleAllows(member.Role, required)
+}
+
+// workspaceRoleAllows makes sure the role of the user in a workspace grants
+// at least the required one, users without a role aren't members.
+func workspaceRoleAllows(role string, required string) errs.Error {
+
+	if role == "" {
+		return errs.NewUnathorizedError("you're not a member of this workspace")
+	}
+
+	if !entity.WorkspaceRoleAllows(role, required) {
+		return errs.NewUnathorizedError("your workspace role doesn't allow this")
+	}
+
+	return nil
+}
+
+// workspaceRole is the workspace role needed for a route of a workspace
+// todo or project given the share role it names: guests only read, members
+// change everything and delete what they created, admins delete the rest.
+func workspaceRole(shareRole string, isCreator bool) string {
+
+	switch {
+	case shareRole == entity.ShareViewer:
+		return entity.WorkspaceRoleGuest
+	case shareRole == entity.ShareEditor || isCreator:
+		return entity.WorkspaceRoleMember
+	}
+
+	return entity.WorkspaceRoleAdmin
+}
@@ -0,0 +1,316 @@
+package auth_service_test
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+	"todo-app/repo/personal_tokens_repo"
+	"todo-app/repo/projects_repo"
+	"todo-app/repo/sessions_repo"
+	"todo-app/repo/shares_repo"
+	"todo-app/repo/tags_repo"
+	"todo-app/repo/todos_repo"
+	"todo-app/repo/users_repo"
+	"todo-app/repo/workspaces_repo"
+	"todo-app/service/auth_service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var service = auth_service.NewAuthService(users_repo.NewRepoMock(), sessions_repo.NewRepoMock(), personal_tokens_repo.NewRepoMock(),
+	todos_repo.NewRepoMock(), tags_repo.NewRepoMock(), projects_repo.NewRepoMock(), shares_repo.NewRepoMock(), workspaces_repo.NewRepoMock())
+
+var user = entity.User{Model: gorm.Model{ID: 2}, Name: "zee", Email: "zee@weeekly.com"}
+
+var ownerId = uint(1)
+var workspaceId = uint(1)
+
+// route is a kind of route guarded by Authorization or ProjectAuthorization,
+// registered with the same handlers as in handler/app.go.
+type route struct {
+	method   string
+	handlers func() []fiber.Handler
+}
+
+var readTodo = route{fiber.MethodGet, func() []fiber.Handler {
+	return []fiber.Handler{service.Authorization()}
+}}
+
+var writeTodo = route{fiber.MethodPatch, func() []fiber.Handler {
+	return []fiber.Handler{service.Authorization()}
+}}
+
+var deleteTodo = route{fiber.MethodDelete, func() []fiber.Handler {
+	return []fiber.Handler{service.Role(entity.ShareOwner), service.Authorization()}
+}}
+
+var todoStatus = route{fiber.MethodPatch, func() []fiber.Handler {
+	return []fiber.Handler{service.Assignee(), service.Authorization()}
+}}
+
+var readProject = route{fiber.MethodGet, func() []fiber.Handler {
+	return []fiber.Handler{service.ProjectAuthorization()}
+}}
+
+var deleteProject = route{fiber.MethodDelete, func() []fiber.Handler {
+	return []fiber.Handler{service.Role(entity.ShareOwner), service.ProjectAuthorization()}
+}}
+
+// call sends a request as user through the handlers of the route and returns
+// the status code, routes that let the request through answer 200.
+func call(r route, header map[string]string) int {
+
+	app := fiber.New()
+
+	handlers := []fiber.Handler{func(c *fiber.Ctx) error {
+		c.Locals("user", user)
+		return c.Next()
+	}}
+
+	handlers = append(handlers, r.handlers()...)
+	handlers = append(handlers, func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	app.Add(r.method, "/:todoId/:projectId/:workspaceId", handlers...)
+
+	req := httptest.NewRequest(r.method, "/1/1/1", nil)
+
+	for name, value := range header {
+		req.Header.Set(name, value)
+	}
+
+	res, _ := app.Test(req, -1)
+
+	return res.StatusCode
+}
+
+func member(role string, accepted bool) func(workspaceId uint, userId uint) (*entity.WorkspaceMember, errs.Error) {
+	return func(workspaceId uint, userId uint) (*entity.WorkspaceMember, errs.Error) {
+		m := &entity.WorkspaceMember{WorkspaceID: workspaceId, UserID: userId, Role: role}
+
+		if accepted {
+			acceptedAt := time.Now()
+			m.AcceptedAt = &acceptedAt
+		}
+
+		return m, nil
+	}
+}
+
+func notMember(workspaceId uint, userId uint) (*entity.WorkspaceMember, errs.Error) {
+	return nil, errs.NewNotFoundError("member not found")
+}
+
+func TestAuthorization(t *testing.T) {
+	assigneeId := user.ID
+
+	tests := []struct {
+		name     string
+		todo     entity.Todo
+		access   shares_repo.TodoAccess
+		route    route
+		expected int
+	}{
+		{"owner reads", entity.Todo{UserID: user.ID}, shares_repo.TodoAccess{ShareRole: entity.ShareOwner}, readTodo, fiber.StatusOK},
+		{"owner writes", entity.Todo{UserID: user.ID}, shares_repo.TodoAccess{ShareRole: entity.ShareOwner}, writeTodo, fiber.StatusOK},
+		{"owner deletes", entity.Todo{UserID: user.ID}, shares_repo.TodoAccess{ShareRole: entity.ShareOwner}, deleteTodo, fiber.StatusOK},
+		{"viewer reads", entity.Todo{UserID: ownerId}, shares_repo.TodoAccess{ShareRole: entity.ShareViewer}, readTodo, fiber.StatusOK},
+		{"viewer writes", entity.Todo{UserID: ownerId}, shares_repo.TodoAccess{ShareRole: entity.ShareViewer}, writeTodo, fiber.StatusForbidden},
+		{"viewer completes", entity.Todo{UserID: ownerId}, shares_repo.TodoAccess{ShareRole: entity.ShareViewer}, todoStatus, fiber.StatusForbidden},
+		{"editor writes", entity.Todo{UserID: ownerId}, shares_repo.TodoAccess{ShareRole: entity.ShareEditor}, writeTodo, fiber.StatusOK},
+		{"editor deletes", entity.Todo{UserID: ownerId}, shares_repo.TodoAccess{ShareRole: entity.ShareEditor}, deleteTodo, fiber.StatusForbidden},
+		{"owner share deletes", entity.Todo{UserID: ownerId}, shares_repo.TodoAccess{ShareRole: entity.ShareOwner}, deleteTodo, fiber.StatusOK},
+		{"pending invitation reads", entity.Todo{UserID: ownerId}, shares_repo.TodoAccess{}, readTodo, fiber.StatusForbidden},
+		{"assigned viewer completes", entity.Todo{UserID: ownerId, AssigneeID: &assigneeId}, shares_repo.TodoAccess{ShareRole: entity.ShareViewer}, todoStatus, fiber.StatusOK},
+		{"assigned viewer writes", entity.Todo{UserID: ownerId, AssigneeID: &assigneeId}, shares_repo.TodoAccess{ShareRole: entity.ShareViewer}, writeTodo, fiber.StatusForbidden},
+		{"workspace guest reads", entity.Todo{UserID: ownerId, WorkspaceID: &workspaceId}, shares_repo.TodoAccess{WorkspaceRole: entity.WorkspaceRoleGuest}, readTodo, fiber.StatusOK},
+		{"workspace guest writes", entity.Todo{UserID: ownerId, WorkspaceID: &workspaceId}, shares_repo.TodoAccess{WorkspaceRole: entity.WorkspaceRoleGuest}, writeTodo, fiber.StatusForbidden},
+		{"assigned workspace guest completes", entity.Todo{UserID: ownerId, WorkspaceID: &workspaceId, AssigneeID: &assigneeId}, shares_repo.TodoAccess{WorkspaceRole: entity.WorkspaceRoleGuest}, todoStatus, fiber.StatusOK},
+		{"workspace member writes", entity.Todo{UserID: ownerId, WorkspaceID: &workspaceId}, shares_repo.TodoAccess{WorkspaceRole: entity.WorkspaceRoleMember}, writeTodo, fiber.StatusOK},
+		{"workspace member deletes others", entity.Todo{UserID: ownerId, WorkspaceID: &workspaceId}, shares_repo.TodoAccess{WorkspaceRole: entity.WorkspaceRoleMember}, deleteTodo, fiber.StatusForbidden},
+		{"workspace member deletes own", entity.Todo{UserID: user.ID, WorkspaceID: &workspaceId}, shares_repo.TodoAccess{WorkspaceRole: entity.WorkspaceRoleMember}, deleteTodo, fiber.StatusOK},
+		{"workspace admin deletes others", entity.Todo{UserID: ownerId, WorkspaceID: &workspaceId}, shares_repo.TodoAccess{WorkspaceRole: entity.WorkspaceRoleAdmin}, deleteTodo, fiber.StatusOK},
+		{"pending workspace member reads", entity.Todo{UserID: ownerId, WorkspaceID: &workspaceId}, shares_repo.TodoAccess{}, readTodo, fiber.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
+				todo := test.todo
+				todo.ID = todoId
+				return &todo, nil
+			}
+
+			shares_repo.Access = func(userId uint, todo *entity.Todo) (*shares_repo.TodoAccess, errs.Error) {
+				assert.Equal(t, user.ID, userId)
+				access := test.access
+				return &access, nil
+			}
+
+			assert.Equal(t, test.expected, call(test.route, nil))
+		})
+	}
+}
+
+func TestAuthorizationTodoNotFound(t *testing.T) {
+	todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
+		return nil, errs.NewNotFoundError("todo not found")
+	}
+
+	assert.Equal(t, fiber.StatusNotFound, call(readTodo, nil))
+}
+
+func TestProjectAuthorization(t *testing.T) {
+	tests := []struct {
+		name      string
+		project   entity.Project
+		shareRole string
+		member    func(workspaceId uint, userId uint) (*entity.WorkspaceMember, errs.Error)
+		route     route
+		expected  int
+	}{
+		{"owner deletes", entity.Project{UserID: user.ID}, "", notMember, deleteProject, fiber.StatusOK},
+		{"viewer reads", entity.Project{UserID: ownerId}, entity.ShareViewer, notMember, readProject, fiber.StatusOK},
+		{"viewer deletes", entity.Project{UserID: ownerId}, entity.ShareViewer, notMember, deleteProject, fiber.StatusForbidden},
+		{"editor deletes", entity.Project{UserID: ownerId}, entity.ShareEditor, notMember, deleteProject, fiber.StatusForbidden},
+		{"owner share deletes", entity.Project{UserID: ownerId}, entity.ShareOwner, notMember, deleteProject, fiber.StatusOK},
+		{"pending invitation reads", entity.Project{UserID: ownerId}, "", notMember, readProject, fiber.StatusForbidden},
+		{"workspace guest reads", entity.Project{UserID: ownerId, WorkspaceID: &workspaceId}, "", member(entity.WorkspaceRoleGuest, true), readProject, fiber.StatusOK},
+		{"workspace guest deletes", entity.Project{UserID: ownerId, WorkspaceID: &workspaceId}, "", member(entity.WorkspaceRoleGuest, true), deleteProject, fiber.StatusForbidden},
+		{"workspace member deletes others", entity.Project{UserID: ownerId, WorkspaceID: &workspaceId}, "", member(entity.WorkspaceRoleMember, true), deleteProject, fiber.StatusForbidden},
+		{"workspace member deletes own", entity.Project{UserID: user.ID, WorkspaceID: &workspaceId}, "", member(entity.WorkspaceRoleMember, true), deleteProject, fiber.StatusOK},
+		{"workspace admin deletes others", entity.Project{UserID: ownerId, WorkspaceID: &workspaceId}, "", member(entity.WorkspaceRoleAdmin, true), deleteProject, fiber.StatusOK},
+		{"pending workspace invitation reads", entity.Project{UserID: ownerId, WorkspaceID: &workspaceId}, "", member(entity.WorkspaceRoleAdmin, false), readProject, fiber.StatusForbidden},
+		{"not a workspace member reads", entity.Project{UserID: ownerId, WorkspaceID: &workspaceId}, "", notMember, readProject, fiber.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			projects_repo.Detail = func(projectId uint) (*entity.Project, errs.Error) {
+				project := test.project
+				project.ID = projectId
+				return &project, nil
+			}
+
+			shares_repo.Role = func(userId uint, todoId uint, projectId uint) (string, errs.Error) {
+				return test.shareRole, nil
+			}
+
+			workspaces_repo.Member = test.member
+
+			assert.Equal(t, test.expected, call(test.route, nil))
+		})
+	}
+}
+
+func TestWorkspace(t *testing.T) {
+	readTodos := route{fiber.MethodGet, func() []fiber.Handler { return []fiber.Handler{service.Workspace()} }}
+	addTodo := route{fiber.MethodPost, func() []fiber.Handler { return []fiber.Handler{service.Workspace()} }}
+
+	tests := []struct {
+		name     string
+		header   string
+		member   func(workspaceId uint, userId uint) (*entity.WorkspaceMember, errs.Error)
+		route    route
+		expected int
+	}{
+		{"personal space", "", notMember, addTodo, fiber.StatusOK},
+		{"guest reads", "1", member(entity.WorkspaceRoleGuest, true), readTodos, fiber.StatusOK},
+		{"guest writes", "1", member(entity.WorkspaceRoleGuest, true), addTodo, fiber.StatusForbidden},
+		{"member writes", "1", member(entity.WorkspaceRoleMember, true), addTodo, fiber.StatusOK},
+		{"admin writes", "1", member(entity.WorkspaceRoleAdmin, true), addTodo, fiber.StatusOK},
+		{"pending invitation reads", "1", member(entity.WorkspaceRoleMember, false), readTodos, fiber.StatusForbidden},
+		{"not a member reads", "1", notMember, readTodos, fiber.StatusForbidden},
+		{"invalid workspace", "abc", notMember, readTodos, fiber.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			workspaces_repo.Member = test.member
+
+			header := map[string]string{}
+
+			if test.header != "" {
+				header[entity.WorkspaceHeader] = test.header
+			}
+
+			assert.Equal(t, test.expected, call(test.route, header))
+		})
+	}
+}
+
+func TestWorkspaceAuthorization(t *testing.T) {
+	tests := []struct {
+		name     string
+		required string
+		member   func(workspaceId uint, userId uint) (*entity.WorkspaceMember, errs.Error)
+		expected int
+	}{
+		{"guest reads", entity.WorkspaceRoleGuest, member(entity.WorkspaceRoleGuest, true), fiber.StatusOK},
+		{"member manages members", entity.WorkspaceRoleAdmin, member(entity.WorkspaceRoleMember, true), fiber.StatusForbidden},
+		{"admin manages members", entity.WorkspaceRoleAdmin, member(entity.WorkspaceRoleAdmin, true), fiber.StatusOK},
+		{"admin transfers ownership", entity.WorkspaceRoleOwner, member(entity.WorkspaceRoleAdmin, true), fiber.StatusForbidden},
+		{"owner transfers ownership", entity.WorkspaceRoleOwner, member(entity.WorkspaceRoleOwner, true), fiber.StatusOK},
+		{"pending invitation reads", entity.WorkspaceRoleGuest, member(entity.WorkspaceRoleOwner, false), fiber.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			workspaces_repo.Member = test.member
+
+			r := route{fiber.MethodGet, func() []fiber.Handler {
+				return []fiber.Handler{service.WorkspaceAuthorization(test.required)}
+			}}
+
+			assert.Equal(t, test.expected, call(r, nil))
+		})
+	}
+}
+
+func TestPersonalTokenOfUnverifiedUser(t *testing.T) {
+	verifiedAt := time.Now()
+
+	readTodos := route{fiber.MethodGet, func() []fiber.Handler {
+		return []fiber.Handler{service.Scope(entity.ScopeTodosRead), service.Authentication()}
+	}}
+
+	tests := []struct {
+		name       string
+		policy     string
+		verifiedAt *time.Time
+		expected   int
+	}{
+		{"verified user under deny", "deny", &verifiedAt, fiber.StatusOK},
+		{"unverified user under deny", "deny", nil, fiber.StatusForbidden},
+		{"unverified user under restrict", "restrict", nil, fiber.StatusOK},
+		{"unverified user under allow", "allow", nil, fiber.StatusOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("UNVERIFIED_ACCOUNT_POLICY", test.policy)
+
+			personal_tokens_repo.FetchByHash = func(tokenHash string) (*entity.PersonalToken, errs.Error) {
+				assert.Equal(t, entity.HashToken("tdk_token"), tokenHash)
+				return &entity.PersonalToken{UserID: user.ID, TokenHash: tokenHash, Scopes: entity.ScopeTodosRead}, nil
+			}
+
+			personal_tokens_repo.Touch = func(tokenHash string, usedAt time.Time) errs.Error {
+				return nil
+			}
+
+			users_repo.FetchById = func(userId uint) (*entity.User, errs.Error) {
+				u := user
+				u.EmailVerifiedAt = test.verifiedAt
+				return &u, nil
+			}
+
+			assert.Equal(t, test.expected, call(readTodos, map[string]string{"Authorization": "Bearer tdk_token"}))
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package comments_service
+
+import (
+	"todo-app/dto"
+	"todo-app/pkg/errs"
+)
+
+type serviceMock struct {
+}
+
+var (
+	Add    func(userId uint, todoId uint, payload *dto.AddComment) (*dto.CommentResponse, errs.Error)
+	Fetch  func(todoId uint, query *dto.CommentQuery) (*dto.CommentResponse, errs.Error)
+	Modify func(userId uint, todoId uint, commentId uint, payload *dto.ModifyComment) (*dto.CommentResponse, errs.Error)
+	Delete func(userId uint, todoId uint, commentId uint) (*dto.CommentResponse, errs.Error)
+)
+
+func NewServiceMock() CommentService {
+	return &serviceMock{}
+}
+
+// Add implements CommentService.
+func (sm *serviceMock) Add(userId uint, todoId uint, payload *dto.AddComment) (*dto.CommentResponse, errs.Error) {
+	return Add(userId, todoId, payload)
+}
+
+// Fetch implements CommentService.
+func (sm *serviceMock) Fetch(todoId uint, query *dto.CommentQuery) (*dto.CommentResponse, errs.Error) {
+	return Fetch(todoId, query)
+}
+
+// Modify implements CommentService.
+func (sm *serviceMock) Modify(userId uint, todoId uint, commentId uint, payload *dto.ModifyComment) (*dto.CommentResponse, errs.Error) {
+	return Modify(userId, todoId, commentId, payload)
+}
+
+// Delete implements CommentService.
+func (sm *serviceMock) Delete(userId uint, todoId uint, commentId uint) (*dto.CommentResponse, errs.Error) {
+	return Delete(userId, todoId, commentId)
+}
@@ -0,0 +1,334 @@
+package comments_service
+
+import (
+	"encoding/base64"
+	"strconv"
+	"time"
+	"todo-app/dto"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+	"todo-app/repo/comments_repo"
+	"todo-app/repo/shares_repo"
+	"todo-app/repo/todos_repo"
+	"todo-app/repo/users_repo"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	defaultLimit = 50
+	maxLimit     = 100
+)
+
+// MentionHook is notified of the users mentioned in a new or edited comment,
+// users who were already mentioned before an edit aren't notified again.
+type MentionHook func(todo *entity.Todo, comment *entity.Comment, mentioned []*entity.User)
+
+type commentService struct {
+	cr        comments_repo.CommentsRepo
+	tr        todos_repo.TodoRepo
+	ur        users_repo.UsersRepo
+	shr       shares_repo.SharesRepo
+	onMention MentionHook
+}
+
+type CommentService interface {
+	Add(userId uint, todoId uint, payload *dto.AddComment) (*dto.CommentResponse, errs.Error)
+	Fetch(todoId uint, query *dto.CommentQuery) (*dto.CommentResponse, errs.Error)
+	Modify(userId uint, todoId uint, commentId uint, payload *dto.ModifyComment) (*dto.CommentResponse, errs.Error)
+	Delete(userId uint, todoId uint, commentId uint) (*dto.CommentResponse, errs.Error)
+}
+
+func NewCommentService(commentRepo comments_repo.CommentsRepo, todoRepo todos_repo.TodoRepo, userRepo users_repo.UsersRepo, shareRepo shares_repo.SharesRepo, onMention MentionHook) CommentService {
+	return &commentService{cr: commentRepo, tr: todoRepo, ur: userRepo, shr: shareRepo, onMention: onMention}
+}
+
+// Add implements CommentService.
+func (cs *commentService) Add(userId uint, todoId uint, payload *dto.AddComment) (*dto.CommentResponse, errs.Error) {
+
+	todo, err := cs.tr.Detail(todoId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	author, err := cs.ur.FetchById(userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	mentioned, err := cs.mentioned(todo, userId, payload.Body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	comment := &entity.Comment{
+		TodoID:   todoId,
+		UserID:   userId,
+		Body:     payload.Body,
+		Mentions: mentions(mentioned),
+	}
+
+	if err := cs.cr.Add(comment); err != nil {
+		return nil, err
+	}
+
+	comment.User = *author
+
+	cs.notify(todo, comment, mentioned)
+
+	return &dto.CommentResponse{
+		Status:  fiber.StatusCreated,
+		Message: "comment successfully added",
+		Data:    dto.EntityToComment(comment),
+	}, nil
+}
+
+// Fetch implements CommentService.
+func (cs *commentService) Fetch(todoId uint, query *dto.CommentQuery) (*dto.CommentResponse, errs.Error) {
+
+	limit := query.Limit
+
+	if limit == 0 {
+		limit = defaultLimit
+	}
+
+	if limit < 0 || limit > maxLimit {
+		return nil, errs.NewBadRequestError("limit must be between 1 and 100")
+	}
+
+	afterId := uint(0)
+
+	if query.Cursor != "" {
+		id, err := decodeCursor(query.Cursor)
+
+		if err != nil {
+			return nil, err
+		}
+
+		afterId = id
+	}
+
+	// one more comment is fetched to know whether there is a next page
+	c, err := cs.cr.Fetch(todoId, afterId, limit+1)
+
+	if err != nil {
+		return nil, err
+	}
+
+	nextCursor := ""
+
+	if len(c) > limit {
+		c = c[:limit]
+		nextCursor = encodeCursor(c[limit-1].ID)
+	}
+
+	comments := []*dto.Comment{}
+
+	for _, eachComment := range c {
+		comments = append(comments, dto.EntityToComment(eachComment))
+	}
+
+	return &dto.CommentResponse{
+		Status:     fiber.StatusOK,
+		Message:    "comments successfully fetched",
+		Data:       comments,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// Modify implements CommentService. Only the author can edit a comment.
+func (cs *commentService) Modify(userId uint, todoId uint, commentId uint, payload *dto.ModifyComment) (*dto.CommentResponse, errs.Error) {
+
+	comment, err := cs.todoComment(todoId, commentId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.UserID != userId {
+		return nil, errs.NewUnathorizedError("only the author can edit a comment")
+	}
+
+	todo, err := cs.tr.Detail(todoId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	mentioned, err := cs.mentioned(todo, userId, payload.Body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	previous := map[uint]bool{}
+
+	for _, eachId := range comment.MentionedUserIds() {
+		previous[eachId] = true
+	}
+
+	now := time.Now()
+
+	comment.Body, comment.EditedAt = payload.Body, &now
+	comment.Mentions = mentions(mentioned)
+
+	if err := cs.cr.Modify(comment); err != nil {
+		return nil, err
+	}
+
+	newlyMentioned := []*entity.User{}
+
+	for _, eachUser := range mentioned {
+		if !previous[eachUser.ID] {
+			newlyMentioned = append(newlyMentioned, eachUser)
+		}
+	}
+
+	cs.notify(todo, comment, newlyMentioned)
+
+	return &dto.CommentResponse{
+		Status:  fiber.StatusOK,
+		Message: "comment successfully modified",
+		Data:    dto.EntityToComment(comment),
+	}, nil
+}
+
+// Delete implements CommentService. Comments are deleted by their author or
+// by the owner of the todo.
+func (cs *commentService) Delete(userId uint, todoId uint, commentId uint) (*dto.CommentResponse, errs.Error) {
+
+	comment, err := cs.todoComment(todoId, commentId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.UserID != userId {
+		todo, err := cs.tr.Detail(todoId)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if todo.UserID != userId {
+			return nil, errs.NewUnathorizedError("you're not authorized to delete this comment")
+		}
+	}
+
+	if err := cs.cr.Delete(comment.ID); err != nil {
+		return nil, err
+	}
+
+	return &dto.CommentResponse{
+		Status:  fiber.StatusOK,
+		Message: "comment successfully deleted",
+		Data:    nil,
+	}, nil
+}
+
+// todoComment looks up a comment of the todo in the path, comments of other
+// todos are reported as not found.
+func (cs *commentService) todoComment(todoId uint, commentId uint) (*entity.Comment, errs.Error) {
+
+	comment, err := cs.cr.Detail(commentId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.TodoID != todoId {
+		return nil, errs.NewNotFoundError("comment not found")
+	}
+
+	return comment, nil
+}
+
+// mentioned looks up the users mentioned in a comment body. Unknown emails,
+// the author and users who can't see the todo are left out, so a mention
+// never reveals the todo to someone else.
+func (cs *commentService) mentioned(todo *entity.Todo, authorId uint, body string) ([]*entity.User, errs.Error) {
+
+	users := []*entity.User{}
+
+	for _, eachEmail := range entity.ParseMentions(body) {
+		user, err := cs.ur.FetchByEmail(eachEmail)
+
+		if err != nil && err.Status() == fiber.StatusNotFound {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if user.ID == authorId {
+			continue
+		}
+
+		visible, err := cs.visible(todo, user.ID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if visible {
+			users = append(users, user)
+		}
+	}
+
+	return users, nil
+}
+
+// visible reports whether the user can see the todo, as a member of its
+// workspace or else as its owner or through an accepted share.
+func (cs *commentService) visible(todo *entity.Todo, userId uint) (bool, errs.Error) {
+
+	access, err := cs.shr.Access(userId, todo)
+
+	if err != nil {
+		return false, err
+	}
+
+	return access.Visible(), nil
+}
+
+func (cs *commentService) notify(todo *entity.Todo, comment *entity.Comment, mentioned []*entity.User) {
+	if len(mentioned) > 0 && cs.onMention != nil {
+		go cs.onMention(todo, comment, mentioned)
+	}
+}
+
+func mentions(users []*entity.User) []entity.CommentMention {
+
+	m := []entity.CommentMention{}
+
+	for _, eachUser := range users {
+		m = append(m, entity.CommentMention{UserID: eachUser.ID})
+	}
+
+	return m
+}
+
+func encodeCursor(commentId uint) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(uint64(commentId), 10)))
+}
+
+func decodeCursor(value string) (uint, errs.Error) {
+
+	b, err := base64.RawURLEncoding.DecodeString(value)
+
+	if err != nil {
+		return 0, errs.NewBadRequestError("invalid cursor")
+	}
+
+	id, err := strconv.ParseUint(string(b), 10, 32)
+
+	if err != nil {
+		return 0, errs.NewBadRequestError("invalid cursor")
+	}
+
+	return uint(id), nil
+}
@@ -0,0 +1,283 @@
+package comments_service_test
+
+import (
+	"testing"
+	"time"
+	"todo-app/dto"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+	"todo-app/repo/comments_repo"
+	"todo-app/repo/shares_repo"
+	"todo-app/repo/todos_repo"
+	"todo-app/repo/users_repo"
+	"todo-app/service/comments_service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var repoMock = comments_repo.NewRepoMock()
+var todoRepoMock = todos_repo.NewRepoMock()
+var userRepoMock = users_repo.NewRepoMock()
+var shareRepoMock = shares_repo.NewRepoMock()
+var mentions = make(chan []*entity.User, 1)
+var service = comments_service.NewCommentService(repoMock, todoRepoMock, userRepoMock, shareRepoMock,
+	func(todo *entity.Todo, comment *entity.Comment, mentioned []*entity.User) {
+		mentions <- mentioned
+	})
+
+var todoId = 1
+var commentId = 1
+
+var author = &entity.User{Model: gorm.Model{ID: 1}, Name: "jihan", Email: "jihan@weeekly.com"}
+var friend = &entity.User{Model: gorm.Model{ID: 2}, Name: "zee", Email: "zee@weeekly.com"}
+var stranger = &entity.User{Model: gorm.Model{ID: 3}, Name: "kai", Email: "kai@weeekly.com"}
+
+func mockTodo() {
+	todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
+		return &entity.Todo{Model: gorm.Model{ID: todoId}, Todos: "groceries", UserID: author.ID}, nil
+	}
+
+	users_repo.FetchById = func(userId uint) (*entity.User, errs.Error) {
+		return author, nil
+	}
+
+	users_repo.FetchByEmail = func(email string) (*entity.User, errs.Error) {
+		for _, eachUser := range []*entity.User{author, friend, stranger} {
+			if eachUser.Email == email {
+				return eachUser, nil
+			}
+		}
+		return nil, errs.NewNotFoundError("user not found")
+	}
+
+	// the todo is only shared with friend
+	shares_repo.Access = func(userId uint, todo *entity.Todo) (*shares_repo.TodoAccess, errs.Error) {
+		switch userId {
+		case todo.UserID:
+			return &shares_repo.TodoAccess{ShareRole: entity.ShareOwner}, nil
+		case friend.ID:
+			return &shares_repo.TodoAccess{ShareRole: entity.ShareViewer}, nil
+		}
+		return &shares_repo.TodoAccess{}, nil
+	}
+}
+
+func mockComment(userId uint, mentioned ...uint) {
+	comments_repo.Detail = func(commentId uint) (*entity.Comment, errs.Error) {
+		comment := &entity.Comment{Model: gorm.Model{ID: commentId}, TodoID: uint(todoId), UserID: userId, Body: "hello"}
+
+		for _, eachId := range mentioned {
+			comment.Mentions = append(comment.Mentions, entity.CommentMention{CommentID: commentId, UserID: eachId})
+		}
+
+		return comment, nil
+	}
+}
+
+func TestAddCommentMentionsSuccess(t *testing.T) {
+	mockTodo()
+
+	comments_repo.Add = func(comment *entity.Comment) errs.Error {
+		assert.Equal(t, uint(todoId), comment.TodoID)
+		assert.Equal(t, author.ID, comment.UserID)
+		assert.Equal(t, []uint{friend.ID}, comment.MentionedUserIds())
+		comment.ID = uint(commentId)
+		return nil
+	}
+
+	body := "@zee@weeekly.com @kai@weeekly.com @jihan@weeekly.com @nobody@weeekly.com can you pick these up?"
+
+	cr, err := service.Add(author.ID, uint(todoId), &dto.AddComment{Body: body})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, cr)
+	assert.Equal(t, fiber.StatusCreated, cr.Status)
+	assert.Equal(t, author.Name, cr.Data.(*dto.Comment).Name)
+
+	select {
+	case users := <-mentions:
+		assert.Len(t, users, 1)
+		assert.Equal(t, friend.Email, users[0].Email)
+	case <-time.After(time.Second):
+		t.Fatal("mention wasn't notified")
+	}
+}
+
+func TestAddCommentWorkspaceMention(t *testing.T) {
+	mockTodo()
+
+	workspaceId := uint(1)
+
+	todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
+		return &entity.Todo{Model: gorm.Model{ID: todoId}, UserID: author.ID, WorkspaceID: &workspaceId}, nil
+	}
+
+	shares_repo.Access = func(userId uint, todo *entity.Todo) (*shares_repo.TodoAccess, errs.Error) {
+		if userId == stranger.ID {
+			return &shares_repo.TodoAccess{WorkspaceRole: entity.WorkspaceRoleMember}, nil
+		}
+		return &shares_repo.TodoAccess{}, nil
+	}
+
+	comments_repo.Add = func(comment *entity.Comment) errs.Error {
+		assert.Equal(t, []uint{stranger.ID}, comment.MentionedUserIds())
+		return nil
+	}
+
+	cr, err := service.Add(author.ID, uint(todoId), &dto.AddComment{Body: "@zee@weeekly.com and @kai@weeekly.com"})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, cr)
+
+	select {
+	case users := <-mentions:
+		assert.Equal(t, stranger.ID, users[0].ID)
+	case <-time.After(time.Second):
+		t.Fatal("mention wasn't notified")
+	}
+}
+
+func TestAddCommentServerError(t *testing.T) {
+	mockTodo()
+
+	comments_repo.Add = func(comment *entity.Comment) errs.Error {
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	cr, err := service.Add(author.ID, uint(todoId), &dto.AddComment{Body: "hello"})
+
+	assert.Nil(t, cr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, err.Status())
+}
+
+func TestFetchCommentsNextCursor(t *testing.T) {
+	comments_repo.Fetch = func(todoId uint, afterId uint, limit int) ([]*entity.Comment, errs.Error) {
+		assert.Equal(t, 3, limit)
+
+		comments := []*entity.Comment{}
+
+		for id := afterId + 1; id <= afterId+uint(limit); id++ {
+			comments = append(comments, &entity.Comment{Model: gorm.Model{ID: id}, TodoID: todoId})
+		}
+
+		return comments, nil
+	}
+
+	cr, err := service.Fetch(uint(todoId), &dto.CommentQuery{Limit: 2})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, cr)
+	assert.Len(t, cr.Data, 2)
+	assert.NotEmpty(t, cr.NextCursor)
+
+	comments_repo.Fetch = func(todoId uint, afterId uint, limit int) ([]*entity.Comment, errs.Error) {
+		assert.Equal(t, uint(2), afterId)
+		return []*entity.Comment{}, nil
+	}
+
+	cr, err = service.Fetch(uint(todoId), &dto.CommentQuery{Cursor: cr.NextCursor, Limit: 2})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, cr)
+	assert.Empty(t, cr.NextCursor)
+}
+
+func TestFetchCommentsInvalidCursor(t *testing.T) {
+	cr, err := service.Fetch(uint(todoId), &dto.CommentQuery{Cursor: "not a cursor"})
+
+	assert.Nil(t, cr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, err.Status())
+}
+
+func TestFetchCommentsInvalidLimit(t *testing.T) {
+	cr, err := service.Fetch(uint(todoId), &dto.CommentQuery{Limit: 101})
+
+	assert.Nil(t, cr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, err.Status())
+}
+
+func TestModifyCommentNotifiesNewMentions(t *testing.T) {
+	mockTodo()
+	mockComment(author.ID, friend.ID)
+
+	workspaceId := uint(1)
+
+	todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
+		return &entity.Todo{Model: gorm.Model{ID: todoId}, UserID: author.ID, WorkspaceID: &workspaceId}, nil
+	}
+
+	shares_repo.Access = func(userId uint, todo *entity.Todo) (*shares_repo.TodoAccess, errs.Error) {
+		return &shares_repo.TodoAccess{WorkspaceRole: entity.WorkspaceRoleMember}, nil
+	}
+
+	comments_repo.Modify = func(comment *entity.Comment) errs.Error {
+		assert.NotNil(t, comment.EditedAt)
+		assert.Equal(t, []uint{friend.ID, stranger.ID}, comment.MentionedUserIds())
+		return nil
+	}
+
+	cr, err := service.Modify(author.ID, uint(todoId), uint(commentId), &dto.ModifyComment{Body: "@zee@weeekly.com @kai@weeekly.com"})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, cr)
+	assert.Equal(t, fiber.StatusOK, cr.Status)
+
+	select {
+	case users := <-mentions:
+		assert.Len(t, users, 1)
+		assert.Equal(t, stranger.ID, users[0].ID)
+	case <-time.After(time.Second):
+		t.Fatal("mention wasn't notified")
+	}
+}
+
+func TestModifyCommentByOtherUser(t *testing.T) {
+	mockComment(friend.ID)
+
+	cr, err := service.Modify(author.ID, uint(todoId), uint(commentId), &dto.ModifyComment{Body: "edited"})
+
+	assert.Nil(t, cr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusForbidden, err.Status())
+}
+
+func TestModifyCommentOfOtherTodo(t *testing.T) {
+	mockComment(author.ID)
+
+	cr, err := service.Modify(author.ID, 2, uint(commentId), &dto.ModifyComment{Body: "edited"})
+
+	assert.Nil(t, cr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusNotFound, err.Status())
+}
+
+func TestDeleteCommentByTodoOwnerSuccess(t *testing.T) {
+	mockTodo()
+	mockComment(friend.ID)
+
+	comments_repo.Delete = func(commentId uint) errs.Error {
+		return nil
+	}
+
+	cr, err := service.Delete(author.ID, uint(todoId), uint(commentId))
+
+	assert.Nil(t, err)
+	assert.NotNil(t, cr)
+	assert.Equal(t, fiber.StatusOK, cr.Status)
+}
+
+func TestDeleteCommentByOtherUser(t *testing.T) {
+	mockTodo()
+	mockComment(friend.ID)
+
+	cr, err := service.Delete(stranger.ID, uint(todoId), uint(commentId))
+
+	assert.Nil(t, cr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusForbidden, err.Status())
+}
@@ -0,0 +1,52 @@
+package projects_service
+
+import (
+	"todo-app/dto"
+	"todo-app/pkg/errs"
+)
+
+type serviceMock struct {
+}
+
+var (
+	Add        func(userId uint, workspaceId uint, payload *dto.AddProject) (*dto.ProjectResponse, errs.Error)
+	Delete     func(projectId uint, query *dto.DeleteProjectQuery) (*dto.ProjectResponse, errs.Error)
+	Detail     func(projectId uint) (*dto.ProjectResponse, errs.Error)
+	Fetch      func(userId uint, workspaceId uint, query *dto.ProjectQuery) (*dto.ProjectResponse, errs.Error)
+	FetchTodos func(projectId uint) (*dto.ProjectResponse, errs.Error)
+	Modify     func(projectId uint, payload *dto.ModifyProject) (*dto.ProjectResponse, errs.Error)
+)
+
+func NewServiceMock() ProjectService {
+	return &serviceMock{}
+}
+
+// Add implements ProjectService.
+func (sm *serviceMock) Add(userId uint, workspaceId uint, payload *dto.AddProject) (*dto.ProjectResponse, errs.Error) {
+	return Add(userId, workspaceId, payload)
+}
+
+// Delete implements ProjectService.
+func (sm *serviceMock) Delete(projectId uint, query *dto.DeleteProjectQuery) (*dto.ProjectResponse, errs.Error) {
+	return Delete(projectId, query)
+}
+
+// Detail implements ProjectService.
+func (sm *serviceMock) Detail(projectId uint) (*dto.ProjectResponse, errs.Error) {
+	return Detail(projectId)
+}
+
+// Fetch implements ProjectService.
+func (sm *serviceMock) Fetch(userId uint, workspaceId uint, query *dto.ProjectQuery) (*dto.ProjectResponse, errs.Error) {
+	return Fetch(userId, workspaceId, query)
+}
+
+// FetchTodos implements ProjectService.
+func (sm *serviceMock) FetchTodos(projectId uint) (*dto.ProjectResponse, errs.Error) {
+	return FetchTodos(projectId)
+}
+
+// Modify implements ProjectService.
+func (sm *serviceMock) Modify(projectId uint, payload *dto.ModifyProject) (*dto.ProjectResponse, errs.Error) {
+	return Modify(projectId, payload)
+}
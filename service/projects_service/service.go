@@ -0,0 +1,165 @@
+package projects_service
+
+import (
+	"todo-app/dto"
+	"todo-app/pkg/errs"
+	"todo-app/repo/projects_repo"
+	"todo-app/repo/todos_repo"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type projectService struct {
+	pr projects_repo.ProjectRepo
+	tr todos_repo.TodoRepo
+}
+
+type ProjectService interface {
+	Add(userId uint, workspaceId uint, payload *dto.AddProject) (*dto.ProjectResponse, errs.Error)
+	Delete(projectId uint, query *dto.DeleteProjectQuery) (*dto.ProjectResponse, errs.Error)
+	Detail(projectId uint) (*dto.ProjectResponse, errs.Error)
+	Fetch(userId uint, workspaceId uint, query *dto.ProjectQuery) (*dto.ProjectResponse, errs.Error)
+	FetchTodos(projectId uint) (*dto.ProjectResponse, errs.Error)
+	Modify(projectId uint, payload *dto.ModifyProject) (*dto.ProjectResponse, errs.Error)
+}
+
+func NewProjectService(projectRepo projects_repo.ProjectRepo, todoRepo todos_repo.TodoRepo) ProjectService {
+	return &projectService{pr: projectRepo, tr: todoRepo}
+}
+
+// Add implements ProjectService.
+func (ps *projectService) Add(userId uint, workspaceId uint, payload *dto.AddProject) (*dto.ProjectResponse, errs.Error) {
+
+	project := payload.AddProjectToEntity()
+	project.UserID = userId
+
+	if workspaceId != 0 {
+		project.WorkspaceID = &workspaceId
+	}
+
+	err := ps.pr.Add(project)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.ProjectResponse{
+		Status:  fiber.StatusCreated,
+		Message: "project successfully added",
+		Data:    dto.EntityToProject(project),
+	}, nil
+}
+
+// Delete implements ProjectService.
+func (ps *projectService) Delete(projectId uint, query *dto.DeleteProjectQuery) (*dto.ProjectResponse, errs.Error) {
+
+	withTodos := false
+
+	switch query.Mode {
+	case "", "inbox":
+	case "cascade":
+		withTodos = true
+	default:
+		return nil, errs.NewBadRequestError("mode must be one of inbox or cascade")
+	}
+
+	_, err := ps.pr.Detail(projectId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = ps.pr.Delete(projectId, withTodos)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.ProjectResponse{
+		Status:  fiber.StatusOK,
+		Message: "project successfully deleted",
+		Data:    nil,
+	}, nil
+}
+
+// Detail implements ProjectService.
+func (ps *projectService) Detail(projectId uint) (*dto.ProjectResponse, errs.Error) {
+
+	project, err := ps.pr.Detail(projectId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.ProjectResponse{
+		Status:  fiber.StatusOK,
+		Message: "project successfully fetched",
+		Data:    dto.EntityToProject(project),
+	}, nil
+}
+
+// Fetch implements ProjectService.
+func (ps *projectService) Fetch(userId uint, workspaceId uint, query *dto.ProjectQuery) (*dto.ProjectResponse, errs.Error) {
+
+	p, err := ps.pr.Fetch(userId, workspaceId, query.Archived)
+
+	if err != nil {
+		return nil, err
+	}
+
+	projects := []*dto.Project{}
+
+	for _, eachProject := range p {
+		projects = append(projects, dto.EntityToProject(eachProject))
+	}
+
+	return &dto.ProjectResponse{
+		Status:  fiber.StatusOK,
+		Message: "projects successfully fetched",
+		Data:    projects,
+	}, nil
+}
+
+// FetchTodos implements ProjectService.
+func (ps *projectService) FetchTodos(projectId uint) (*dto.ProjectResponse, errs.Error) {
+
+	t, err := ps.tr.FetchByProject(projectId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	todos := []*dto.Todo{}
+
+	for _, eachTodo := range t {
+		todos = append(todos, dto.EntityToTodo(eachTodo))
+	}
+
+	return &dto.ProjectResponse{
+		Status:  fiber.StatusOK,
+		Message: "project todos successfully fetched",
+		Data:    todos,
+	}, nil
+}
+
+// Modify implements ProjectService.
+func (ps *projectService) Modify(projectId uint, payload *dto.ModifyProject) (*dto.ProjectResponse, errs.Error) {
+
+	_, err := ps.pr.Detail(projectId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = ps.pr.Modify(projectId, payload.ModifyProjectToEntity())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.ProjectResponse{
+		Status:  fiber.StatusOK,
+		Message: "project successfully modified",
+		Data:    nil,
+	}, nil
+}
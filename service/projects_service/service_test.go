@@ -0,0 +1,165 @@
+package projects_service_test
+
+import (
+	"testing"
+	"todo-app/dto"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+	"todo-app/repo/projects_repo"
+	"todo-app/repo/todos_repo"
+	"todo-app/service/projects_service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var repoMock = projects_repo.NewRepoMock()
+var todoRepoMock = todos_repo.NewRepoMock()
+var service = projects_service.NewProjectService(repoMock, todoRepoMock)
+
+var projectId = 1
+var userId = 1
+
+var add = &dto.AddProject{
+	Name:  "groceries",
+	Color: "#00ff00",
+}
+
+var modify = &dto.ModifyProject{
+	Name:     "groceries",
+	Archived: true,
+}
+
+func TestAddProjectSuccess(t *testing.T) {
+	projects_repo.Add = func(project *entity.Project) errs.Error {
+		assert.Equal(t, uint(userId), project.UserID)
+		return nil
+	}
+
+	pr, err := service.Add(uint(userId), 0, add)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, pr)
+	assert.Equal(t, fiber.StatusCreated, pr.Status)
+}
+
+func TestAddProjectServerError(t *testing.T) {
+	projects_repo.Add = func(project *entity.Project) errs.Error {
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	pr, err := service.Add(uint(userId), 0, add)
+
+	assert.Nil(t, pr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, err.Status())
+}
+
+func TestDeleteProjectInvalidMode(t *testing.T) {
+	pr, err := service.Delete(uint(projectId), &dto.DeleteProjectQuery{Mode: "archive"})
+
+	assert.Nil(t, pr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, err.Status())
+}
+
+func TestDeleteProjectNotFound(t *testing.T) {
+	projects_repo.Detail = func(projectId uint) (*entity.Project, errs.Error) {
+		return nil, errs.NewNotFoundError("project not found")
+	}
+
+	pr, err := service.Delete(uint(projectId), &dto.DeleteProjectQuery{})
+
+	assert.Nil(t, pr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusNotFound, err.Status())
+}
+
+func TestDeleteProjectCascadeSuccess(t *testing.T) {
+	projects_repo.Detail = func(projectId uint) (*entity.Project, errs.Error) {
+		return &entity.Project{}, nil
+	}
+
+	projects_repo.Delete = func(projectId uint, withTodos bool) errs.Error {
+		assert.True(t, withTodos)
+		return nil
+	}
+
+	pr, err := service.Delete(uint(projectId), &dto.DeleteProjectQuery{Mode: "cascade"})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, pr)
+	assert.Equal(t, fiber.StatusOK, pr.Status)
+}
+
+func TestDeleteProjectInboxSuccess(t *testing.T) {
+	projects_repo.Detail = func(projectId uint) (*entity.Project, errs.Error) {
+		return &entity.Project{}, nil
+	}
+
+	projects_repo.Delete = func(projectId uint, withTodos bool) errs.Error {
+		assert.False(t, withTodos)
+		return nil
+	}
+
+	pr, err := service.Delete(uint(projectId), &dto.DeleteProjectQuery{})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, pr)
+	assert.Equal(t, fiber.StatusOK, pr.Status)
+}
+
+func TestDetailProjectSuccess(t *testing.T) {
+	projects_repo.Detail = func(projectId uint) (*entity.Project, errs.Error) {
+		return &entity.Project{Model: gorm.Model{ID: 1}}, nil
+	}
+
+	pr, err := service.Detail(uint(projectId))
+
+	assert.Nil(t, err)
+	assert.NotNil(t, pr)
+	assert.Equal(t, fiber.StatusOK, pr.Status)
+}
+
+func TestFetchProjectSuccess(t *testing.T) {
+	projects_repo.Fetch = func(userId uint, workspaceId uint, archived bool) ([]*entity.Project, errs.Error) {
+		return []*entity.Project{{Model: gorm.Model{ID: 1}}}, nil
+	}
+
+	pr, err := service.Fetch(uint(userId), 0, &dto.ProjectQuery{})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, pr)
+	assert.Equal(t, fiber.StatusOK, pr.Status)
+}
+
+func TestFetchProjectTodosSuccess(t *testing.T) {
+	todos_repo.FetchByProject = func(projectId uint) ([]*entity.Todo, errs.Error) {
+		return []*entity.Todo{{Model: gorm.Model{ID: 1}}}, nil
+	}
+
+	pr, err := service.FetchTodos(uint(projectId))
+
+	assert.Nil(t, err)
+	assert.NotNil(t, pr)
+	assert.Equal(t, fiber.StatusOK, pr.Status)
+	assert.Len(t, pr.Data, 1)
+}
+
+func TestModifyProjectSuccess(t *testing.T) {
+	projects_repo.Detail = func(projectId uint) (*entity.Project, errs.Error) {
+		return &entity.Project{}, nil
+	}
+
+	projects_repo.Modify = func(projectId uint, project *entity.Project) errs.Error {
+		assert.True(t, project.Archived)
+		return nil
+	}
+
+	pr, err := service.Modify(uint(projectId), modify)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, pr)
+	assert.Equal(t, fiber.StatusOK, pr.Status)
+}
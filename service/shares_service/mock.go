@@ -0,0 +1,70 @@
+package shares_service
+
+import (
+	"todo-app/dto"
+	"todo-app/pkg/errs"
+)
+
+type serviceMock struct {
+}
+
+var (
+	ShareTodo     func(userId uint, todoId uint, payload *dto.AddShare) (*dto.ShareResponse, errs.Error)
+	ShareProject  func(userId uint, projectId uint, payload *dto.AddShare) (*dto.ShareResponse, errs.Error)
+	TodoShares    func(todoId uint) (*dto.ShareResponse, errs.Error)
+	ProjectShares func(projectId uint) (*dto.ShareResponse, errs.Error)
+	Invitations   func(userId uint) (*dto.ShareResponse, errs.Error)
+	Accept        func(userId uint, shareId uint) (*dto.ShareResponse, errs.Error)
+	Decline       func(userId uint, shareId uint) (*dto.ShareResponse, errs.Error)
+	Modify        func(userId uint, shareId uint, payload *dto.ModifyShare) (*dto.ShareResponse, errs.Error)
+	Delete        func(userId uint, shareId uint) (*dto.ShareResponse, errs.Error)
+)
+
+func NewServiceMock() ShareService {
+	return &serviceMock{}
+}
+
+// ShareTodo implements ShareService.
+func (sm *serviceMock) ShareTodo(userId uint, todoId uint, payload *dto.AddShare) (*dto.ShareResponse, errs.Error) {
+	return ShareTodo(userId, todoId, payload)
+}
+
+// ShareProject implements ShareService.
+func (sm *serviceMock) ShareProject(userId uint, projectId uint, payload *dto.AddShare) (*dto.ShareResponse, errs.Error) {
+	return ShareProject(userId, projectId, payload)
+}
+
+// TodoShares implements ShareService.
+func (sm *serviceMock) TodoShares(todoId uint) (*dto.ShareResponse, errs.Error) {
+	return TodoShares(todoId)
+}
+
+// ProjectShares implements ShareService.
+func (sm *serviceMock) ProjectShares(projectId uint) (*dto.ShareResponse, errs.Error) {
+	return ProjectShares(projectId)
+}
+
+// Invitations implements ShareService.
+func (sm *serviceMock) Invitations(userId uint) (*dto.ShareResponse, errs.Error) {
+	return Invitations(userId)
+}
+
+// Accept implements ShareService.
+func (sm *serviceMock) Accept(userId uint, shareId uint) (*dto.ShareResponse, errs.Error) {
+	return Accept(userId, shareId)
+}
+
+// Decline implements ShareService.
+func (sm *serviceMock) Decline(userId uint, shareId uint) (*dto.ShareResponse, errs.Error) {
+	return Decline(userId, shareId)
+}
+
+// Modify implements ShareService.
+func (sm *serviceMock) Modify(userId uint, shareId uint, payload *dto.ModifyShare) (*dto.ShareResponse, errs.Error) {
+	return Modify(userId, shareId, payload)
+}
+
+// Delete implements ShareService.
+func (sm *serviceMock) Delete(userId uint, shareId uint) (*dto.ShareResponse, errs.Error) {
+	return Delete(userId, shareId)
+}
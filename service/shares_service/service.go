@@ -0,0 +1,352 @@
+package shares_service
+
+import (
+	"fmt"
+	"todo-app/dto"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+	"todo-app/pkg/mailer"
+	"todo-app/repo/projects_repo"
+	"todo-app/repo/shares_repo"
+	"todo-app/repo/todos_repo"
+	"todo-app/repo/users_repo"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+)
+
+type shareService struct {
+	shr shares_repo.SharesRepo
+	ur  users_repo.UsersRepo
+	tr  todos_repo.TodoRepo
+	pr  projects_repo.ProjectRepo
+	m   mailer.Mailer
+}
+
+type ShareService interface {
+	ShareTodo(userId uint, todoId uint, payload *dto.AddShare) (*dto.ShareResponse, errs.Error)
+	ShareProject(userId uint, projectId uint, payload *dto.AddShare) (*dto.ShareResponse, errs.Error)
+	TodoShares(todoId uint) (*dto.ShareResponse, errs.Error)
+	ProjectShares(projectId uint) (*dto.ShareResponse, errs.Error)
+	Invitations(userId uint) (*dto.ShareResponse, errs.Error)
+	Accept(userId uint, shareId uint) (*dto.ShareResponse, errs.Error)
+	Decline(userId uint, shareId uint) (*dto.ShareResponse, errs.Error)
+	Modify(userId uint, shareId uint, payload *dto.ModifyShare) (*dto.ShareResponse, errs.Error)
+	Delete(userId uint, shareId uint) (*dto.ShareResponse, errs.Error)
+}
+
+func NewShareService(shareRepo shares_repo.SharesRepo, userRepo users_repo.UsersRepo, todoRepo todos_repo.TodoRepo, projectRepo projects_repo.ProjectRepo, m mailer.Mailer) ShareService {
+	return &shareService{shr: shareRepo, ur: userRepo, tr: todoRepo, pr: projectRepo, m: m}
+}
+
+// ShareTodo implements ShareService.
+func (ss *shareService) ShareTodo(userId uint, todoId uint, payload *dto.AddShare) (*dto.ShareResponse, errs.Error) {
+
+	t, err := ss.tr.Detail(todoId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// workspace todos are only shared through the workspace so they never
+	// reach anyone outside of it
+	if t.WorkspaceID != nil {
+		return nil, errs.NewBadRequestError("workspace todos are shared by inviting to the workspace")
+	}
+
+	share := &entity.Share{TodoID: &t.ID}
+
+	return ss.invite(userId, t.UserID, share, fmt.Sprintf("the todo \"%s\"", t.Todos), payload)
+}
+
+// ShareProject implements ShareService.
+func (ss *shareService) ShareProject(userId uint, projectId uint, payload *dto.AddShare) (*dto.ShareResponse, errs.Error) {
+
+	p, err := ss.pr.Detail(projectId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if p.WorkspaceID != nil {
+		return nil, errs.NewBadRequestError("workspace projects are shared by inviting to the workspace")
+	}
+
+	share := &entity.Share{ProjectID: &p.ID}
+
+	return ss.invite(userId, p.UserID, share, fmt.Sprintf("the list \"%s\"", p.Name), payload)
+}
+
+// invite adds the share of a todo or project owned by ownerId for the user
+// with the payload email and lets them know by mail.
+func (ss *shareService) invite(userId uint, ownerId uint, share *entity.Share, item string, payload *dto.AddShare) (*dto.ShareResponse, errs.Error) {
+
+	if !entity.IsShareRole(payload.Role) {
+		return nil, errs.NewBadRequestError("role must be one of viewer, editor or owner")
+	}
+
+	inviter, err := ss.ur.FetchById(userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	invitee, err := ss.ur.FetchByEmail(payload.Email)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if invitee.ID == ownerId {
+		return nil, errs.NewBadRequestError("can't share with the owner")
+	}
+
+	share.UserID = invitee.ID
+	share.InvitedBy = userId
+	share.Role = payload.Role
+
+	if err := ss.shr.Add(share); err != nil {
+		return nil, err
+	}
+
+	share.User = *invitee
+
+	body := fmt.Sprintf("Hi %s,\n\n%s shared %s with you as %s. Open TodoKu to accept or decline the invitation.",
+		invitee.Name, inviter.Name, item, payload.Role)
+
+	go ss.send(invitee.Email, "You've been invited to collaborate on TodoKu", body)
+
+	return &dto.ShareResponse{
+		Status:  fiber.StatusCreated,
+		Message: "invitation successfully sent",
+		Data:    dto.EntityToShare(share),
+	}, nil
+}
+
+func (ss *shareService) send(to string, subject string, body string) {
+	if err := ss.m.Send(to, subject, body); err != nil {
+		log.Errorf("error while sending mail: %s", err.Error())
+	}
+}
+
+// TodoShares implements ShareService.
+func (ss *shareService) TodoShares(todoId uint) (*dto.ShareResponse, errs.Error) {
+
+	s, err := ss.shr.FetchByTodo(todoId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sharesResponse(s), nil
+}
+
+// ProjectShares implements ShareService.
+func (ss *shareService) ProjectShares(projectId uint) (*dto.ShareResponse, errs.Error) {
+
+	s, err := ss.shr.FetchByProject(projectId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sharesResponse(s), nil
+}
+
+// Invitations implements ShareService.
+func (ss *shareService) Invitations(userId uint) (*dto.ShareResponse, errs.Error) {
+
+	s, err := ss.shr.FetchInvitations(userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sharesResponse(s), nil
+}
+
+func sharesResponse(s []*entity.Share) *dto.ShareResponse {
+
+	shares := []*dto.Share{}
+
+	for _, eachShare := range s {
+		shares = append(shares, dto.EntityToShare(eachShare))
+	}
+
+	return &dto.ShareResponse{
+		Status:  fiber.StatusOK,
+		Message: "shares successfully fetched",
+		Data:    shares,
+	}
+}
+
+// invitation returns a share of the user, other shares are reported as not
+// found so their ids don't leak.
+func (ss *shareService) invitation(userId uint, shareId uint) (*entity.Share, errs.Error) {
+
+	share, err := ss.shr.Detail(shareId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if share.UserID != userId {
+		return nil, errs.NewNotFoundError("share not found")
+	}
+
+	return share, nil
+}
+
+// Accept implements ShareService.
+func (ss *shareService) Accept(userId uint, shareId uint) (*dto.ShareResponse, errs.Error) {
+
+	share, err := ss.invitation(userId, shareId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ss.shr.Accept(share.ID); err != nil {
+		return nil, err
+	}
+
+	return &dto.ShareResponse{
+		Status:  fiber.StatusOK,
+		Message: "invitation successfully accepted",
+		Data:    nil,
+	}, nil
+}
+
+// Decline implements ShareService.
+func (ss *shareService) Decline(userId uint, shareId uint) (*dto.ShareResponse, errs.Error) {
+
+	share, err := ss.invitation(userId, shareId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if share.IsAccepted() {
+		return nil, errs.NewConflictError("invitation has been accepted")
+	}
+
+	if err := ss.shr.Delete(share.ID); err != nil {
+		return nil, err
+	}
+
+	return &dto.ShareResponse{
+		Status:  fiber.StatusOK,
+		Message: "invitation successfully declined",
+		Data:    nil,
+	}, nil
+}
+
+// canManage reports whether the user owns the shared todo or project, or
+// has been given the owner role on it.
+func (ss *shareService) canManage(userId uint, share *entity.Share) (bool, errs.Error) {
+
+	ownerId, todoId, projectId := uint(0), uint(0), uint(0)
+
+	if share.TodoID != nil {
+		t, err := ss.tr.Detail(*share.TodoID)
+
+		if err != nil {
+			return false, err
+		}
+
+		ownerId, todoId = t.UserID, t.ID
+
+		if t.ProjectID != nil {
+			projectId = *t.ProjectID
+		}
+	} else {
+		p, err := ss.pr.Detail(*share.ProjectID)
+
+		if err != nil {
+			return false, err
+		}
+
+		ownerId, projectId = p.UserID, p.ID
+	}
+
+	if ownerId == userId {
+		return true, nil
+	}
+
+	role, err := ss.shr.Role(userId, todoId, projectId)
+
+	if err != nil {
+		return false, err
+	}
+
+	return entity.RoleAllows(role, entity.ShareOwner), nil
+}
+
+// Modify implements ShareService.
+func (ss *shareService) Modify(userId uint, shareId uint, payload *dto.ModifyShare) (*dto.ShareResponse, errs.Error) {
+
+	if !entity.IsShareRole(payload.Role) {
+		return nil, errs.NewBadRequestError("role must be one of viewer, editor or owner")
+	}
+
+	share, err := ss.shr.Detail(shareId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, err := ss.canManage(userId, share)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !allowed {
+		return nil, errs.NewUnathorizedError("you're not authorized to manage this share")
+	}
+
+	if err := ss.shr.ModifyRole(share.ID, payload.Role); err != nil {
+		return nil, err
+	}
+
+	share.Role = payload.Role
+
+	return &dto.ShareResponse{
+		Status:  fiber.StatusOK,
+		Message: "share successfully modified",
+		Data:    dto.EntityToShare(share),
+	}, nil
+}
+
+// Delete implements ShareService. The owner revokes a share, the user it
+// was shared with leaves it.
+func (ss *shareService) Delete(userId uint, shareId uint) (*dto.ShareResponse, errs.Error) {
+
+	share, err := ss.shr.Detail(shareId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if share.UserID != userId {
+		allowed, err := ss.canManage(userId, share)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !allowed {
+			return nil, errs.NewUnathorizedError("you're not authorized to manage this share")
+		}
+	}
+
+	if err := ss.shr.Delete(share.ID); err != nil {
+		return nil, err
+	}
+
+	return &dto.ShareResponse{
+		Status:  fiber.StatusOK,
+		Message: "share successfully deleted",
+		Data:    nil,
+	}, nil
+}
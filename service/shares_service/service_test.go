@@ -0,0 +1,329 @@
+package shares_service_test
+
+import (
+	"testing"
+	"time"
+	"todo-app/dto"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+	"todo-app/pkg/mailer"
+	"todo-app/repo/projects_repo"
+	"todo-app/repo/shares_repo"
+	"todo-app/repo/todos_repo"
+	"todo-app/repo/users_repo"
+	"todo-app/service/shares_service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var repoMock = shares_repo.NewRepoMock()
+var userRepoMock = users_repo.NewRepoMock()
+var todoRepoMock = todos_repo.NewRepoMock()
+var projectRepoMock = projects_repo.NewRepoMock()
+var mailerMock = mailer.NewMailerMock()
+var service = shares_service.NewShareService(repoMock, userRepoMock, todoRepoMock, projectRepoMock, mailerMock)
+
+var userId = 1
+var todoId = 1
+var projectId = 1
+var shareId = 1
+
+var owner = &entity.User{Model: gorm.Model{ID: 1}, Name: "jihan", Email: "jihan@weeekly.com"}
+var invitee = &entity.User{Model: gorm.Model{ID: 2}, Name: "zee", Email: "zee@weeekly.com"}
+
+var add = &dto.AddShare{
+	Email: "zee@weeekly.com",
+	Role:  entity.ShareEditor,
+}
+
+func mockInvite() {
+	todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
+		return &entity.Todo{Model: gorm.Model{ID: todoId}, Todos: "groceries", UserID: owner.ID}, nil
+	}
+
+	projects_repo.Detail = func(projectId uint) (*entity.Project, errs.Error) {
+		return &entity.Project{Model: gorm.Model{ID: projectId}, Name: "home", UserID: owner.ID}, nil
+	}
+
+	users_repo.FetchById = func(userId uint) (*entity.User, errs.Error) {
+		return owner, nil
+	}
+
+	users_repo.FetchByEmail = func(email string) (*entity.User, errs.Error) {
+		return invitee, nil
+	}
+
+	mailer.Send = func(to string, subject string, body string) error {
+		return nil
+	}
+}
+
+func TestShareTodoSuccess(t *testing.T) {
+	mockInvite()
+
+	shares_repo.Add = func(share *entity.Share) errs.Error {
+		assert.Equal(t, uint(todoId), *share.TodoID)
+		assert.Nil(t, share.ProjectID)
+		assert.Equal(t, invitee.ID, share.UserID)
+		assert.Equal(t, uint(userId), share.InvitedBy)
+		assert.Equal(t, entity.ShareEditor, share.Role)
+		assert.Nil(t, share.AcceptedAt)
+		return nil
+	}
+
+	sr, err := service.ShareTodo(uint(userId), uint(todoId), add)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, sr)
+	assert.Equal(t, fiber.StatusCreated, sr.Status)
+	assert.Equal(t, invitee.Email, sr.Data.(*dto.Share).Email)
+}
+
+func TestShareProjectSuccess(t *testing.T) {
+	mockInvite()
+
+	shares_repo.Add = func(share *entity.Share) errs.Error {
+		assert.Nil(t, share.TodoID)
+		assert.Equal(t, uint(projectId), *share.ProjectID)
+		return nil
+	}
+
+	sr, err := service.ShareProject(uint(userId), uint(projectId), add)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, sr)
+	assert.Equal(t, fiber.StatusCreated, sr.Status)
+}
+
+func TestShareTodoInvalidRole(t *testing.T) {
+	mockInvite()
+
+	sr, err := service.ShareTodo(uint(userId), uint(todoId), &dto.AddShare{Email: add.Email, Role: "admin"})
+
+	assert.Nil(t, sr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, err.Status())
+}
+
+func TestShareTodoUserNotFound(t *testing.T) {
+	mockInvite()
+
+	users_repo.FetchByEmail = func(email string) (*entity.User, errs.Error) {
+		return nil, errs.NewNotFoundError("user not found")
+	}
+
+	sr, err := service.ShareTodo(uint(userId), uint(todoId), add)
+
+	assert.Nil(t, sr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusNotFound, err.Status())
+}
+
+func TestShareTodoWithOwner(t *testing.T) {
+	mockInvite()
+
+	users_repo.FetchByEmail = func(email string) (*entity.User, errs.Error) {
+		return owner, nil
+	}
+
+	sr, err := service.ShareTodo(uint(userId), uint(todoId), add)
+
+	assert.Nil(t, sr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, err.Status())
+}
+
+func TestShareTodoConflict(t *testing.T) {
+	mockInvite()
+
+	shares_repo.Add = func(share *entity.Share) errs.Error {
+		return errs.NewConflictError("already shared with this user")
+	}
+
+	sr, err := service.ShareTodo(uint(userId), uint(todoId), add)
+
+	assert.Nil(t, sr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusConflict, err.Status())
+}
+
+func TestTodoSharesSuccess(t *testing.T) {
+	shares_repo.FetchByTodo = func(todoId uint) ([]*entity.Share, errs.Error) {
+		return []*entity.Share{{Model: gorm.Model{ID: 1}, UserID: invitee.ID, Role: entity.ShareViewer, User: *invitee}}, nil
+	}
+
+	sr, err := service.TodoShares(uint(todoId))
+
+	assert.Nil(t, err)
+	assert.NotNil(t, sr)
+	assert.Equal(t, fiber.StatusOK, sr.Status)
+	assert.Len(t, sr.Data, 1)
+}
+
+func TestInvitationsServerError(t *testing.T) {
+	shares_repo.FetchInvitations = func(userId uint) ([]*entity.Share, errs.Error) {
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	sr, err := service.Invitations(uint(userId))
+
+	assert.Nil(t, sr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, err.Status())
+}
+
+func TestAcceptSuccess(t *testing.T) {
+	shares_repo.Detail = func(shareId uint) (*entity.Share, errs.Error) {
+		return &entity.Share{Model: gorm.Model{ID: shareId}, UserID: uint(userId)}, nil
+	}
+
+	shares_repo.Accept = func(shareId uint) errs.Error {
+		return nil
+	}
+
+	sr, err := service.Accept(uint(userId), uint(shareId))
+
+	assert.Nil(t, err)
+	assert.NotNil(t, sr)
+	assert.Equal(t, fiber.StatusOK, sr.Status)
+}
+
+func TestAcceptSomeoneElsesInvitation(t *testing.T) {
+	shares_repo.Detail = func(shareId uint) (*entity.Share, errs.Error) {
+		return &entity.Share{Model: gorm.Model{ID: shareId}, UserID: 2}, nil
+	}
+
+	sr, err := service.Accept(uint(userId), uint(shareId))
+
+	assert.Nil(t, sr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusNotFound, err.Status())
+}
+
+func TestDeclineSuccess(t *testing.T) {
+	shares_repo.Detail = func(shareId uint) (*entity.Share, errs.Error) {
+		return &entity.Share{Model: gorm.Model{ID: shareId}, UserID: uint(userId)}, nil
+	}
+
+	shares_repo.Delete = func(shareId uint) errs.Error {
+		return nil
+	}
+
+	sr, err := service.Decline(uint(userId), uint(shareId))
+
+	assert.Nil(t, err)
+	assert.NotNil(t, sr)
+	assert.Equal(t, fiber.StatusOK, sr.Status)
+}
+
+func TestDeclineAccepted(t *testing.T) {
+	acceptedAt := time.Now()
+
+	shares_repo.Detail = func(shareId uint) (*entity.Share, errs.Error) {
+		return &entity.Share{Model: gorm.Model{ID: shareId}, UserID: uint(userId), AcceptedAt: &acceptedAt}, nil
+	}
+
+	sr, err := service.Decline(uint(userId), uint(shareId))
+
+	assert.Nil(t, sr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusConflict, err.Status())
+}
+
+func sharedTodo() {
+	id := uint(todoId)
+
+	shares_repo.Detail = func(shareId uint) (*entity.Share, errs.Error) {
+		return &entity.Share{Model: gorm.Model{ID: shareId}, TodoID: &id, UserID: invitee.ID, Role: entity.ShareViewer, User: *invitee}, nil
+	}
+
+	todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
+		return &entity.Todo{Model: gorm.Model{ID: todoId}, UserID: owner.ID}, nil
+	}
+}
+
+func TestModifyShareByOwnerSuccess(t *testing.T) {
+	sharedTodo()
+
+	shares_repo.ModifyRole = func(shareId uint, role string) errs.Error {
+		assert.Equal(t, entity.ShareEditor, role)
+		return nil
+	}
+
+	sr, err := service.Modify(owner.ID, uint(shareId), &dto.ModifyShare{Role: entity.ShareEditor})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, sr)
+	assert.Equal(t, fiber.StatusOK, sr.Status)
+	assert.Equal(t, entity.ShareEditor, sr.Data.(*dto.Share).Role)
+}
+
+func TestModifyShareByOwnerRoleSuccess(t *testing.T) {
+	sharedTodo()
+
+	shares_repo.Role = func(userId uint, todoId uint, projectId uint) (string, errs.Error) {
+		return entity.ShareOwner, nil
+	}
+
+	shares_repo.ModifyRole = func(shareId uint, role string) errs.Error {
+		return nil
+	}
+
+	sr, err := service.Modify(3, uint(shareId), &dto.ModifyShare{Role: entity.ShareEditor})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, sr)
+	assert.Equal(t, fiber.StatusOK, sr.Status)
+}
+
+func TestModifyShareByEditor(t *testing.T) {
+	sharedTodo()
+
+	shares_repo.Role = func(userId uint, todoId uint, projectId uint) (string, errs.Error) {
+		return entity.ShareEditor, nil
+	}
+
+	sr, err := service.Modify(3, uint(shareId), &dto.ModifyShare{Role: entity.ShareOwner})
+
+	assert.Nil(t, sr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusForbidden, err.Status())
+}
+
+func TestModifyShareInvalidRole(t *testing.T) {
+	sr, err := service.Modify(owner.ID, uint(shareId), &dto.ModifyShare{Role: "admin"})
+
+	assert.Nil(t, sr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, err.Status())
+}
+
+func TestDeleteShareLeaveSuccess(t *testing.T) {
+	sharedTodo()
+
+	shares_repo.Delete = func(shareId uint) errs.Error {
+		return nil
+	}
+
+	sr, err := service.Delete(invitee.ID, uint(shareId))
+
+	assert.Nil(t, err)
+	assert.NotNil(t, sr)
+	assert.Equal(t, fiber.StatusOK, sr.Status)
+}
+
+func TestDeleteShareByStranger(t *testing.T) {
+	sharedTodo()
+
+	shares_repo.Role = func(userId uint, todoId uint, projectId uint) (string, errs.Error) {
+		return "", nil
+	}
+
+	sr, err := service.Delete(3, uint(shareId))
+
+	assert.Nil(t, sr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusForbidden, err.Status())
+}
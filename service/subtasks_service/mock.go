@@ -0,0 +1,52 @@
+package subtasks_service
+
+import (
+	"todo-app/dto"
+	"todo-app/pkg/errs"
+)
+
+type serviceMock struct {
+}
+
+var (
+	Add     func(todoId uint, payload *dto.AddSubtask) (*dto.SubtaskResponse, errs.Error)
+	Delete  func(todoId uint, subtaskId uint) (*dto.SubtaskResponse, errs.Error)
+	Fetch   func(todoId uint) (*dto.SubtaskResponse, errs.Error)
+	Modify  func(todoId uint, subtaskId uint, payload *dto.ModifySubtask) (*dto.SubtaskResponse, errs.Error)
+	Reorder func(todoId uint, payload *dto.ReorderSubtasks) (*dto.SubtaskResponse, errs.Error)
+	Toggle  func(todoId uint, subtaskId uint) (*dto.SubtaskResponse, errs.Error)
+)
+
+func NewServiceMock() SubtaskService {
+	return &serviceMock{}
+}
+
+// Add implements SubtaskService.
+func (sm *serviceMock) Add(todoId uint, payload *dto.AddSubtask) (*dto.SubtaskResponse, errs.Error) {
+	return Add(todoId, payload)
+}
+
+// Delete implements SubtaskService.
+func (sm *serviceMock) Delete(todoId uint, subtaskId uint) (*dto.SubtaskResponse, errs.Error) {
+	return Delete(todoId, subtaskId)
+}
+
+// Fetch implements SubtaskService.
+func (sm *serviceMock) Fetch(todoId uint) (*dto.SubtaskResponse, errs.Error) {
+	return Fetch(todoId)
+}
+
+// Modify implements SubtaskService.
+func (sm *serviceMock) Modify(todoId uint, subtaskId uint, payload *dto.ModifySubtask) (*dto.SubtaskResponse, errs.Error) {
+	return Modify(todoId, subtaskId, payload)
+}
+
+// Reorder implements SubtaskService.
+func (sm *serviceMock) Reorder(todoId uint, payload *dto.ReorderSubtasks) (*dto.SubtaskResponse, errs.Error) {
+	return Reorder(todoId, payload)
+}
+
+// Toggle implements SubtaskService.
+func (sm *serviceMock) Toggle(todoId uint, subtaskId uint) (*dto.SubtaskResponse, errs.Error) {
+	return Toggle(todoId, subtaskId)
+}
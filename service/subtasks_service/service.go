@@ -0,0 +1,174 @@
+package subtasks_service
+
+import (
+	"todo-app/dto"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+	"todo-app/repo/subtasks_repo"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type subtaskService struct {
+	sr subtasks_repo.SubtaskRepo
+}
+
+type SubtaskService interface {
+	Add(todoId uint, payload *dto.AddSubtask) (*dto.SubtaskResponse, errs.Error)
+	Delete(todoId uint, subtaskId uint) (*dto.SubtaskResponse, errs.Error)
+	Fetch(todoId uint) (*dto.SubtaskResponse, errs.Error)
+	Modify(todoId uint, subtaskId uint, payload *dto.ModifySubtask) (*dto.SubtaskResponse, errs.Error)
+	Reorder(todoId uint, payload *dto.ReorderSubtasks) (*dto.SubtaskResponse, errs.Error)
+	Toggle(todoId uint, subtaskId uint) (*dto.SubtaskResponse, errs.Error)
+}
+
+func NewSubtaskService(subtaskRepo subtasks_repo.SubtaskRepo) SubtaskService {
+	return &subtaskService{sr: subtaskRepo}
+}
+
+// Add implements SubtaskService.
+func (ss *subtaskService) Add(todoId uint, payload *dto.AddSubtask) (*dto.SubtaskResponse, errs.Error) {
+
+	subtask := &entity.Subtask{
+		Title:  payload.Title,
+		TodoID: todoId,
+	}
+
+	err := ss.sr.Add(subtask)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.SubtaskResponse{
+		Status:  fiber.StatusCreated,
+		Message: "subtask successfully added",
+		Data:    dto.EntityToSubtask(subtask),
+	}, nil
+}
+
+// Delete implements SubtaskService.
+func (ss *subtaskService) Delete(todoId uint, subtaskId uint) (*dto.SubtaskResponse, errs.Error) {
+
+	_, err := ss.todoSubtask(todoId, subtaskId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = ss.sr.Delete(subtaskId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.SubtaskResponse{
+		Status:  fiber.StatusOK,
+		Message: "subtask successfully deleted",
+		Data:    nil,
+	}, nil
+}
+
+// Fetch implements SubtaskService.
+func (ss *subtaskService) Fetch(todoId uint) (*dto.SubtaskResponse, errs.Error) {
+
+	s, err := ss.sr.Fetch(todoId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	subtasks := []*dto.Subtask{}
+
+	for _, eachSubtask := range s {
+		subtasks = append(subtasks, dto.EntityToSubtask(eachSubtask))
+	}
+
+	return &dto.SubtaskResponse{
+		Status:  fiber.StatusOK,
+		Message: "subtasks successfully fetched",
+		Data:    subtasks,
+	}, nil
+}
+
+// Modify implements SubtaskService.
+func (ss *subtaskService) Modify(todoId uint, subtaskId uint, payload *dto.ModifySubtask) (*dto.SubtaskResponse, errs.Error) {
+
+	_, err := ss.todoSubtask(todoId, subtaskId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = ss.sr.Modify(subtaskId, payload.ModifySubtaskToEntity())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.SubtaskResponse{
+		Status:  fiber.StatusOK,
+		Message: "subtask successfully modified",
+		Data:    nil,
+	}, nil
+}
+
+// Reorder implements SubtaskService.
+func (ss *subtaskService) Reorder(todoId uint, payload *dto.ReorderSubtasks) (*dto.SubtaskResponse, errs.Error) {
+
+	if len(payload.SubtaskIds) == 0 {
+		return nil, errs.NewBadRequestError("subtask ids can't be empty")
+	}
+
+	err := ss.sr.Reorder(todoId, payload.SubtaskIds)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.SubtaskResponse{
+		Status:  fiber.StatusOK,
+		Message: "subtasks successfully reordered",
+		Data:    nil,
+	}, nil
+}
+
+// Toggle implements SubtaskService.
+func (ss *subtaskService) Toggle(todoId uint, subtaskId uint) (*dto.SubtaskResponse, errs.Error) {
+
+	subtask, err := ss.todoSubtask(todoId, subtaskId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	subtask.Done = !subtask.Done
+
+	err = ss.sr.Modify(subtaskId, subtask)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.SubtaskResponse{
+		Status:  fiber.StatusOK,
+		Message: "subtask successfully toggled",
+		Data:    dto.EntityToSubtask(subtask),
+	}, nil
+}
+
+// todoSubtask fetches the subtask only when it belongs to the authorized todo
+func (ss *subtaskService) todoSubtask(todoId uint, subtaskId uint) (*entity.Subtask, errs.Error) {
+
+	subtask, err := ss.sr.Detail(subtaskId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if subtask.TodoID != todoId {
+		return nil, errs.NewNotFoundError("subtask not found")
+	}
+
+	return subtask, nil
+}
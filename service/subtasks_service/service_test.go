@@ -0,0 +1,160 @@
+package subtasks_service_test
+
+import (
+	"testing"
+	"todo-app/dto"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+	"todo-app/repo/subtasks_repo"
+	"todo-app/service/subtasks_service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var repoMock = subtasks_repo.NewRepoMock()
+var service = subtasks_service.NewSubtaskService(repoMock)
+
+var todoId = 1
+var subtaskId = 1
+
+var add = &dto.AddSubtask{
+	Title: "buy milk",
+}
+
+var modify = &dto.ModifySubtask{
+	Title: "buy oat milk",
+	Done:  true,
+}
+
+func TestAddSubtaskSuccess(t *testing.T) {
+	subtasks_repo.Add = func(subtask *entity.Subtask) errs.Error {
+		assert.Equal(t, uint(todoId), subtask.TodoID)
+		return nil
+	}
+
+	sr, err := service.Add(uint(todoId), add)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, sr)
+	assert.Equal(t, fiber.StatusCreated, sr.Status)
+}
+
+func TestAddSubtaskServerError(t *testing.T) {
+	subtasks_repo.Add = func(subtask *entity.Subtask) errs.Error {
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	sr, err := service.Add(uint(todoId), add)
+
+	assert.Nil(t, sr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, err.Status())
+}
+
+func TestDeleteSubtaskOtherTodo(t *testing.T) {
+	subtasks_repo.Detail = func(subtaskId uint) (*entity.Subtask, errs.Error) {
+		return &entity.Subtask{TodoID: 2}, nil
+	}
+
+	sr, err := service.Delete(uint(todoId), uint(subtaskId))
+
+	assert.Nil(t, sr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusNotFound, err.Status())
+}
+
+func TestDeleteSubtaskSuccess(t *testing.T) {
+	subtasks_repo.Detail = func(subtaskId uint) (*entity.Subtask, errs.Error) {
+		return &entity.Subtask{TodoID: uint(todoId)}, nil
+	}
+
+	subtasks_repo.Delete = func(subtaskId uint) errs.Error {
+		return nil
+	}
+
+	sr, err := service.Delete(uint(todoId), uint(subtaskId))
+
+	assert.Nil(t, err)
+	assert.NotNil(t, sr)
+	assert.Equal(t, fiber.StatusOK, sr.Status)
+}
+
+func TestFetchSubtaskSuccess(t *testing.T) {
+	subtasks_repo.Fetch = func(todoId uint) ([]*entity.Subtask, errs.Error) {
+		return []*entity.Subtask{{Model: gorm.Model{ID: 1}}}, nil
+	}
+
+	sr, err := service.Fetch(uint(todoId))
+
+	assert.Nil(t, err)
+	assert.NotNil(t, sr)
+	assert.Equal(t, fiber.StatusOK, sr.Status)
+}
+
+func TestModifySubtaskNotFound(t *testing.T) {
+	subtasks_repo.Detail = func(subtaskId uint) (*entity.Subtask, errs.Error) {
+		return nil, errs.NewNotFoundError("subtask not found")
+	}
+
+	sr, err := service.Modify(uint(todoId), uint(subtaskId), modify)
+
+	assert.Nil(t, sr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusNotFound, err.Status())
+}
+
+func TestModifySubtaskSuccess(t *testing.T) {
+	subtasks_repo.Detail = func(subtaskId uint) (*entity.Subtask, errs.Error) {
+		return &entity.Subtask{TodoID: uint(todoId)}, nil
+	}
+
+	subtasks_repo.Modify = func(subtaskId uint, subtask *entity.Subtask) errs.Error {
+		assert.True(t, subtask.Done)
+		return nil
+	}
+
+	sr, err := service.Modify(uint(todoId), uint(subtaskId), modify)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, sr)
+	assert.Equal(t, fiber.StatusOK, sr.Status)
+}
+
+func TestReorderSubtaskBadRequest(t *testing.T) {
+	sr, err := service.Reorder(uint(todoId), &dto.ReorderSubtasks{})
+
+	assert.Nil(t, sr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, err.Status())
+}
+
+func TestReorderSubtaskSuccess(t *testing.T) {
+	subtasks_repo.Reorder = func(todoId uint, subtaskIds []uint) errs.Error {
+		return nil
+	}
+
+	sr, err := service.Reorder(uint(todoId), &dto.ReorderSubtasks{SubtaskIds: []uint{2, 1}})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, sr)
+	assert.Equal(t, fiber.StatusOK, sr.Status)
+}
+
+func TestToggleSubtaskSuccess(t *testing.T) {
+	subtasks_repo.Detail = func(subtaskId uint) (*entity.Subtask, errs.Error) {
+		return &entity.Subtask{TodoID: uint(todoId), Done: true}, nil
+	}
+
+	subtasks_repo.Modify = func(subtaskId uint, subtask *entity.Subtask) errs.Error {
+		assert.False(t, subtask.Done)
+		return nil
+	}
+
+	sr, err := service.Toggle(uint(todoId), uint(subtaskId))
+
+	assert.Nil(t, err)
+	assert.NotNil(t, sr)
+	assert.Equal(t, fiber.StatusOK, sr.Status)
+}
@@ -0,0 +1,46 @@
+package tags_service
+
+import (
+	"todo-app/dto"
+	"todo-app/pkg/errs"
+)
+
+type serviceMock struct {
+}
+
+var (
+	Add    func(userId uint, payload *dto.AddTag) (*dto.TagResponse, errs.Error)
+	Delete func(tagId uint) (*dto.TagResponse, errs.Error)
+	Detail func(tagId uint) (*dto.TagResponse, errs.Error)
+	Fetch  func(userId uint) (*dto.TagResponse, errs.Error)
+	Modify func(tagId uint, payload *dto.ModifyTag) (*dto.TagResponse, errs.Error)
+)
+
+func NewServiceMock() TagService {
+	return &serviceMock{}
+}
+
+// Add implements TagService.
+func (sm *serviceMock) Add(userId uint, payload *dto.AddTag) (*dto.TagResponse, errs.Error) {
+	return Add(userId, payload)
+}
+
+// Delete implements TagService.
+func (sm *serviceMock) Delete(tagId uint) (*dto.TagResponse, errs.Error) {
+	return Delete(tagId)
+}
+
+// Detail implements TagService.
+func (sm *serviceMock) Detail(tagId uint) (*dto.TagResponse, errs.Error) {
+	return Detail(tagId)
+}
+
+// Fetch implements TagService.
+func (sm *serviceMock) Fetch(userId uint) (*dto.TagResponse, errs.Error) {
+	return Fetch(userId)
+}
+
+// Modify implements TagService.
+func (sm *serviceMock) Modify(tagId uint, payload *dto.ModifyTag) (*dto.TagResponse, errs.Error) {
+	return Modify(tagId, payload)
+}
@@ -0,0 +1,126 @@
+package tags_service
+
+import (
+	"todo-app/dto"
+	"todo-app/pkg/errs"
+	"todo-app/repo/tags_repo"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type tagService struct {
+	tgr tags_repo.TagRepo
+}
+
+type TagService interface {
+	Add(userId uint, payload *dto.AddTag) (*dto.TagResponse, errs.Error)
+	Delete(tagId uint) (*dto.TagResponse, errs.Error)
+	Detail(tagId uint) (*dto.TagResponse, errs.Error)
+	Fetch(userId uint) (*dto.TagResponse, errs.Error)
+	Modify(tagId uint, payload *dto.ModifyTag) (*dto.TagResponse, errs.Error)
+}
+
+func NewTagService(tagRepo tags_repo.TagRepo) TagService {
+	return &tagService{tgr: tagRepo}
+}
+
+// Add implements TagService.
+func (ts *tagService) Add(userId uint, payload *dto.AddTag) (*dto.TagResponse, errs.Error) {
+
+	tag := payload.AddTagToEntity()
+	tag.UserID = userId
+
+	err := ts.tgr.Add(tag)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.TagResponse{
+		Status:  fiber.StatusCreated,
+		Message: "tag successfully added",
+		Data:    dto.EntityToTag(tag),
+	}, nil
+}
+
+// Delete implements TagService.
+func (ts *tagService) Delete(tagId uint) (*dto.TagResponse, errs.Error) {
+
+	_, err := ts.tgr.Detail(tagId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = ts.tgr.Delete(tagId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.TagResponse{
+		Status:  fiber.StatusOK,
+		Message: "tag successfully deleted",
+		Data:    nil,
+	}, nil
+}
+
+// Detail implements TagService.
+func (ts *tagService) Detail(tagId uint) (*dto.TagResponse, errs.Error) {
+
+	tag, err := ts.tgr.Detail(tagId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.TagResponse{
+		Status:  fiber.StatusOK,
+		Message: "tag successfully fetched",
+		Data:    dto.EntityToTag(tag),
+	}, nil
+}
+
+// Fetch implements TagService.
+func (ts *tagService) Fetch(userId uint) (*dto.TagResponse, errs.Error) {
+
+	t, err := ts.tgr.Fetch(userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tags := []*dto.Tag{}
+
+	for _, eachTag := range t {
+		tags = append(tags, dto.EntityToTag(eachTag))
+	}
+
+	return &dto.TagResponse{
+		Status:  fiber.StatusOK,
+		Message: "tags successfully fetched",
+		Data:    tags,
+	}, nil
+}
+
+// Modify implements TagService.
+func (ts *tagService) Modify(tagId uint, payload *dto.ModifyTag) (*dto.TagResponse, errs.Error) {
+
+	_, err := ts.tgr.Detail(tagId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = ts.tgr.Modify(tagId, payload.ModifyTagToEntity())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.TagResponse{
+		Status:  fiber.StatusOK,
+		Message: "tag successfully modified",
+		Data:    nil,
+	}, nil
+}
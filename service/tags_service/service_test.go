@@ -0,0 +1,147 @@
+package tags_service_test
+
+import (
+	"testing"
+	"todo-app/dto"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+	"todo-app/repo/tags_repo"
+	"todo-app/service/tags_service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var repoMock = tags_repo.NewRepoMock()
+var service = tags_service.NewTagService(repoMock)
+
+var tagId = 1
+var userId = 1
+
+var add = &dto.AddTag{
+	Name:  "work",
+	Color: "#ff0000",
+}
+
+var modify = &dto.ModifyTag{
+	Name:  "home",
+	Color: "#00ff00",
+}
+
+func TestAddTagSuccess(t *testing.T) {
+	tags_repo.Add = func(tag *entity.Tag) errs.Error {
+		assert.Equal(t, uint(userId), tag.UserID)
+		return nil
+	}
+
+	tr, err := service.Add(uint(userId), add)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.Equal(t, fiber.StatusCreated, tr.Status)
+}
+
+func TestAddTagConflict(t *testing.T) {
+	tags_repo.Add = func(tag *entity.Tag) errs.Error {
+		return errs.NewConflictError("tag name has been used")
+	}
+
+	tr, err := service.Add(uint(userId), add)
+
+	assert.Nil(t, tr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusConflict, err.Status())
+}
+
+func TestDeleteTagNotFound(t *testing.T) {
+	tags_repo.Detail = func(tagId uint) (*entity.Tag, errs.Error) {
+		return nil, errs.NewNotFoundError("tag not found")
+	}
+
+	tr, err := service.Delete(uint(tagId))
+
+	assert.Nil(t, tr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusNotFound, err.Status())
+}
+
+func TestDeleteTagSuccess(t *testing.T) {
+	tags_repo.Detail = func(tagId uint) (*entity.Tag, errs.Error) {
+		return &entity.Tag{}, nil
+	}
+
+	tags_repo.Delete = func(tagId uint) errs.Error {
+		return nil
+	}
+
+	tr, err := service.Delete(uint(tagId))
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.Equal(t, fiber.StatusOK, tr.Status)
+}
+
+func TestDetailTagSuccess(t *testing.T) {
+	tags_repo.Detail = func(tagId uint) (*entity.Tag, errs.Error) {
+		return &entity.Tag{Model: gorm.Model{ID: 1}, Name: "work"}, nil
+	}
+
+	tr, err := service.Detail(uint(tagId))
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.Equal(t, fiber.StatusOK, tr.Status)
+}
+
+func TestFetchTagServerError(t *testing.T) {
+	tags_repo.Fetch = func(userId uint) ([]*entity.Tag, errs.Error) {
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	tr, err := service.Fetch(uint(userId))
+
+	assert.Nil(t, tr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, err.Status())
+}
+
+func TestFetchTagSuccess(t *testing.T) {
+	tags_repo.Fetch = func(userId uint) ([]*entity.Tag, errs.Error) {
+		return []*entity.Tag{{Model: gorm.Model{ID: 1}, Name: "work"}}, nil
+	}
+
+	tr, err := service.Fetch(uint(userId))
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.Equal(t, fiber.StatusOK, tr.Status)
+}
+
+func TestModifyTagNotFound(t *testing.T) {
+	tags_repo.Detail = func(tagId uint) (*entity.Tag, errs.Error) {
+		return nil, errs.NewNotFoundError("tag not found")
+	}
+
+	tr, err := service.Modify(uint(tagId), modify)
+
+	assert.Nil(t, tr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusNotFound, err.Status())
+}
+
+func TestModifyTagSuccess(t *testing.T) {
+	tags_repo.Detail = func(tagId uint) (*entity.Tag, errs.Error) {
+		return &entity.Tag{}, nil
+	}
+
+	tags_repo.Modify = func(tagId uint, tag *entity.Tag) errs.Error {
+		return nil
+	}
+
+	tr, err := service.Modify(uint(tagId), modify)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.Equal(t, fiber.StatusOK, tr.Status)
+}
@@ -0,0 +1,98 @@
+package todos_service
+
+import (
+	"todo-app/dto"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AssignHook is notified when a user assigns a todo to someone else.
+type AssignHook func(todo *entity.Todo, assignee *entity.User, assigner *entity.User)
+
+// Assign implements TodoService.
+func (ts *todoService) Assign(userId uint, todoId uint, payload *dto.AssignTodo) (*dto.TodoResponse, errs.Error) {
+
+	todo, err := ts.tr.Detail(todoId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	assignee, err := ts.ur.FetchById(payload.UserId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ts.assignable(todo, assignee.ID); err != nil {
+		return nil, err
+	}
+
+	reassigned := todo.AssigneeID == nil || *todo.AssigneeID != assignee.ID
+
+	if err := ts.tr.Assign(todoId, &assignee.ID); err != nil {
+		return nil, err
+	}
+
+	todo.AssigneeID = &assignee.ID
+
+	if reassigned && assignee.ID != userId && ts.onAssign != nil {
+		assigner, err := ts.ur.FetchById(userId)
+
+		if err != nil {
+			return nil, err
+		}
+
+		go ts.onAssign(todo, assignee, assigner)
+	}
+
+	return &dto.TodoResponse{
+		Status:  fiber.StatusOK,
+		Message: "todo successfully assigned",
+		Data:    dto.EntityToTodo(todo),
+	}, nil
+}
+
+// Unassign implements TodoService.
+func (ts *todoService) Unassign(todoId uint) (*dto.TodoResponse, errs.Error) {
+
+	todo, err := ts.tr.Detail(todoId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ts.tr.Assign(todoId, nil); err != nil {
+		return nil, err
+	}
+
+	todo.AssigneeID = nil
+
+	return &dto.TodoResponse{
+		Status:  fiber.StatusOK,
+		Message: "todo successfully unassigned",
+		Data:    dto.EntityToTodo(todo),
+	}, nil
+}
+
+// assignable makes sure the user can see the todo, as a member of its
+// workspace or else as its owner or through an accepted share.
+func (ts *todoService) assignable(todo *entity.Todo, userId uint) errs.Error {
+
+	access, err := ts.shr.Access(userId, todo)
+
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case access.Visible():
+		return nil
+	case todo.WorkspaceID != nil:
+		return errs.NewBadRequestError("assignee must be a member of the workspace")
+	}
+
+	return errs.NewBadRequestError("assignee must have access to the todo")
+}
@@ -0,0 +1,220 @@
+package todos_service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+	"todo-app/dto"
+	"todo-app/pkg/errs"
+	"todo-app/repo/todos_repo"
+	"unicode"
+)
+
+const (
+	defaultLimit = 50
+	maxLimit     = 100
+)
+
+// todoFilter turns the query parameters of GET /todos into a repository
+// filter, dates without a time are read in the query timezone.
+func todoFilter(userId uint, query *dto.TodoQuery) (*todos_repo.TodoFilter, errs.Error) {
+
+	timeZone := query.TimeZone
+
+	if timeZone == "" {
+		timeZone = "UTC"
+	}
+
+	loc, locErr := time.LoadLocation(timeZone)
+
+	if locErr != nil {
+		return nil, errs.NewBadRequestError("invalid timezone")
+	}
+
+	filter := &todos_repo.TodoFilter{
+		UserID: userId,
+		Search: query.Search,
+		TagIds: query.Tags,
+		Sort:   query.Sort,
+		Limit:  query.Limit,
+	}
+
+	if len(query.Tags) > 0 {
+		switch query.TagMode {
+		case "", "any":
+		case "all":
+			filter.MatchAllTags = true
+		default:
+			return nil, errs.NewBadRequestError("tag_mode must be one of any or all")
+		}
+	}
+
+	switch query.Status {
+	case "":
+	case "done", "open":
+		done := query.Status == "done"
+		filter.Status = &done
+	default:
+		return nil, errs.NewBadRequestError("status must be one of done or open")
+	}
+
+	switch query.Assignee {
+	case "":
+	case "me":
+		filter.AssigneeID = userId
+	default:
+		return nil, errs.NewBadRequestError("assignee must be me")
+	}
+
+	now := time.Now().In(loc)
+	startOfToday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	switch query.Due {
+	case "":
+	case "overdue":
+		filter.OverdueAt = &now
+	case "today":
+		endOfToday := startOfToday.AddDate(0, 0, 1)
+		filter.DueFrom, filter.DueTo = &startOfToday, &endOfToday
+	case "week":
+		// weeks start on monday, so sunday is the last day of the week
+		daysLeft := (7 - int(now.Weekday())) % 7
+		endOfWeek := startOfToday.AddDate(0, 0, daysLeft+1)
+		filter.DueFrom, filter.DueTo = &startOfToday, &endOfWeek
+	default:
+		return nil, errs.NewBadRequestError("due must be one of overdue, today or week")
+	}
+
+	bounds := []struct {
+		value string
+		end   bool
+		field **time.Time
+	}{
+		{query.CreatedFrom, false, &filter.CreatedFrom},
+		{query.CreatedTo, true, &filter.CreatedTo},
+		{query.UpdatedFrom, false, &filter.UpdatedFrom},
+		{query.UpdatedTo, true, &filter.UpdatedTo},
+	}
+
+	for _, bound := range bounds {
+		if bound.value == "" {
+			continue
+		}
+
+		instant, err := parseBound(bound.value, bound.end, loc)
+
+		if err != nil {
+			return nil, err
+		}
+
+		*bound.field = instant
+	}
+
+	// due views keep listing the closest due date first
+	if filter.Sort == "" && query.Due != "" {
+		filter.Sort = "due_at"
+	}
+
+	switch filter.Sort {
+	case "":
+		filter.Sort = "position"
+	case "position", "priority", "due_at", "created_at", "updated_at":
+	default:
+		return nil, errs.NewBadRequestError("sort must be one of position, priority, due_at, created_at or updated_at")
+	}
+
+	switch query.Order {
+	case "":
+		filter.Descending = filter.Sort == "priority"
+	case "asc", "desc":
+		filter.Descending = query.Order == "desc"
+	default:
+		return nil, errs.NewBadRequestError("order must be one of asc or desc")
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = defaultLimit
+	}
+
+	if filter.Limit < 0 || filter.Limit > maxLimit {
+		return nil, errs.NewBadRequestError("limit must be between 1 and 100")
+	}
+
+	if query.Cursor != "" {
+		cursor, err := decodeCursor(query.Cursor)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if cursor.Sort != filter.Sort || cursor.Descending != filter.Descending {
+			return nil, errs.NewBadRequestError("cursor doesn't match the sort")
+		}
+
+		filter.After = cursor
+	}
+
+	return filter, nil
+}
+
+// parseBound reads an RFC 3339 instant or a 2006-01-02 date, an end date
+// includes the whole day.
+func parseBound(value string, end bool, loc *time.Location) (*time.Time, errs.Error) {
+
+	if instant, err := time.Parse(time.RFC3339, value); err == nil {
+		return &instant, nil
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", value, loc)
+
+	if err != nil {
+		return nil, errs.NewBadRequestError("date filters must be in 2006-01-02 or RFC 3339 format")
+	}
+
+	if end {
+		date = date.AddDate(0, 0, 1)
+	}
+
+	return &date, nil
+}
+
+// prefixQuery turns free text into a tsquery matching every word as a
+// prefix, "pay re" becomes "pay:* & re:*". Anything but letters and digits
+// is dropped so the text can't inject tsquery operators.
+func prefixQuery(text string) string {
+
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for i := range words {
+		words[i] += ":*"
+	}
+
+	return strings.Join(words, " & ")
+}
+
+func encodeCursor(cursor *todos_repo.TodoCursor) string {
+
+	b, _ := json.Marshal(cursor)
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(value string) (*todos_repo.TodoCursor, errs.Error) {
+
+	cursor := &todos_repo.TodoCursor{}
+
+	b, err := base64.RawURLEncoding.DecodeString(value)
+
+	if err != nil {
+		return nil, errs.NewBadRequestError("invalid cursor")
+	}
+
+	if err := json.Unmarshal(b, cursor); err != nil {
+		return nil, errs.NewBadRequestError("invalid cursor")
+	}
+
+	return cursor, nil
+}
@@ -9,11 +9,22 @@ type serviceMock struct {
 }
 
 var (
-	Add    func(userId uint, payload *dto.AddTodo) (*dto.TodoResponse, errs.Error)
-	Delete func(todoId uint) (*dto.TodoResponse, errs.Error)
-	Detail func(todoId uint) (*dto.TodoResponse, errs.Error)
-	Fetch  func(userId uint) (*dto.TodoResponse, errs.Error)
-	Modify func(todoId uint, payload *dto.ModifyTodo) (*dto.TodoResponse, errs.Error)
+	Add          func(userId uint, workspaceId uint, payload *dto.AddTodo) (*dto.TodoResponse, errs.Error)
+	Delete       func(todoId uint) (*dto.TodoResponse, errs.Error)
+	Detail       func(todoId uint) (*dto.TodoResponse, errs.Error)
+	Fetch        func(userId uint, workspaceId uint, query *dto.TodoQuery) (*dto.TodoResponse, errs.Error)
+	Modify       func(todoId uint, payload *dto.ModifyTodo) (*dto.TodoResponse, errs.Error)
+	ModifyStatus func(todoId uint, payload *dto.ModifyStatus) (*dto.TodoResponse, errs.Error)
+	Assign       func(userId uint, todoId uint, payload *dto.AssignTodo) (*dto.TodoResponse, errs.Error)
+	Unassign     func(todoId uint) (*dto.TodoResponse, errs.Error)
+	Reorder      func(userId uint, payload *dto.ReorderTodos) (*dto.TodoResponse, errs.Error)
+	Search       func(userId uint, workspaceId uint, query *dto.SearchQuery) (*dto.TodoResponse, errs.Error)
+	Skip         func(todoId uint) (*dto.TodoResponse, errs.Error)
+	Trash        func(userId uint, workspaceId uint) (*dto.TodoResponse, errs.Error)
+	Restore      func(todoId uint) (*dto.TodoResponse, errs.Error)
+	Purge        func(todoId uint) (*dto.TodoResponse, errs.Error)
+	PurgeTrash   func(retentionDays int) (int64, errs.Error)
+	Bulk         func(userId uint, payload *dto.BulkTodos) (*dto.TodoResponse, errs.Error)
 )
 
 func NewServiceMock() TodoService {
@@ -21,8 +32,8 @@ func NewServiceMock() TodoService {
 }
 
 // Add implements TodoService.
-func (sm *serviceMock) Add(userId uint, payload *dto.AddTodo) (*dto.TodoResponse, errs.Error) {
-	return Add(userId, payload)
+func (sm *serviceMock) Add(userId uint, workspaceId uint, payload *dto.AddTodo) (*dto.TodoResponse, errs.Error) {
+	return Add(userId, workspaceId, payload)
 }
 
 // Delete implements TodoService.
@@ -36,11 +47,66 @@ func (sm *serviceMock) Detail(todoId uint) (*dto.TodoResponse, errs.Error) {
 }
 
 // Fetch implements TodoService.
-func (sm *serviceMock) Fetch(userId uint) (*dto.TodoResponse, errs.Error) {
-	return Fetch(userId)
+func (sm *serviceMock) Fetch(userId uint, workspaceId uint, query *dto.TodoQuery) (*dto.TodoResponse, errs.Error) {
+	return Fetch(userId, workspaceId, query)
 }
 
 // Modify implements TodoService.
 func (sm *serviceMock) Modify(todoId uint, payload *dto.ModifyTodo) (*dto.TodoResponse, errs.Error) {
 	return Modify(todoId, payload)
 }
+
+// ModifyStatus implements TodoService.
+func (sm *serviceMock) ModifyStatus(todoId uint, payload *dto.ModifyStatus) (*dto.TodoResponse, errs.Error) {
+	return ModifyStatus(todoId, payload)
+}
+
+// Assign implements TodoService.
+func (sm *serviceMock) Assign(userId uint, todoId uint, payload *dto.AssignTodo) (*dto.TodoResponse, errs.Error) {
+	return Assign(userId, todoId, payload)
+}
+
+// Unassign implements TodoService.
+func (sm *serviceMock) Unassign(todoId uint) (*dto.TodoResponse, errs.Error) {
+	return Unassign(todoId)
+}
+
+// Reorder implements TodoService.
+func (sm *serviceMock) Reorder(userId uint, payload *dto.ReorderTodos) (*dto.TodoResponse, errs.Error) {
+	return Reorder(userId, payload)
+}
+
+// Search implements TodoService.
+func (sm *serviceMock) Search(userId uint, workspaceId uint, query *dto.SearchQuery) (*dto.TodoResponse, errs.Error) {
+	return Search(userId, workspaceId, query)
+}
+
+// Skip implements TodoService.
+func (sm *serviceMock) Skip(todoId uint) (*dto.TodoResponse, errs.Error) {
+	return Skip(todoId)
+}
+
+// Trash implements TodoService.
+func (sm *serviceMock) Trash(userId uint, workspaceId uint) (*dto.TodoResponse, errs.Error) {
+	return Trash(userId, workspaceId)
+}
+
+// Restore implements TodoService.
+func (sm *serviceMock) Restore(todoId uint) (*dto.TodoResponse, errs.Error) {
+	return Restore(todoId)
+}
+
+// Purge implements TodoService.
+func (sm *serviceMock) Purge(todoId uint) (*dto.TodoResponse, errs.Error) {
+	return Purge(todoId)
+}
+
+// PurgeTrash implements TodoService.
+func (sm *serviceMock) PurgeTrash(retentionDays int) (int64, errs.Error) {
+	return PurgeTrash(retentionDays)
+}
+
+// Bulk implements TodoService.
+func (sm *serviceMock) Bulk(userId uint, payload *dto.BulkTodos) (*dto.TodoResponse, errs.Error) {
+	return Bulk(userId, payload)
+}
@@ -1,37 +1,110 @@
 package todos_service
 
 import (
+	"fmt"
+	"time"
 	"todo-app/dto"
 	"todo-app/entity"
 	"todo-app/pkg/errs"
+	"todo-app/pkg/storage"
+	"todo-app/repo/projects_repo"
+	"todo-app/repo/shares_repo"
+	"todo-app/repo/tags_repo"
 	"todo-app/repo/todos_repo"
+	"todo-app/repo/users_repo"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
 )
 
 type todoService struct {
-	tr todos_repo.TodoRepo
+	tr       todos_repo.TodoRepo
+	tgr      tags_repo.TagRepo
+	pr       projects_repo.ProjectRepo
+	shr      shares_repo.SharesRepo
+	ur       users_repo.UsersRepo
+	blobs    storage.Storage
+	onAssign AssignHook
 }
 
 type TodoService interface {
-	Add(userId uint, payload *dto.AddTodo) (*dto.TodoResponse, errs.Error)
+	Add(userId uint, workspaceId uint, payload *dto.AddTodo) (*dto.TodoResponse, errs.Error)
 	Delete(todoId uint) (*dto.TodoResponse, errs.Error)
 	Detail(todoId uint) (*dto.TodoResponse, errs.Error)
-	Fetch(userId uint) (*dto.TodoResponse, errs.Error)
+	Fetch(userId uint, workspaceId uint, query *dto.TodoQuery) (*dto.TodoResponse, errs.Error)
 	Modify(todoId uint, payload *dto.ModifyTodo) (*dto.TodoResponse, errs.Error)
+	ModifyStatus(todoId uint, payload *dto.ModifyStatus) (*dto.TodoResponse, errs.Error)
+	Assign(userId uint, todoId uint, payload *dto.AssignTodo) (*dto.TodoResponse, errs.Error)
+	Unassign(todoId uint) (*dto.TodoResponse, errs.Error)
+	Reorder(userId uint, payload *dto.ReorderTodos) (*dto.TodoResponse, errs.Error)
+	Search(userId uint, workspaceId uint, query *dto.SearchQuery) (*dto.TodoResponse, errs.Error)
+	Skip(todoId uint) (*dto.TodoResponse, errs.Error)
+	Trash(userId uint, workspaceId uint) (*dto.TodoResponse, errs.Error)
+	Restore(todoId uint) (*dto.TodoResponse, errs.Error)
+	Purge(todoId uint) (*dto.TodoResponse, errs.Error)
+	PurgeTrash(retentionDays int) (int64, errs.Error)
+	Bulk(userId uint, payload *dto.BulkTodos) (*dto.TodoResponse, errs.Error)
 }
 
-func NewTodoService(todoRepo todos_repo.TodoRepo) TodoService {
-	return &todoService{tr: todoRepo}
+func NewTodoService(todoRepo todos_repo.TodoRepo, tagRepo tags_repo.TagRepo, projectRepo projects_repo.ProjectRepo, shareRepo shares_repo.SharesRepo, userRepo users_repo.UsersRepo, blobs storage.Storage, onAssign AssignHook) TodoService {
+	return &todoService{tr: todoRepo, tgr: tagRepo, pr: projectRepo, shr: shareRepo, ur: userRepo, blobs: blobs, onAssign: onAssign}
 }
 
 // Add implements TodoService.
-func (ts *todoService) Add(userId uint, payload *dto.AddTodo) (*dto.TodoResponse, errs.Error) {
+func (ts *todoService) Add(userId uint, workspaceId uint, payload *dto.AddTodo) (*dto.TodoResponse, errs.Error) {
 
-	err := ts.tr.Add(&entity.Todo{
+	todo := &entity.Todo{
 		Todos:  payload.Todos,
 		UserID: userId,
-	})
+	}
+
+	if workspaceId != 0 {
+		todo.WorkspaceID = &workspaceId
+	}
+
+	if payload.DueDate != "" {
+		if err := todo.SetDue(payload.DueDate, payload.DueTime, payload.TimeZone); err != nil {
+			return nil, err
+		}
+	}
+
+	if payload.Priority != "" {
+		priority, err := entity.ParsePriority(payload.Priority)
+
+		if err != nil {
+			return nil, err
+		}
+
+		todo.Priority = priority
+	}
+
+	if len(payload.TagIds) > 0 {
+		tags, err := ts.userTags(userId, payload.TagIds)
+
+		if err != nil {
+			return nil, err
+		}
+
+		todo.Tags = tags
+	}
+
+	if payload.ProjectId != nil && *payload.ProjectId != 0 {
+		if err := ts.userProject(userId, workspaceId, *payload.ProjectId); err != nil {
+			return nil, err
+		}
+
+		todo.ProjectID = payload.ProjectId
+	}
+
+	if err := todo.SetRecurrence(payload.Recurrence, payload.RepeatFromCompletion); err != nil {
+		return nil, err
+	}
+
+	if todo.Recurrence != "" && todo.DueAt == nil {
+		return nil, errs.NewBadRequestError("recurring todo needs a due date")
+	}
+
+	err := ts.tr.Add(todo)
 
 	if err != nil {
 		return nil, err
@@ -78,56 +151,150 @@ func (ts *todoService) Detail(todoId uint) (*dto.TodoResponse, errs.Error) {
 	return &dto.TodoResponse{
 		Status:  fiber.StatusOK,
 		Message: "get todo with detail successfully fetched",
-		Data: &dto.Todo{
-			Id:        todo.ID,
-			Todos:     todo.Todos,
-			Status:    todo.Status,
-			CreatedAt: todo.CreatedAt,
-			UpdatedAt: todo.UpdatedAt,
-		},
+		Data:    dto.EntityToTodo(todo),
 	}, nil
 }
 
 // Fetch implements TodoService.
-func (ts *todoService) Fetch(userId uint) (*dto.TodoResponse, errs.Error) {
+func (ts *todoService) Fetch(userId uint, workspaceId uint, query *dto.TodoQuery) (*dto.TodoResponse, errs.Error) {
 
-	t, err := ts.tr.Fetch(userId)
+	filter, err := todoFilter(userId, query)
 
 	if err != nil {
 		return nil, err
 	}
 
+	filter.WorkspaceID = workspaceId
+
+	limit := filter.Limit
+
+	// one more todo is fetched to know whether there is a next page
+	filter.Limit++
+
+	t, err := ts.tr.Fetch(filter)
+
+	if err != nil {
+		return nil, err
+	}
+
+	nextCursor := ""
+
+	if len(t) > limit {
+		t = t[:limit]
+		nextCursor = encodeCursor(todos_repo.NewTodoCursor(filter.Sort, filter.Descending, t[limit-1]))
+	}
+
 	todos := []*dto.Todo{}
 
 	for _, eachTodo := range t {
-		todo := &dto.Todo{
-			Id:        eachTodo.ID,
-			Todos:     eachTodo.Todos,
-			Status:    eachTodo.Status,
-			CreatedAt: eachTodo.CreatedAt,
-			UpdatedAt: eachTodo.UpdatedAt,
-		}
-
+		todo := dto.EntityToTodo(eachTodo)
+		todo.Shared = eachTodo.WorkspaceID == nil && eachTodo.UserID != userId
 		todos = append(todos, todo)
 	}
 
 	return &dto.TodoResponse{
-		Status:  fiber.StatusOK,
-		Message: "todos successfully fetched",
-		Data:    todos,
+		Status:     fiber.StatusOK,
+		Message:    "todos successfully fetched",
+		Data:       todos,
+		NextCursor: &nextCursor,
 	}, nil
 }
 
 // Modify implements TodoService.
 func (ts *todoService) Modify(todoId uint, payload *dto.ModifyTodo) (*dto.TodoResponse, errs.Error) {
 
-	_, err := ts.tr.Detail(todoId)
+	t, err := ts.tr.Detail(todoId)
 
 	if err != nil {
 		return nil, err
 	}
 
-	err = ts.tr.Modify(todoId, payload.ModifyTodoToEntity())
+	todo := payload.ModifyTodoToEntity()
+	todo.DueAt, todo.DueAllDay, todo.TimeZone = t.DueAt, t.DueAllDay, t.TimeZone
+	todo.Priority, todo.ProjectID = t.Priority, t.ProjectID
+	todo.Recurrence, todo.RepeatFromCompletion, todo.Occurrence = t.Recurrence, t.RepeatFromCompletion, t.Occurrence
+
+	// due_date omitted from the body leaves the due date untouched, an empty one clears it
+	if payload.DueDate != nil {
+		todo.DueAt, todo.DueAllDay, todo.TimeZone = nil, false, ""
+
+		if *payload.DueDate != "" {
+			if err := todo.SetDue(*payload.DueDate, payload.DueTime, payload.TimeZone); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if payload.Priority != "" {
+		priority, err := entity.ParsePriority(payload.Priority)
+
+		if err != nil {
+			return nil, err
+		}
+
+		todo.Priority = priority
+	}
+
+	// project_id 0 moves the todo back to the inbox
+	if payload.ProjectId != nil {
+		todo.ProjectID = nil
+
+		if *payload.ProjectId != 0 {
+			workspaceId := uint(0)
+
+			if t.WorkspaceID != nil {
+				workspaceId = *t.WorkspaceID
+			}
+
+			if err := ts.userProject(t.UserID, workspaceId, *payload.ProjectId); err != nil {
+				return nil, err
+			}
+
+			todo.ProjectID = payload.ProjectId
+		}
+	}
+
+	// recurrence omitted from the body leaves it untouched, an empty rule clears it
+	if payload.Recurrence != nil {
+		if err := todo.SetRecurrence(*payload.Recurrence, payload.RepeatFromCompletion); err != nil {
+			return nil, err
+		}
+	}
+
+	if todo.Recurrence != "" && todo.DueAt == nil {
+		return nil, errs.NewBadRequestError("recurring todo needs a due date")
+	}
+
+	changes := &todos_repo.TodoChanges{
+		CompleteSubtasks: payload.Status && payload.CompleteSubtasks,
+	}
+
+	tags := t.Tags
+
+	// tag_ids omitted from the body leaves tags untouched, an empty list clears them
+	if payload.TagIds != nil {
+		tags, err = ts.userTags(t.UserID, payload.TagIds)
+
+		if err != nil {
+			return nil, err
+		}
+
+		changes.ReplaceTags, changes.Tags = true, tags
+	}
+
+	// completing a recurring todo hands its recurrence over to the next
+	// occurrence, so reopening and completing it again doesn't spawn twice
+	if !t.Status && todo.Status {
+		todo.UserID, todo.WorkspaceID, todo.AssigneeID, todo.Subtasks = t.UserID, t.WorkspaceID, t.AssigneeID, t.Subtasks
+		changes.Next = todo.NextOccurrence(time.Now())
+
+		if changes.Next != nil {
+			changes.Next.Tags = tags
+			todo.Recurrence, todo.RepeatFromCompletion = "", false
+		}
+	}
+
+	err = ts.tr.Modify(todoId, todo, changes)
 
 	if err != nil {
 		return nil, err
@@ -139,3 +306,392 @@ func (ts *todoService) Modify(todoId uint, payload *dto.ModifyTodo) (*dto.TodoRe
 		Data:    nil,
 	}, nil
 }
+
+// ModifyStatus implements TodoService. Only the status is changed, so the
+// assignee of a todo can complete or reopen it without editing the rest.
+func (ts *todoService) ModifyStatus(todoId uint, payload *dto.ModifyStatus) (*dto.TodoResponse, errs.Error) {
+
+	todo, err := ts.tr.Detail(todoId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	changes := &todos_repo.TodoChanges{
+		CompleteSubtasks: payload.Status && payload.CompleteSubtasks,
+	}
+
+	if !todo.Status && payload.Status {
+		changes.Next = todo.NextOccurrence(time.Now())
+
+		if changes.Next != nil {
+			todo.Recurrence, todo.RepeatFromCompletion = "", false
+		}
+	}
+
+	todo.Status = payload.Status
+
+	err = ts.tr.Modify(todoId, todo, changes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.TodoResponse{
+		Status:  fiber.StatusOK,
+		Message: "todo status successfully modified",
+		Data:    dto.EntityToTodo(todo),
+	}, nil
+}
+
+// Reorder implements TodoService.
+func (ts *todoService) Reorder(userId uint, payload *dto.ReorderTodos) (*dto.TodoResponse, errs.Error) {
+
+	if len(payload.TodoIds) == 0 {
+		return nil, errs.NewBadRequestError("todo ids can't be empty")
+	}
+
+	err := ts.tr.Reorder(userId, payload.TodoIds)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.TodoResponse{
+		Status:  fiber.StatusOK,
+		Message: "todos successfully reordered",
+		Data:    nil,
+	}, nil
+}
+
+// Search implements TodoService.
+func (ts *todoService) Search(userId uint, workspaceId uint, query *dto.SearchQuery) (*dto.TodoResponse, errs.Error) {
+
+	tsQuery := prefixQuery(query.Q)
+
+	if tsQuery == "" {
+		return nil, errs.NewBadRequestError("q can't be empty")
+	}
+
+	limit := query.Limit
+
+	if limit == 0 {
+		limit = defaultLimit
+	}
+
+	if limit < 0 || limit > maxLimit {
+		return nil, errs.NewBadRequestError("limit must be between 1 and 100")
+	}
+
+	m, err := ts.tr.Search(userId, workspaceId, tsQuery, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []*dto.TodoMatch{}
+
+	for _, eachMatch := range m {
+		todo := dto.EntityToTodo(eachMatch.Todo)
+		todo.Shared = eachMatch.Todo.WorkspaceID == nil && eachMatch.Todo.UserID != userId
+		matches = append(matches, &dto.TodoMatch{
+			Todo:    todo,
+			Rank:    eachMatch.Rank,
+			Snippet: eachMatch.Snippet,
+		})
+	}
+
+	return &dto.TodoResponse{
+		Status:  fiber.StatusOK,
+		Message: "todos successfully searched",
+		Data:    matches,
+	}, nil
+}
+
+// Skip implements TodoService.
+func (ts *todoService) Skip(todoId uint) (*dto.TodoResponse, errs.Error) {
+
+	todo, err := ts.tr.Detail(todoId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if todo.Recurrence == "" {
+		return nil, errs.NewBadRequestError("todo is not recurring")
+	}
+
+	if todo.Status {
+		return nil, errs.NewBadRequestError("todo has been completed")
+	}
+
+	nextDue := todo.NextDue(time.Now())
+
+	if nextDue == nil {
+		return nil, errs.NewBadRequestError("todo has no next occurrence")
+	}
+
+	todo.DueAt = nextDue
+	todo.Occurrence++
+
+	err = ts.tr.Modify(todoId, todo, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.TodoResponse{
+		Status:  fiber.StatusOK,
+		Message: "todo occurrence successfully skipped",
+		Data:    dto.EntityToTodo(todo),
+	}, nil
+}
+
+// Trash implements TodoService.
+func (ts *todoService) Trash(userId uint, workspaceId uint) (*dto.TodoResponse, errs.Error) {
+
+	t, err := ts.tr.FetchTrash(userId, workspaceId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	todos := []*dto.Todo{}
+
+	for _, eachTodo := range t {
+		todos = append(todos, dto.EntityToTodo(eachTodo))
+	}
+
+	return &dto.TodoResponse{
+		Status:  fiber.StatusOK,
+		Message: "trashed todos successfully fetched",
+		Data:    todos,
+	}, nil
+}
+
+// Restore implements TodoService.
+func (ts *todoService) Restore(todoId uint) (*dto.TodoResponse, errs.Error) {
+
+	_, err := ts.tr.DetailTrash(todoId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = ts.tr.Restore(todoId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.TodoResponse{
+		Status:  fiber.StatusOK,
+		Message: "todo successfully restored",
+		Data:    nil,
+	}, nil
+}
+
+// Purge implements TodoService.
+func (ts *todoService) Purge(todoId uint) (*dto.TodoResponse, errs.Error) {
+
+	_, err := ts.tr.DetailTrash(todoId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := ts.tr.Purge(todoId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ts.deleteBlobs(keys)
+
+	return &dto.TodoResponse{
+		Status:  fiber.StatusOK,
+		Message: "todo successfully purged",
+		Data:    nil,
+	}, nil
+}
+
+// PurgeTrash implements TodoService. A retention below one day keeps the
+// trash forever, otherwise todos trashed a moment ago would be purged.
+func (ts *todoService) PurgeTrash(retentionDays int) (int64, errs.Error) {
+
+	if retentionDays < 1 {
+		return 0, nil
+	}
+
+	purged, keys, err := ts.tr.PurgeTrash(time.Now().AddDate(0, 0, -retentionDays))
+
+	if err != nil {
+		return 0, err
+	}
+
+	ts.deleteBlobs(keys)
+
+	return purged, nil
+}
+
+// deleteBlobs removes the files of purged attachments. Their rows are gone
+// already, so a failure only leaves an orphaned blob behind and is logged.
+func (ts *todoService) deleteBlobs(keys []string) {
+	for _, eachKey := range keys {
+		if err := ts.blobs.Delete(eachKey); err != nil {
+			log.Errorf("error while deleting attachment %s: %s", eachKey, err.Error())
+		}
+	}
+}
+
+// Bulk implements TodoService.
+func (ts *todoService) Bulk(userId uint, payload *dto.BulkTodos) (*dto.TodoResponse, errs.Error) {
+
+	todoIds := uniqueIds(payload.TodoIds)
+
+	if len(todoIds) == 0 {
+		return nil, errs.NewBadRequestError("todo ids can't be empty")
+	}
+
+	if len(todoIds) > maxLimit {
+		return nil, errs.NewBadRequestError("bulk requests are limited to 100 todos")
+	}
+
+	action := &todos_repo.BulkAction{Name: payload.Action}
+
+	switch payload.Action {
+	case todos_repo.BulkComplete, todos_repo.BulkReopen, todos_repo.BulkDelete:
+	case todos_repo.BulkMove:
+		// project_id 0 or omitted moves the todos to the inbox
+		if payload.ProjectId != nil && *payload.ProjectId != 0 {
+			if err := ts.userProject(userId, 0, *payload.ProjectId); err != nil {
+				return nil, err
+			}
+
+			action.ProjectID = payload.ProjectId
+		}
+	case todos_repo.BulkTag:
+		if _, err := ts.userTags(userId, []uint{payload.TagId}); err != nil {
+			return nil, err
+		}
+
+		action.TagID = payload.TagId
+	default:
+		return nil, errs.NewBadRequestError("action must be one of complete, reopen, delete, move or tag")
+	}
+
+	r, err := ts.tr.Bulk(userId, todoIds, action, payload.AllOrNothing)
+
+	if err != nil {
+		return nil, err
+	}
+
+	results := []*dto.BulkResult{}
+	failed := 0
+
+	for _, eachResult := range r {
+		result := &dto.BulkResult{
+			Id:      eachResult.TodoID,
+			Status:  fiber.StatusOK,
+			Message: "todo successfully processed",
+		}
+
+		if eachResult.Err != nil {
+			result.Status, result.Message = eachResult.Err.Status(), eachResult.Err.Message()
+			failed++
+		}
+
+		results = append(results, result)
+	}
+
+	// the per todo results are still returned when the whole request is rolled back
+	if failed > 0 && payload.AllOrNothing {
+		return &dto.TodoResponse{
+			Status:  fiber.StatusUnprocessableEntity,
+			Message: "no todo was processed since some of them failed",
+			Data:    results,
+		}, nil
+	}
+
+	message := "todos successfully processed"
+
+	if failed > 0 {
+		message = fmt.Sprintf("%d of %d todos failed", failed, len(results))
+	}
+
+	return &dto.TodoResponse{
+		Status:  fiber.StatusOK,
+		Message: message,
+		Data:    results,
+	}, nil
+}
+
+// userProject makes sure the project belongs to the space of the todo, the
+// workspace or else the personal space of the user
+func (ts *todoService) userProject(userId uint, workspaceId uint, projectId uint) errs.Error {
+
+	project, err := ts.pr.Detail(projectId)
+
+	if err != nil {
+		return err
+	}
+
+	// todos only move between projects of the same space
+	if workspaceId != 0 {
+		if project.WorkspaceID == nil || *project.WorkspaceID != workspaceId {
+			return errs.NewNotFoundError("project not found")
+		}
+	} else if project.UserID != userId || project.WorkspaceID != nil {
+		return errs.NewNotFoundError("project not found")
+	}
+
+	if project.Archived {
+		return errs.NewBadRequestError("project has been archived")
+	}
+
+	return nil
+}
+
+// userTags makes sure every tag id belongs to the user
+func (ts *todoService) userTags(userId uint, tagIds []uint) ([]entity.Tag, errs.Error) {
+
+	tagIds = uniqueIds(tagIds)
+
+	if len(tagIds) == 0 {
+		return []entity.Tag{}, nil
+	}
+
+	t, err := ts.tgr.FetchByIds(userId, tagIds)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(t) != len(tagIds) {
+		return nil, errs.NewNotFoundError("tag not found")
+	}
+
+	tags := []entity.Tag{}
+
+	for _, eachTag := range t {
+		tags = append(tags, *eachTag)
+	}
+
+	return tags, nil
+}
+
+// uniqueIds removes duplicated ids, keeping their first position
+func uniqueIds(ids []uint) []uint {
+
+	unique := []uint{}
+	seen := map[uint]bool{}
+
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	return unique
+}
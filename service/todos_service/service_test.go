@@ -6,7 +6,12 @@ import (
 	"todo-app/dto"
 	"todo-app/entity"
 	"todo-app/pkg/errs"
+	"todo-app/pkg/storage"
+	"todo-app/repo/projects_repo"
+	"todo-app/repo/shares_repo"
+	"todo-app/repo/tags_repo"
 	"todo-app/repo/todos_repo"
+	"todo-app/repo/users_repo"
 	"todo-app/service/todos_service"
 
 	"github.com/gofiber/fiber/v2"
@@ -15,7 +20,16 @@ import (
 )
 
 var repoMock = todos_repo.NewRepoMock()
-var service = todos_service.NewTodoService(repoMock)
+var tagRepoMock = tags_repo.NewRepoMock()
+var projectRepoMock = projects_repo.NewRepoMock()
+var shareRepoMock = shares_repo.NewRepoMock()
+var userRepoMock = users_repo.NewRepoMock()
+var storageMock = storage.NewStorageMock()
+var assignments = make(chan *entity.User, 1)
+var service = todos_service.NewTodoService(repoMock, tagRepoMock, projectRepoMock, shareRepoMock, userRepoMock, storageMock,
+	func(todo *entity.Todo, assignee *entity.User, assigner *entity.User) {
+		assignments <- assignee
+	})
 
 var todoId = 1
 var userId = 1
@@ -34,7 +48,7 @@ func TestAddTodoSuccess(t *testing.T) {
 		return nil
 	}
 
-	tr, err := service.Add(uint(todoId), add)
+	tr, err := service.Add(uint(todoId), 0, add)
 
 	assert.Nil(t, err)
 	assert.NotNil(t, tr)
@@ -46,13 +60,44 @@ func TestAddTodoServerError(t *testing.T) {
 		return errs.NewInternalServerError("something went wrong")
 	}
 
-	tr, err := service.Add(uint(todoId), add)
+	tr, err := service.Add(uint(todoId), 0, add)
 
 	assert.Nil(t, tr)
 	assert.NotNil(t, err)
 	assert.Equal(t, fiber.StatusInternalServerError, err.Status())
 }
 
+func TestAddTodoWithDueSuccess(t *testing.T) {
+	todos_repo.Add = func(todo *entity.Todo) errs.Error {
+		assert.NotNil(t, todo.DueAt)
+		assert.False(t, todo.DueAllDay)
+		assert.Equal(t, 10, todo.DueAt.Hour())
+		return nil
+	}
+
+	tr, err := service.Add(uint(userId), 0, &dto.AddTodo{
+		Todos:    "pay rent",
+		DueDate:  "2024-01-31",
+		DueTime:  "17:00",
+		TimeZone: "Asia/Jakarta",
+	})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.Equal(t, fiber.StatusCreated, tr.Status)
+}
+
+func TestAddTodoInvalidDueDate(t *testing.T) {
+	tr, err := service.Add(uint(userId), 0, &dto.AddTodo{
+		Todos:   "pay rent",
+		DueDate: "31-01-2024",
+	})
+
+	assert.Nil(t, tr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, err.Status())
+}
+
 func TestDeleteTodoDetailNotFound(t *testing.T) {
 	todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
 		return nil, errs.NewNotFoundError("todo not found")
@@ -146,11 +191,11 @@ func TestDetailTodoSuccess(t *testing.T) {
 }
 
 func TestFetchTodoServerError(t *testing.T) {
-	todos_repo.Fetch = func(userId uint) ([]*entity.Todo, errs.Error) {
+	todos_repo.Fetch = func(filter *todos_repo.TodoFilter) ([]*entity.Todo, errs.Error) {
 		return nil, errs.NewInternalServerError("something went wrong")
 	}
 
-	tr, err := service.Fetch(uint(userId))
+	tr, err := service.Fetch(uint(userId), 0, &dto.TodoQuery{})
 
 	assert.Nil(t, tr)
 	assert.NotNil(t, err)
@@ -158,7 +203,7 @@ func TestFetchTodoServerError(t *testing.T) {
 }
 
 func TestFetchTodoSuccess(t *testing.T) {
-	todos_repo.Fetch = func(userId uint) ([]*entity.Todo, errs.Error) {
+	todos_repo.Fetch = func(filter *todos_repo.TodoFilter) ([]*entity.Todo, errs.Error) {
 		return []*entity.Todo{
 			{
 				Model: gorm.Model{
@@ -170,13 +215,84 @@ func TestFetchTodoSuccess(t *testing.T) {
 		}, nil
 	}
 
-	tr, err := service.Fetch(uint(userId))
+	tr, err := service.Fetch(uint(userId), 0, &dto.TodoQuery{})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.Equal(t, fiber.StatusOK, tr.Status)
+}
+
+func TestFetchTodoSharedSuccess(t *testing.T) {
+	todos_repo.Fetch = func(filter *todos_repo.TodoFilter) ([]*entity.Todo, errs.Error) {
+		return []*entity.Todo{
+			{Model: gorm.Model{ID: 1}, UserID: uint(userId)},
+			{Model: gorm.Model{ID: 2}, UserID: 2},
+		}, nil
+	}
+
+	tr, err := service.Fetch(uint(userId), 0, &dto.TodoQuery{})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+
+	todos := tr.Data.([]*dto.Todo)
+
+	assert.False(t, todos[0].Shared)
+	assert.True(t, todos[1].Shared)
+}
+
+func TestFetchTodoOverdueSuccess(t *testing.T) {
+	todos_repo.Fetch = func(filter *todos_repo.TodoFilter) ([]*entity.Todo, errs.Error) {
+		assert.Equal(t, "due_at", filter.Sort)
+		dueAt := filter.OverdueAt.Add(-time.Hour)
+		return []*entity.Todo{
+			{
+				Model: gorm.Model{
+					ID: 1,
+				},
+				DueAt: &dueAt,
+			},
+		}, nil
+	}
+
+	tr, err := service.Fetch(uint(userId), 0, &dto.TodoQuery{Due: "overdue"})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.Equal(t, fiber.StatusOK, tr.Status)
+	assert.True(t, tr.Data.([]*dto.Todo)[0].Overdue)
+}
+
+func TestFetchTodoDueWeekSuccess(t *testing.T) {
+	todos_repo.Fetch = func(filter *todos_repo.TodoFilter) ([]*entity.Todo, errs.Error) {
+		assert.True(t, filter.DueFrom.Before(*filter.DueTo))
+		assert.Equal(t, time.Monday, filter.DueTo.Weekday())
+		return []*entity.Todo{}, nil
+	}
+
+	tr, err := service.Fetch(uint(userId), 0, &dto.TodoQuery{Due: "week", TimeZone: "Asia/Jakarta"})
 
 	assert.Nil(t, err)
 	assert.NotNil(t, tr)
 	assert.Equal(t, fiber.StatusOK, tr.Status)
 }
 
+func TestFetchTodoInvalidDue(t *testing.T) {
+	tr, err := service.Fetch(uint(userId), 0, &dto.TodoQuery{Due: "someday"})
+
+	assert.Nil(t, tr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, err.Status())
+}
+
+func TestFetchTodoInvalidTimeZone(t *testing.T) {
+	tr, err := service.Fetch(uint(userId), 0, &dto.TodoQuery{Due: "today", TimeZone: "Mars/Olympus"})
+
+	assert.Nil(t, tr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, err.Status())
+}
+
 func TestModifyTodoDetailServerError(t *testing.T) {
 
 	todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
@@ -209,7 +325,7 @@ func TestModifyTodoServerError(t *testing.T) {
 		return &entity.Todo{}, nil
 	}
 
-	todos_repo.Modify = func(todoId uint, todo *entity.Todo) errs.Error {
+	todos_repo.Modify = func(todoId uint, todo *entity.Todo, changes *todos_repo.TodoChanges) errs.Error {
 		return errs.NewInternalServerError("something went wrong")
 	}
 
@@ -226,7 +342,78 @@ func TestModifyTodoSuccess(t *testing.T) {
 		return &entity.Todo{}, nil
 	}
 
-	todos_repo.Modify = func(todoId uint, todo *entity.Todo) errs.Error {
+	todos_repo.Modify = func(todoId uint, todo *entity.Todo, changes *todos_repo.TodoChanges) errs.Error {
+		return nil
+	}
+
+	tr, err := service.Modify(uint(todoId), modify)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.Equal(t, fiber.StatusOK, tr.Status)
+}
+
+func TestAddTodoInvalidPriority(t *testing.T) {
+	tr, err := service.Add(uint(userId), 0, &dto.AddTodo{
+		Todos:    "pay rent",
+		Priority: "asap",
+	})
+
+	assert.Nil(t, tr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, err.Status())
+}
+
+func TestModifyTodoKeepPriority(t *testing.T) {
+
+	todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
+		return &entity.Todo{Priority: entity.PriorityHigh}, nil
+	}
+
+	todos_repo.Modify = func(todoId uint, todo *entity.Todo, changes *todos_repo.TodoChanges) errs.Error {
+		assert.Equal(t, entity.PriorityHigh, todo.Priority)
+		return nil
+	}
+
+	tr, err := service.Modify(uint(todoId), modify)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.Equal(t, fiber.StatusOK, tr.Status)
+}
+
+func TestModifyTodoClearDue(t *testing.T) {
+	dueAt := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
+		return &entity.Todo{DueAt: &dueAt, DueAllDay: true, TimeZone: "UTC"}, nil
+	}
+
+	todos_repo.Modify = func(todoId uint, todo *entity.Todo, changes *todos_repo.TodoChanges) errs.Error {
+		assert.Nil(t, todo.DueAt)
+		assert.False(t, todo.DueAllDay)
+		return nil
+	}
+
+	clear := ""
+
+	tr, err := service.Modify(uint(todoId), &dto.ModifyTodo{Todos: "new todos", DueDate: &clear})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.Equal(t, fiber.StatusOK, tr.Status)
+}
+
+func TestModifyTodoKeepDue(t *testing.T) {
+	dueAt := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
+		return &entity.Todo{DueAt: &dueAt, DueAllDay: true, TimeZone: "UTC"}, nil
+	}
+
+	todos_repo.Modify = func(todoId uint, todo *entity.Todo, changes *todos_repo.TodoChanges) errs.Error {
+		assert.Equal(t, &dueAt, todo.DueAt)
+		assert.True(t, todo.DueAllDay)
 		return nil
 	}
 
@@ -236,3 +423,1053 @@ func TestModifyTodoSuccess(t *testing.T) {
 	assert.NotNil(t, tr)
 	assert.Equal(t, fiber.StatusOK, tr.Status)
 }
+
+func TestReorderTodoBadRequest(t *testing.T) {
+
+	tr, err := service.Reorder(uint(userId), &dto.ReorderTodos{})
+
+	assert.Nil(t, tr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, err.Status())
+}
+
+func TestReorderTodoNotFound(t *testing.T) {
+
+	todos_repo.Reorder = func(userId uint, todoIds []uint) errs.Error {
+		return errs.NewNotFoundError("todo not found")
+	}
+
+	tr, err := service.Reorder(uint(userId), &dto.ReorderTodos{TodoIds: []uint{2, 1}})
+
+	assert.Nil(t, tr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusNotFound, err.Status())
+}
+
+func TestReorderTodoSuccess(t *testing.T) {
+
+	todos_repo.Reorder = func(userId uint, todoIds []uint) errs.Error {
+		return nil
+	}
+
+	tr, err := service.Reorder(uint(userId), &dto.ReorderTodos{TodoIds: []uint{2, 1}})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.Equal(t, fiber.StatusOK, tr.Status)
+}
+
+func TestAddTodoWithTagsSuccess(t *testing.T) {
+	tags_repo.FetchByIds = func(userId uint, tagIds []uint) ([]*entity.Tag, errs.Error) {
+		assert.Equal(t, []uint{1, 2}, tagIds)
+		return []*entity.Tag{{Model: gorm.Model{ID: 1}}, {Model: gorm.Model{ID: 2}}}, nil
+	}
+
+	todos_repo.Add = func(todo *entity.Todo) errs.Error {
+		assert.Len(t, todo.Tags, 2)
+		return nil
+	}
+
+	tr, err := service.Add(uint(userId), 0, &dto.AddTodo{
+		Todos:  "buy milk",
+		TagIds: []uint{1, 2, 2},
+	})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.Equal(t, fiber.StatusCreated, tr.Status)
+}
+
+func TestAddTodoWithOtherUserTag(t *testing.T) {
+	tags_repo.FetchByIds = func(userId uint, tagIds []uint) ([]*entity.Tag, errs.Error) {
+		return []*entity.Tag{}, nil
+	}
+
+	tr, err := service.Add(uint(userId), 0, &dto.AddTodo{
+		Todos:  "buy milk",
+		TagIds: []uint{3},
+	})
+
+	assert.Nil(t, tr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusNotFound, err.Status())
+}
+
+func TestModifyTodoClearTags(t *testing.T) {
+
+	todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
+		return &entity.Todo{UserID: uint(userId)}, nil
+	}
+
+	todos_repo.Modify = func(todoId uint, todo *entity.Todo, changes *todos_repo.TodoChanges) errs.Error {
+		assert.True(t, changes.ReplaceTags)
+		assert.Empty(t, changes.Tags)
+		return nil
+	}
+
+	tr, err := service.Modify(uint(todoId), &dto.ModifyTodo{
+		Todos:  "buy milk",
+		TagIds: []uint{},
+	})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.Equal(t, fiber.StatusOK, tr.Status)
+}
+
+func TestFetchTodoByAllTagsSuccess(t *testing.T) {
+	todos_repo.Fetch = func(filter *todos_repo.TodoFilter) ([]*entity.Todo, errs.Error) {
+		assert.Equal(t, []uint{1, 2}, filter.TagIds)
+		assert.True(t, filter.MatchAllTags)
+		return []*entity.Todo{}, nil
+	}
+
+	tr, err := service.Fetch(uint(userId), 0, &dto.TodoQuery{Tags: []uint{1, 2}, TagMode: "all"})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.Equal(t, fiber.StatusOK, tr.Status)
+}
+
+func TestFetchTodoInvalidTagMode(t *testing.T) {
+	tr, err := service.Fetch(uint(userId), 0, &dto.TodoQuery{Tags: []uint{1}, TagMode: "none"})
+
+	assert.Nil(t, tr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, err.Status())
+}
+
+func TestAddTodoWithProjectSuccess(t *testing.T) {
+	projectId := uint(1)
+
+	projects_repo.Detail = func(projectId uint) (*entity.Project, errs.Error) {
+		return &entity.Project{UserID: uint(userId)}, nil
+	}
+
+	todos_repo.Add = func(todo *entity.Todo) errs.Error {
+		assert.Equal(t, projectId, *todo.ProjectID)
+		return nil
+	}
+
+	tr, err := service.Add(uint(userId), 0, &dto.AddTodo{
+		Todos:     "buy milk",
+		ProjectId: &projectId,
+	})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.Equal(t, fiber.StatusCreated, tr.Status)
+}
+
+func TestAddTodoWithOtherUserProject(t *testing.T) {
+	projectId := uint(1)
+
+	projects_repo.Detail = func(projectId uint) (*entity.Project, errs.Error) {
+		return &entity.Project{UserID: 2}, nil
+	}
+
+	tr, err := service.Add(uint(userId), 0, &dto.AddTodo{
+		Todos:     "buy milk",
+		ProjectId: &projectId,
+	})
+
+	assert.Nil(t, tr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusNotFound, err.Status())
+}
+
+func TestModifyTodoMoveToInbox(t *testing.T) {
+	projectId := uint(1)
+	inbox := uint(0)
+
+	todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
+		return &entity.Todo{ProjectID: &projectId}, nil
+	}
+
+	todos_repo.Modify = func(todoId uint, todo *entity.Todo, changes *todos_repo.TodoChanges) errs.Error {
+		assert.Nil(t, todo.ProjectID)
+		return nil
+	}
+
+	tr, err := service.Modify(uint(todoId), &dto.ModifyTodo{
+		Todos:     "buy milk",
+		ProjectId: &inbox,
+	})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.Equal(t, fiber.StatusOK, tr.Status)
+}
+
+func TestModifyTodoCompleteSubtasks(t *testing.T) {
+	completed := false
+
+	todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
+		return &entity.Todo{}, nil
+	}
+
+	todos_repo.Modify = func(todoId uint, todo *entity.Todo, changes *todos_repo.TodoChanges) errs.Error {
+		completed = changes.CompleteSubtasks
+		return nil
+	}
+
+	tr, err := service.Modify(uint(todoId), &dto.ModifyTodo{
+		Todos:            "groceries",
+		Status:           true,
+		CompleteSubtasks: true,
+	})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.True(t, completed)
+}
+
+func TestDetailTodoProgress(t *testing.T) {
+	todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
+		return &entity.Todo{
+			Subtasks: []entity.Subtask{{Done: true}, {Done: false}, {Done: true}},
+		}, nil
+	}
+
+	tr, err := service.Detail(uint(todoId))
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.Equal(t, dto.Progress{Done: 2, Total: 3}, tr.Data.(*dto.Todo).Progress)
+}
+
+func TestAddTodoInvalidRecurrence(t *testing.T) {
+	tr, err := service.Add(uint(userId), 0, &dto.AddTodo{
+		Todos:      "pay rent",
+		DueDate:    "2024-01-01",
+		Recurrence: "FREQ=YEARLY",
+	})
+
+	assert.Nil(t, tr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, err.Status())
+}
+
+func TestAddTodoRecurrenceWithoutDue(t *testing.T) {
+	tr, err := service.Add(uint(userId), 0, &dto.AddTodo{
+		Todos:      "pay rent",
+		Recurrence: "FREQ=MONTHLY",
+	})
+
+	assert.Nil(t, tr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, err.Status())
+}
+
+func TestAddTodoWithRecurrenceSuccess(t *testing.T) {
+	todos_repo.Add = func(todo *entity.Todo) errs.Error {
+		assert.Equal(t, "FREQ=WEEKLY;BYDAY=MO,TH", todo.Recurrence)
+		return nil
+	}
+
+	tr, err := service.Add(uint(userId), 0, &dto.AddTodo{
+		Todos:      "weekly review",
+		DueDate:    "2024-01-01",
+		Recurrence: "rrule:freq=weekly;interval=1;byday=MO,TH",
+	})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.Equal(t, fiber.StatusCreated, tr.Status)
+}
+
+func TestAddTodoMonthlyRecurrenceKeepsDueDay(t *testing.T) {
+	var added *entity.Todo
+
+	todos_repo.Add = func(todo *entity.Todo) errs.Error {
+		added = todo
+		return nil
+	}
+
+	_, err := service.Add(uint(userId), 0, &dto.AddTodo{
+		Todos:      "pay rent",
+		DueDate:    "2024-01-31",
+		TimeZone:   "Asia/Jakarta",
+		Recurrence: "FREQ=MONTHLY",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "FREQ=MONTHLY;BYMONTHDAY=31", added.Recurrence)
+
+	// the 31st is clamped to february and kept for march
+	feb := added.NextOccurrence(time.Now())
+	mar := feb.NextOccurrence(time.Now())
+	apr := mar.NextOccurrence(time.Now())
+
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+
+	assert.Equal(t, time.Date(2024, 2, 29, 0, 0, 0, 0, jakarta), feb.DueAt.In(jakarta))
+	assert.Equal(t, time.Date(2024, 3, 31, 0, 0, 0, 0, jakarta), mar.DueAt.In(jakarta))
+	assert.Equal(t, time.Date(2024, 4, 30, 0, 0, 0, 0, jakarta), apr.DueAt.In(jakarta))
+}
+
+func TestModifyTodoSpawnMonthlyOccurrenceAfterShortMonth(t *testing.T) {
+	dueAt := time.Date(2023, 2, 28, 9, 0, 0, 0, time.UTC)
+	var next *entity.Todo
+
+	todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
+		return &entity.Todo{
+			DueAt:      &dueAt,
+			TimeZone:   "UTC",
+			Recurrence: "FREQ=MONTHLY;BYMONTHDAY=31",
+			Occurrence: 2,
+		}, nil
+	}
+
+	todos_repo.Modify = func(todoId uint, todo *entity.Todo, changes *todos_repo.TodoChanges) errs.Error {
+		next = changes.Next
+		return nil
+	}
+
+	_, err := service.Modify(uint(todoId), &dto.ModifyTodo{Todos: "pay rent", Status: true})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, next)
+	assert.Equal(t, time.Date(2023, 3, 31, 9, 0, 0, 0, time.UTC), *next.DueAt)
+}
+
+func TestModifyTodoSpawnWeeklyOccurrence(t *testing.T) {
+	// thursday 2024-01-04 17:00 in Jakarta
+	dueAt := time.Date(2024, 1, 4, 10, 0, 0, 0, time.UTC)
+	var next *entity.Todo
+	var modified *entity.Todo
+
+	todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
+		return &entity.Todo{
+			Todos:      "weekly review",
+			DueAt:      &dueAt,
+			TimeZone:   "Asia/Jakarta",
+			UserID:     uint(userId),
+			Recurrence: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH",
+			Occurrence: 1,
+			Tags:       []entity.Tag{{Model: gorm.Model{ID: 1}}},
+			Subtasks:   []entity.Subtask{{Model: gorm.Model{ID: 1}, Title: "inbox zero", Done: true}},
+		}, nil
+	}
+
+	todos_repo.Modify = func(todoId uint, todo *entity.Todo, changes *todos_repo.TodoChanges) errs.Error {
+		modified, next = todo, changes.Next
+		return nil
+	}
+
+	tr, err := service.Modify(uint(todoId), &dto.ModifyTodo{Todos: "weekly review", Status: true})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.Equal(t, "", modified.Recurrence)
+	assert.NotNil(t, next)
+	assert.Equal(t, time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC), *next.DueAt)
+	assert.Equal(t, "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", next.Recurrence)
+	assert.Equal(t, 2, next.Occurrence)
+	assert.False(t, next.Status)
+	assert.Len(t, next.Tags, 1)
+	assert.Equal(t, []entity.Subtask{{Title: "inbox zero"}}, next.Subtasks)
+}
+
+func TestModifyTodoSpawnMonthlyOccurrence(t *testing.T) {
+	dueAt := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	var next *entity.Todo
+
+	todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
+		return &entity.Todo{
+			DueAt:      &dueAt,
+			DueAllDay:  true,
+			TimeZone:   "UTC",
+			Recurrence: "FREQ=MONTHLY;BYMONTHDAY=-1",
+			Occurrence: 1,
+		}, nil
+	}
+
+	todos_repo.Modify = func(todoId uint, todo *entity.Todo, changes *todos_repo.TodoChanges) errs.Error {
+		next = changes.Next
+		return nil
+	}
+
+	_, err := service.Modify(uint(todoId), &dto.ModifyTodo{Todos: "pay rent", Status: true})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, next)
+	assert.Equal(t, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), *next.DueAt)
+	assert.True(t, next.DueAllDay)
+}
+
+func TestModifyTodoRecurrenceEnded(t *testing.T) {
+	dueAt := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	added := false
+
+	todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
+		return &entity.Todo{
+			DueAt:      &dueAt,
+			Recurrence: "FREQ=DAILY;COUNT=3",
+			Occurrence: 3,
+		}, nil
+	}
+
+	todos_repo.Modify = func(todoId uint, todo *entity.Todo, changes *todos_repo.TodoChanges) errs.Error {
+		assert.Equal(t, "FREQ=DAILY;COUNT=3", todo.Recurrence)
+		added = changes.Next != nil
+		return nil
+	}
+
+	_, err := service.Modify(uint(todoId), &dto.ModifyTodo{Todos: "stretch", Status: true})
+
+	assert.Nil(t, err)
+	assert.False(t, added)
+}
+
+func TestModifyTodoSpawnFromCompletion(t *testing.T) {
+	dueAt := time.Date(2024, 1, 1, 8, 30, 0, 0, time.UTC)
+	var next *entity.Todo
+
+	todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
+		return &entity.Todo{
+			DueAt:                &dueAt,
+			TimeZone:             "UTC",
+			Recurrence:           "FREQ=DAILY;INTERVAL=3",
+			RepeatFromCompletion: true,
+		}, nil
+	}
+
+	todos_repo.Modify = func(todoId uint, todo *entity.Todo, changes *todos_repo.TodoChanges) errs.Error {
+		next = changes.Next
+		return nil
+	}
+
+	_, err := service.Modify(uint(todoId), &dto.ModifyTodo{Todos: "water plants", Status: true})
+
+	now := time.Now().UTC()
+
+	assert.Nil(t, err)
+	assert.NotNil(t, next)
+	assert.Equal(t, time.Date(now.Year(), now.Month(), now.Day()+3, 8, 30, 0, 0, time.UTC), *next.DueAt)
+}
+
+func TestModifyTodoRecurringInvalidTag(t *testing.T) {
+	dueAt := time.Date(2024, 1, 1, 8, 30, 0, 0, time.UTC)
+	modified := false
+
+	todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
+		return &entity.Todo{
+			DueAt:      &dueAt,
+			TimeZone:   "UTC",
+			Recurrence: "FREQ=DAILY",
+		}, nil
+	}
+
+	tags_repo.FetchByIds = func(userId uint, tagIds []uint) ([]*entity.Tag, errs.Error) {
+		return []*entity.Tag{}, nil
+	}
+
+	todos_repo.Modify = func(todoId uint, todo *entity.Todo, changes *todos_repo.TodoChanges) errs.Error {
+		modified = true
+		return nil
+	}
+
+	tr, err := service.Modify(uint(todoId), &dto.ModifyTodo{Todos: "water plants", Status: true, TagIds: []uint{9}})
+
+	assert.Nil(t, tr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusNotFound, err.Status())
+	assert.False(t, modified)
+}
+
+func TestSkipTodoNotRecurring(t *testing.T) {
+	todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
+		return &entity.Todo{}, nil
+	}
+
+	tr, err := service.Skip(uint(todoId))
+
+	assert.Nil(t, tr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, err.Status())
+}
+
+func TestSkipTodoSuccess(t *testing.T) {
+	dueAt := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
+		return &entity.Todo{
+			DueAt:      &dueAt,
+			DueAllDay:  true,
+			Recurrence: "FREQ=MONTHLY;BYMONTHDAY=1,15;UNTIL=20241231",
+			Occurrence: 1,
+		}, nil
+	}
+
+	todos_repo.Modify = func(todoId uint, todo *entity.Todo, changes *todos_repo.TodoChanges) errs.Error {
+		assert.Equal(t, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), *todo.DueAt)
+		assert.Equal(t, 2, todo.Occurrence)
+		return nil
+	}
+
+	tr, err := service.Skip(uint(todoId))
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.Equal(t, fiber.StatusOK, tr.Status)
+}
+
+func TestSkipTodoRecurrenceEnded(t *testing.T) {
+	dueAt := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
+		return &entity.Todo{
+			DueAt:      &dueAt,
+			Recurrence: "FREQ=DAILY;UNTIL=20241231",
+		}, nil
+	}
+
+	tr, err := service.Skip(uint(todoId))
+
+	assert.Nil(t, tr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, err.Status())
+}
+
+func TestFetchTodoWithFilters(t *testing.T) {
+	todos_repo.Fetch = func(filter *todos_repo.TodoFilter) ([]*entity.Todo, errs.Error) {
+		jakarta, _ := time.LoadLocation("Asia/Jakarta")
+
+		assert.Equal(t, uint(userId), filter.UserID)
+		assert.Equal(t, "rent", filter.Search)
+		assert.False(t, *filter.Status)
+		assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, jakarta), *filter.CreatedFrom)
+		assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, jakarta), *filter.CreatedTo)
+		assert.Equal(t, time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC), filter.UpdatedFrom.UTC())
+		assert.Equal(t, "created_at", filter.Sort)
+		assert.True(t, filter.Descending)
+		assert.Equal(t, 11, filter.Limit)
+		return []*entity.Todo{}, nil
+	}
+
+	tr, err := service.Fetch(uint(userId), 0, &dto.TodoQuery{
+		Search:      "rent",
+		Status:      "open",
+		TimeZone:    "Asia/Jakarta",
+		CreatedFrom: "2024-01-01",
+		CreatedTo:   "2024-01-31",
+		UpdatedFrom: "2024-01-15T17:00:00+07:00",
+		Sort:        "created_at",
+		Order:       "desc",
+		Limit:       10,
+	})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.Equal(t, "", *tr.NextCursor)
+}
+
+func TestFetchTodoInvalidFilters(t *testing.T) {
+	queries := []*dto.TodoQuery{
+		{Status: "pending"},
+		{Sort: "todos"},
+		{Order: "up"},
+		{Limit: 101},
+		{CreatedFrom: "yesterday"},
+		{Cursor: "not a cursor"},
+	}
+
+	for _, query := range queries {
+		tr, err := service.Fetch(uint(userId), 0, query)
+
+		assert.Nil(t, tr)
+		assert.NotNil(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, err.Status())
+	}
+}
+
+func TestFetchTodoNextPage(t *testing.T) {
+	todos_repo.Fetch = func(filter *todos_repo.TodoFilter) ([]*entity.Todo, errs.Error) {
+		assert.Nil(t, filter.After)
+		return []*entity.Todo{
+			{Model: gorm.Model{ID: 3}, Position: 1},
+			{Model: gorm.Model{ID: 1}, Position: 2},
+			{Model: gorm.Model{ID: 2}, Position: 3},
+		}, nil
+	}
+
+	tr, err := service.Fetch(uint(userId), 0, &dto.TodoQuery{Limit: 2})
+
+	assert.Nil(t, err)
+	assert.Len(t, tr.Data, 2)
+	assert.NotEqual(t, "", *tr.NextCursor)
+
+	todos_repo.Fetch = func(filter *todos_repo.TodoFilter) ([]*entity.Todo, errs.Error) {
+		assert.Equal(t, uint(1), filter.After.ID)
+		assert.Equal(t, 2, filter.After.Position)
+		return []*entity.Todo{
+			{Model: gorm.Model{ID: 2}, Position: 3},
+		}, nil
+	}
+
+	tr, err = service.Fetch(uint(userId), 0, &dto.TodoQuery{Limit: 2, Cursor: *tr.NextCursor})
+
+	assert.Nil(t, err)
+	assert.Len(t, tr.Data, 1)
+	assert.Equal(t, "", *tr.NextCursor)
+}
+
+func TestFetchTodoWithoutLimit(t *testing.T) {
+	todos_repo.Fetch = func(filter *todos_repo.TodoFilter) ([]*entity.Todo, errs.Error) {
+		assert.Equal(t, 51, filter.Limit)
+		todos := []*entity.Todo{}
+		for i := 1; i <= filter.Limit; i++ {
+			todos = append(todos, &entity.Todo{Model: gorm.Model{ID: uint(i)}, Position: i})
+		}
+		return todos, nil
+	}
+
+	tr, err := service.Fetch(uint(userId), 0, &dto.TodoQuery{})
+
+	assert.Nil(t, err)
+	assert.Len(t, tr.Data, 50)
+	assert.NotEqual(t, "", *tr.NextCursor)
+}
+
+func TestFetchTodoCursorSortMismatch(t *testing.T) {
+	todos_repo.Fetch = func(filter *todos_repo.TodoFilter) ([]*entity.Todo, errs.Error) {
+		return []*entity.Todo{{Model: gorm.Model{ID: 1}}, {Model: gorm.Model{ID: 2}}}, nil
+	}
+
+	tr, _ := service.Fetch(uint(userId), 0, &dto.TodoQuery{Limit: 1})
+
+	tr, err := service.Fetch(uint(userId), 0, &dto.TodoQuery{Limit: 1, Sort: "priority", Cursor: *tr.NextCursor})
+
+	assert.Nil(t, tr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, err.Status())
+}
+
+func TestSearchTodoEmptyQuery(t *testing.T) {
+	tr, err := service.Search(uint(userId), 0, &dto.SearchQuery{Q: " !& "})
+
+	assert.Nil(t, tr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, err.Status())
+}
+
+func TestSearchTodoServerError(t *testing.T) {
+	todos_repo.Search = func(userId uint, workspaceId uint, tsQuery string, limit int) ([]*todos_repo.TodoMatch, errs.Error) {
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	tr, err := service.Search(uint(userId), 0, &dto.SearchQuery{Q: "rent"})
+
+	assert.Nil(t, tr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, err.Status())
+}
+
+func TestSearchTodoSuccess(t *testing.T) {
+	todos_repo.Search = func(userId uint, workspaceId uint, tsQuery string, limit int) ([]*todos_repo.TodoMatch, errs.Error) {
+		assert.Equal(t, "pay:* & re:* & 2024:*", tsQuery)
+		assert.Equal(t, 50, limit)
+		return []*todos_repo.TodoMatch{
+			{
+				Todo:    &entity.Todo{Model: gorm.Model{ID: 1}, Todos: "pay rent 2024"},
+				Rank:    0.5,
+				Snippet: "<mark>pay</mark> <mark>rent</mark> <mark>2024</mark>",
+			},
+		}, nil
+	}
+
+	tr, err := service.Search(uint(userId), 0, &dto.SearchQuery{Q: "pay re|2024:*"})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.Equal(t, fiber.StatusOK, tr.Status)
+	assert.Equal(t, uint(1), tr.Data.([]*dto.TodoMatch)[0].Todo.Id)
+}
+
+func TestSearchTodoShared(t *testing.T) {
+	todos_repo.Search = func(userId uint, workspaceId uint, tsQuery string, limit int) ([]*todos_repo.TodoMatch, errs.Error) {
+		return []*todos_repo.TodoMatch{
+			{Todo: &entity.Todo{Model: gorm.Model{ID: 1}, UserID: uint(userId)}},
+			{Todo: &entity.Todo{Model: gorm.Model{ID: 2}, UserID: 2}},
+		}, nil
+	}
+
+	tr, err := service.Search(uint(userId), 0, &dto.SearchQuery{Q: "rent"})
+
+	matches := tr.Data.([]*dto.TodoMatch)
+
+	assert.Nil(t, err)
+	assert.False(t, matches[0].Todo.Shared)
+	assert.True(t, matches[1].Todo.Shared)
+}
+
+func TestTrashTodoSuccess(t *testing.T) {
+	todos_repo.FetchTrash = func(userId uint, workspaceId uint) ([]*entity.Todo, errs.Error) {
+		return []*entity.Todo{
+			{
+				Model: gorm.Model{
+					ID:        1,
+					DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true},
+				},
+			},
+		}, nil
+	}
+
+	tr, err := service.Trash(uint(userId), 0)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.Equal(t, fiber.StatusOK, tr.Status)
+	assert.NotNil(t, tr.Data.([]*dto.Todo)[0].DeletedAt)
+}
+
+func TestRestoreTodoNotInTrash(t *testing.T) {
+	todos_repo.DetailTrash = func(todoId uint) (*entity.Todo, errs.Error) {
+		return nil, errs.NewNotFoundError("todo not found in trash")
+	}
+
+	tr, err := service.Restore(uint(todoId))
+
+	assert.Nil(t, tr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusNotFound, err.Status())
+}
+
+func TestRestoreTodoSuccess(t *testing.T) {
+	todos_repo.DetailTrash = func(todoId uint) (*entity.Todo, errs.Error) {
+		return &entity.Todo{}, nil
+	}
+
+	todos_repo.Restore = func(todoId uint) errs.Error {
+		return nil
+	}
+
+	tr, err := service.Restore(uint(todoId))
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.Equal(t, fiber.StatusOK, tr.Status)
+}
+
+func TestPurgeTodoServerError(t *testing.T) {
+	todos_repo.DetailTrash = func(todoId uint) (*entity.Todo, errs.Error) {
+		return &entity.Todo{}, nil
+	}
+
+	todos_repo.Purge = func(todoId uint) ([]string, errs.Error) {
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	tr, err := service.Purge(uint(todoId))
+
+	assert.Nil(t, tr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, err.Status())
+}
+
+func TestPurgeTodoSuccess(t *testing.T) {
+	todos_repo.DetailTrash = func(todoId uint) (*entity.Todo, errs.Error) {
+		return &entity.Todo{}, nil
+	}
+
+	todos_repo.Purge = func(todoId uint) ([]string, errs.Error) {
+		return []string{"todos/1/a", "todos/1/b"}, nil
+	}
+
+	deleted := []string{}
+
+	storage.Delete = func(key string) error {
+		deleted = append(deleted, key)
+		return nil
+	}
+
+	tr, err := service.Purge(uint(todoId))
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.Equal(t, fiber.StatusOK, tr.Status)
+	assert.Equal(t, []string{"todos/1/a", "todos/1/b"}, deleted)
+}
+
+func TestPurgeTrashRetention(t *testing.T) {
+	todos_repo.PurgeTrash = func(deletedBefore time.Time) (int64, []string, errs.Error) {
+		assert.WithinDuration(t, time.Now().AddDate(0, 0, -30), deletedBefore, time.Minute)
+		return 2, []string{}, nil
+	}
+
+	purged, err := service.PurgeTrash(30)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), purged)
+}
+
+func TestPurgeTrashRetentionDisabled(t *testing.T) {
+	called := false
+
+	todos_repo.PurgeTrash = func(deletedBefore time.Time) (int64, []string, errs.Error) {
+		called = true
+		return 2, []string{}, nil
+	}
+
+	purged, err := service.PurgeTrash(0)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), purged)
+	assert.False(t, called)
+}
+
+func TestBulkTodoEmptyIds(t *testing.T) {
+	tr, err := service.Bulk(uint(userId), &dto.BulkTodos{Action: "complete"})
+
+	assert.Nil(t, tr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, err.Status())
+}
+
+func TestBulkTodoInvalidAction(t *testing.T) {
+	tr, err := service.Bulk(uint(userId), &dto.BulkTodos{TodoIds: []uint{1}, Action: "archive"})
+
+	assert.Nil(t, tr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, err.Status())
+}
+
+func TestBulkTodoOtherUserTag(t *testing.T) {
+	tags_repo.FetchByIds = func(userId uint, tagIds []uint) ([]*entity.Tag, errs.Error) {
+		return []*entity.Tag{}, nil
+	}
+
+	tr, err := service.Bulk(uint(userId), &dto.BulkTodos{TodoIds: []uint{1}, Action: "tag", TagId: 9})
+
+	assert.Nil(t, tr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusNotFound, err.Status())
+}
+
+func TestBulkTodoMoveToInbox(t *testing.T) {
+	todos_repo.Bulk = func(userId uint, todoIds []uint, action *todos_repo.BulkAction, allOrNothing bool) ([]*todos_repo.BulkResult, errs.Error) {
+		assert.Equal(t, []uint{2, 1}, todoIds)
+		assert.Equal(t, "move", action.Name)
+		assert.Nil(t, action.ProjectID)
+		return []*todos_repo.BulkResult{{TodoID: 2}, {TodoID: 1}}, nil
+	}
+
+	tr, err := service.Bulk(uint(userId), &dto.BulkTodos{TodoIds: []uint{2, 1, 2}, Action: "move"})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.Equal(t, fiber.StatusOK, tr.Status)
+	assert.Len(t, tr.Data, 2)
+}
+
+func TestBulkTodoPartialFailure(t *testing.T) {
+	todos_repo.Bulk = func(userId uint, todoIds []uint, action *todos_repo.BulkAction, allOrNothing bool) ([]*todos_repo.BulkResult, errs.Error) {
+		return []*todos_repo.BulkResult{
+			{TodoID: 1},
+			{TodoID: 2, Err: errs.NewUnathorizedError("you're not authorized to access this todo")},
+		}, nil
+	}
+
+	tr, err := service.Bulk(uint(userId), &dto.BulkTodos{TodoIds: []uint{1, 2}, Action: "complete"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, fiber.StatusOK, tr.Status)
+	assert.Equal(t, "1 of 2 todos failed", tr.Message)
+	assert.Equal(t, fiber.StatusForbidden, tr.Data.([]*dto.BulkResult)[1].Status)
+}
+
+func TestBulkTodoAllOrNothingFailure(t *testing.T) {
+	todos_repo.Bulk = func(userId uint, todoIds []uint, action *todos_repo.BulkAction, allOrNothing bool) ([]*todos_repo.BulkResult, errs.Error) {
+		assert.True(t, allOrNothing)
+		return []*todos_repo.BulkResult{
+			{TodoID: 1},
+			{TodoID: 2, Err: errs.NewNotFoundError("todo not found")},
+		}, nil
+	}
+
+	tr, err := service.Bulk(uint(userId), &dto.BulkTodos{TodoIds: []uint{1, 2}, Action: "delete", AllOrNothing: true})
+
+	assert.Nil(t, err)
+	assert.Equal(t, fiber.StatusUnprocessableEntity, tr.Status)
+	assert.Equal(t, fiber.StatusNotFound, tr.Data.([]*dto.BulkResult)[1].Status)
+}
+
+func TestFetchTodoAssignedToMe(t *testing.T) {
+	todos_repo.Fetch = func(filter *todos_repo.TodoFilter) ([]*entity.Todo, errs.Error) {
+		assert.Equal(t, uint(userId), filter.AssigneeID)
+		return []*entity.Todo{}, nil
+	}
+
+	tr, err := service.Fetch(uint(userId), 0, &dto.TodoQuery{Assignee: "me"})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.Equal(t, fiber.StatusOK, tr.Status)
+}
+
+func TestFetchTodoInvalidAssignee(t *testing.T) {
+	tr, err := service.Fetch(uint(userId), 0, &dto.TodoQuery{Assignee: "2"})
+
+	assert.Nil(t, tr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, err.Status())
+}
+
+var assignee = &entity.User{Model: gorm.Model{ID: 2}, Name: "zee", Email: "zee@weeekly.com"}
+
+func mockAssign(workspaceId *uint) {
+	todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
+		return &entity.Todo{Model: gorm.Model{ID: todoId}, Todos: "groceries", UserID: uint(userId), WorkspaceID: workspaceId}, nil
+	}
+
+	users_repo.FetchById = func(id uint) (*entity.User, errs.Error) {
+		if id == assignee.ID {
+			return assignee, nil
+		}
+		return &entity.User{Model: gorm.Model{ID: id}, Name: "jihan"}, nil
+	}
+
+	todos_repo.Assign = func(todoId uint, assigneeId *uint) errs.Error {
+		return nil
+	}
+
+	shares_repo.Access = func(userId uint, todo *entity.Todo) (*shares_repo.TodoAccess, errs.Error) {
+		if userId == todo.UserID {
+			return &shares_repo.TodoAccess{ShareRole: entity.ShareOwner}, nil
+		}
+		return &shares_repo.TodoAccess{}, nil
+	}
+}
+
+func TestAssignTodoSharedSuccess(t *testing.T) {
+	mockAssign(nil)
+
+	shares_repo.Access = func(userId uint, todo *entity.Todo) (*shares_repo.TodoAccess, errs.Error) {
+		return &shares_repo.TodoAccess{ShareRole: entity.ShareViewer}, nil
+	}
+
+	todos_repo.Assign = func(todoId uint, assigneeId *uint) errs.Error {
+		assert.Equal(t, assignee.ID, *assigneeId)
+		return nil
+	}
+
+	tr, err := service.Assign(uint(userId), uint(todoId), &dto.AssignTodo{UserId: assignee.ID})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.Equal(t, fiber.StatusOK, tr.Status)
+	assert.Equal(t, assignee.ID, *tr.Data.(*dto.Todo).AssigneeId)
+
+	select {
+	case user := <-assignments:
+		assert.Equal(t, assignee.Email, user.Email)
+	case <-time.After(time.Second):
+		t.Fatal("assignment wasn't notified")
+	}
+}
+
+func TestAssignTodoWithoutAccess(t *testing.T) {
+	mockAssign(nil)
+
+	shares_repo.Access = func(userId uint, todo *entity.Todo) (*shares_repo.TodoAccess, errs.Error) {
+		return &shares_repo.TodoAccess{}, nil
+	}
+
+	tr, err := service.Assign(uint(userId), uint(todoId), &dto.AssignTodo{UserId: assignee.ID})
+
+	assert.Nil(t, tr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, err.Status())
+}
+
+func TestAssignTodoWorkspaceMemberSuccess(t *testing.T) {
+	workspaceId := uint(1)
+
+	mockAssign(&workspaceId)
+
+	shares_repo.Access = func(userId uint, todo *entity.Todo) (*shares_repo.TodoAccess, errs.Error) {
+		return &shares_repo.TodoAccess{WorkspaceRole: entity.WorkspaceRoleGuest}, nil
+	}
+
+	tr, err := service.Assign(uint(userId), uint(todoId), &dto.AssignTodo{UserId: assignee.ID})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.Equal(t, fiber.StatusOK, tr.Status)
+
+	select {
+	case user := <-assignments:
+		assert.Equal(t, assignee.Email, user.Email)
+	case <-time.After(time.Second):
+		t.Fatal("assignment wasn't notified")
+	}
+}
+
+func TestAssignTodoNotWorkspaceMember(t *testing.T) {
+	workspaceId := uint(1)
+
+	mockAssign(&workspaceId)
+
+	shares_repo.Access = func(userId uint, todo *entity.Todo) (*shares_repo.TodoAccess, errs.Error) {
+		return &shares_repo.TodoAccess{}, nil
+	}
+
+	tr, err := service.Assign(uint(userId), uint(todoId), &dto.AssignTodo{UserId: assignee.ID})
+
+	assert.Nil(t, tr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, err.Status())
+}
+
+func TestAssignTodoToSelfDoesNotNotify(t *testing.T) {
+	mockAssign(nil)
+
+	tr, err := service.Assign(uint(userId), uint(todoId), &dto.AssignTodo{UserId: uint(userId)})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+
+	select {
+	case <-assignments:
+		t.Fatal("self assignment was notified")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestUnassignTodoSuccess(t *testing.T) {
+	mockAssign(nil)
+
+	todos_repo.Assign = func(todoId uint, assigneeId *uint) errs.Error {
+		assert.Nil(t, assigneeId)
+		return nil
+	}
+
+	tr, err := service.Unassign(uint(todoId))
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.Equal(t, fiber.StatusOK, tr.Status)
+}
+
+func TestModifyTodoStatusRecurringSuccess(t *testing.T) {
+	dueAt := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	todos_repo.Detail = func(todoId uint) (*entity.Todo, errs.Error) {
+		return &entity.Todo{Model: gorm.Model{ID: todoId}, Todos: "standup", DueAt: &dueAt, TimeZone: "UTC", Recurrence: "FREQ=DAILY", Occurrence: 1}, nil
+	}
+
+	todos_repo.Modify = func(todoId uint, todo *entity.Todo, changes *todos_repo.TodoChanges) errs.Error {
+		assert.True(t, todo.Status)
+		assert.Equal(t, "standup", todo.Todos)
+		assert.Empty(t, todo.Recurrence)
+		assert.Equal(t, 2, changes.Next.Occurrence)
+		return nil
+	}
+
+	tr, err := service.ModifyStatus(uint(todoId), &dto.ModifyStatus{Status: true})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tr)
+	assert.Equal(t, fiber.StatusOK, tr.Status)
+}
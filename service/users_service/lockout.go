@@ -0,0 +1,87 @@
+package users_service
+
+import (
+	"strings"
+	"time"
+	"todo-app/dto"
+	"todo-app/entity"
+	"todo-app/infra/config"
+	"todo-app/pkg/errs"
+)
+
+// LockoutHook is notified when failed logins lock a registered email out.
+type LockoutHook func(user *entity.User, ip string, until time.Time)
+
+// attemptKey is a key whose failed logins are tracked together with the
+// maximum failures it is allowed before being locked out.
+type attemptKey struct {
+	key         string
+	maxFailures int
+}
+
+func attemptKeys(payload *dto.Login) []attemptKey {
+
+	keys := []attemptKey{
+		{key: "email:" + strings.ToLower(payload.Email), maxFailures: config.AppConfig().LoginMaxFailures},
+	}
+
+	if payload.IP != "" {
+		keys = append(keys, attemptKey{key: "ip:" + payload.IP, maxFailures: config.AppConfig().LoginMaxFailuresPerIP})
+	}
+
+	return keys
+}
+
+// checkAttempts rejects the login while its email or IP is blocked.
+func (us *userService) checkAttempts(keys []attemptKey, now time.Time) errs.Error {
+
+	retryAfter := time.Duration(0)
+
+	for _, eachKey := range keys {
+		attempt, err := us.ar.Fetch(eachKey.key)
+
+		if err != nil {
+			return err
+		}
+
+		retryAfter = max(retryAfter, attempt.RetryAfter(now))
+	}
+
+	if retryAfter > 0 {
+		return errs.NewTooManyRequestsError("too many failed login attempts, try again later", retryAfter)
+	}
+
+	return nil
+}
+
+// loginFailed counts a failed login against its email and IP, user is nil
+// when the email isn't registered.
+func (us *userService) loginFailed(keys []attemptKey, user *entity.User, ip string, now time.Time) errs.Error {
+
+	for i, eachKey := range keys {
+		attempt, err := us.ar.Fail(eachKey.key, eachKey.maxFailures, now)
+
+		if err != nil {
+			return err
+		}
+
+		// only the failure reaching the maximum notifies, the first key is
+		// the email
+		if i == 0 && user != nil && attempt.Failures == eachKey.maxFailures && us.onLockout != nil {
+			go us.onLockout(user, ip, attempt.BlockedUntil)
+		}
+	}
+
+	return errs.NewUnauthenticatedError("invalid user email or password")
+}
+
+func (us *userService) resetAttempts(keys []attemptKey) errs.Error {
+
+	names := []string{}
+
+	for _, eachKey := range keys {
+		names = append(names, eachKey.key)
+	}
+
+	return us.ar.Reset(names...)
+}
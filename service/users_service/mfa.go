@@ -0,0 +1,202 @@
+package users_service
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+	"todo-app/dto"
+	"todo-app/entity"
+	"todo-app/infra/config"
+	"todo-app/pkg/errs"
+	"todo-app/pkg/totp"
+)
+
+// totpSkew is how many steps before and after the current one a code is
+// still accepted, to tolerate clock drift.
+const totpSkew = 1
+
+// EnrollTOTP implements UserService.
+func (us *userService) EnrollTOTP(userId uint) (*dto.UserResponse, errs.Error) {
+
+	u, err := us.ur.FetchById(userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if u.HasTOTP() {
+		return nil, errs.NewConflictError("two factor authentication is already enabled")
+	}
+
+	secret, genErr := totp.GenerateSecret()
+
+	if genErr != nil {
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := us.mr.SetSecret(userId, secret); err != nil {
+		return nil, err
+	}
+
+	return &dto.UserResponse{
+		Status:  http.StatusOK,
+		Message: "scan the code with your authenticator app and confirm it",
+		Data: dto.TOTPEnrolment{
+			Secret: secret,
+			URI:    totp.URI(config.AppConfig().TOTPIssuer, u.Email, secret),
+		},
+	}, nil
+}
+
+// ConfirmTOTP implements UserService.
+func (us *userService) ConfirmTOTP(userId uint, payload *dto.ConfirmTOTP) (*dto.UserResponse, errs.Error) {
+
+	u, err := us.ur.FetchById(userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if u.HasTOTP() {
+		return nil, errs.NewConflictError("two factor authentication is already enabled")
+	}
+
+	if u.TOTPSecret == "" {
+		return nil, errs.NewBadRequestError("two factor authentication hasn't been enrolled")
+	}
+
+	step, ok := totp.Validate(u.TOTPSecret, payload.Code, time.Now(), totpSkew)
+
+	if !ok {
+		return nil, errs.NewBadRequestError("invalid two factor authentication code")
+	}
+
+	codes, raws, err := entity.NewRecoveryCodes(userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := us.mr.Enable(userId, step, codes); err != nil {
+		return nil, err
+	}
+
+	// the raw recovery codes are only ever shown here
+	return &dto.UserResponse{
+		Status:  http.StatusOK,
+		Message: "two factor authentication successfully enabled",
+		Data:    dto.RecoveryCodes{RecoveryCodes: raws},
+	}, nil
+}
+
+// DisableTOTP implements UserService.
+func (us *userService) DisableTOTP(userId uint, payload *dto.DisableTOTP) (*dto.UserResponse, errs.Error) {
+
+	u, err := us.ur.FetchById(userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !u.CompareHashPassword(payload.Password) {
+		return nil, errs.NewBadRequestError("password is incorrect")
+	}
+
+	if !u.HasTOTP() {
+		return nil, errs.NewBadRequestError("two factor authentication isn't enabled")
+	}
+
+	if err := us.mr.Disable(userId); err != nil {
+		return nil, err
+	}
+
+	return &dto.UserResponse{
+		Status:  http.StatusOK,
+		Message: "two factor authentication successfully disabled",
+		Data:    nil,
+	}, nil
+}
+
+// LoginMFA implements UserService.
+func (us *userService) LoginMFA(payload *dto.LoginMFA) (*dto.UserResponse, errs.Error) {
+
+	challenge := &entity.User{}
+
+	if err := challenge.ValidateMFAToken(payload.MFAToken); err != nil {
+		return nil, err
+	}
+
+	if payload.Code == "" && payload.RecoveryCode == "" {
+		return nil, errs.NewBadRequestError("code or recovery code can't be empty")
+	}
+
+	u, err := us.ur.FetchById(challenge.ID)
+
+	if err != nil {
+		if err.Status() == http.StatusNotFound {
+			return nil, errs.NewUnauthenticatedError("invalid user")
+		}
+		return nil, err
+	}
+
+	if !u.HasTOTP() {
+		return nil, errs.NewUnauthenticatedError("two factor authentication isn't enabled")
+	}
+
+	// a stolen challenge token must not allow guessing codes endlessly
+	now := time.Now()
+	keys := []attemptKey{{key: fmt.Sprintf("mfa:%d", u.ID), maxFailures: config.AppConfig().LoginMaxFailures}}
+
+	if err := us.checkAttempts(keys, now); err != nil {
+		return nil, err
+	}
+
+	if err := us.verifySecondFactor(u, payload, now); err != nil {
+		if err.Status() != http.StatusUnauthorized {
+			return nil, err
+		}
+
+		if _, failErr := us.ar.Fail(keys[0].key, keys[0].maxFailures, now); failErr != nil {
+			return nil, failErr
+		}
+
+		return nil, err
+	}
+
+	if err := us.resetAttempts(keys); err != nil {
+		return nil, err
+	}
+
+	return us.startSession(u, payload.DeviceName, payload.IP, payload.UserAgent)
+}
+
+// verifySecondFactor consumes the TOTP code, or the recovery code when no
+// code is given, so neither can be replayed.
+func (us *userService) verifySecondFactor(u *entity.User, payload *dto.LoginMFA, now time.Time) errs.Error {
+
+	invalidCode := errs.NewUnauthenticatedError("invalid two factor authentication code")
+
+	if payload.Code == "" {
+		err := us.mr.UseRecoveryCode(u.ID, entity.HashRecoveryCode(payload.RecoveryCode))
+
+		if err != nil && err.Status() == http.StatusNotFound {
+			return invalidCode
+		}
+
+		return err
+	}
+
+	step, ok := totp.Validate(u.TOTPSecret, payload.Code, now, totpSkew)
+
+	if !ok {
+		return invalidCode
+	}
+
+	err := us.mr.UseStep(u.ID, step)
+
+	if err != nil && err.Status() == http.StatusConflict {
+		return invalidCode
+	}
+
+	return err
+}
@@ -13,6 +13,28 @@ var (
 	Modify   func(userId uint, payload *dto.Modify) (*dto.UserResponse, errs.Error)
 	Profile  func(userId uint) (*dto.UserResponse, errs.Error)
 	Register func(payload *dto.Register) (*dto.UserResponse, errs.Error)
+	Refresh  func(payload *dto.RefreshToken) (*dto.UserResponse, errs.Error)
+	Logout   func(sessionId string) (*dto.UserResponse, errs.Error)
+
+	ChangePassword func(userId uint, sessionId string, payload *dto.ChangePassword) (*dto.UserResponse, errs.Error)
+	ForgotPassword func(payload *dto.ForgotPassword) (*dto.UserResponse, errs.Error)
+	ResetPassword  func(payload *dto.ResetPassword) (*dto.UserResponse, errs.Error)
+
+	VerifyEmail        func(payload *dto.VerifyEmail) (*dto.UserResponse, errs.Error)
+	ResendVerification func(payload *dto.ResendVerification) (*dto.UserResponse, errs.Error)
+
+	Sessions       func(userId uint, sessionId string) (*dto.UserResponse, errs.Error)
+	RevokeSession  func(userId uint, sessionId uint) (*dto.UserResponse, errs.Error)
+	RevokeSessions func(userId uint) (*dto.UserResponse, errs.Error)
+
+	EnrollTOTP  func(userId uint) (*dto.UserResponse, errs.Error)
+	ConfirmTOTP func(userId uint, payload *dto.ConfirmTOTP) (*dto.UserResponse, errs.Error)
+	DisableTOTP func(userId uint, payload *dto.DisableTOTP) (*dto.UserResponse, errs.Error)
+	LoginMFA    func(payload *dto.LoginMFA) (*dto.UserResponse, errs.Error)
+
+	AddPersonalToken    func(userId uint, payload *dto.AddPersonalToken) (*dto.UserResponse, errs.Error)
+	PersonalTokens      func(userId uint) (*dto.UserResponse, errs.Error)
+	RevokePersonalToken func(userId uint, tokenId uint) (*dto.UserResponse, errs.Error)
 )
 
 func NewServiceMock() UserService {
@@ -38,3 +60,88 @@ func (sm *serviceMock) Profile(userId uint) (*dto.UserResponse, errs.Error) {
 func (sm *serviceMock) Register(payload *dto.Register) (*dto.UserResponse, errs.Error) {
 	return Register(payload)
 }
+
+// Refresh implements UserService.
+func (sm *serviceMock) Refresh(payload *dto.RefreshToken) (*dto.UserResponse, errs.Error) {
+	return Refresh(payload)
+}
+
+// Logout implements UserService.
+func (sm *serviceMock) Logout(sessionId string) (*dto.UserResponse, errs.Error) {
+	return Logout(sessionId)
+}
+
+// Sessions implements UserService.
+func (sm *serviceMock) Sessions(userId uint, sessionId string) (*dto.UserResponse, errs.Error) {
+	return Sessions(userId, sessionId)
+}
+
+// RevokeSession implements UserService.
+func (sm *serviceMock) RevokeSession(userId uint, sessionId uint) (*dto.UserResponse, errs.Error) {
+	return RevokeSession(userId, sessionId)
+}
+
+// RevokeSessions implements UserService.
+func (sm *serviceMock) RevokeSessions(userId uint) (*dto.UserResponse, errs.Error) {
+	return RevokeSessions(userId)
+}
+
+// ChangePassword implements UserService.
+func (sm *serviceMock) ChangePassword(userId uint, sessionId string, payload *dto.ChangePassword) (*dto.UserResponse, errs.Error) {
+	return ChangePassword(userId, sessionId, payload)
+}
+
+// ForgotPassword implements UserService.
+func (sm *serviceMock) ForgotPassword(payload *dto.ForgotPassword) (*dto.UserResponse, errs.Error) {
+	return ForgotPassword(payload)
+}
+
+// ResetPassword implements UserService.
+func (sm *serviceMock) ResetPassword(payload *dto.ResetPassword) (*dto.UserResponse, errs.Error) {
+	return ResetPassword(payload)
+}
+
+// VerifyEmail implements UserService.
+func (sm *serviceMock) VerifyEmail(payload *dto.VerifyEmail) (*dto.UserResponse, errs.Error) {
+	return VerifyEmail(payload)
+}
+
+// ResendVerification implements UserService.
+func (sm *serviceMock) ResendVerification(payload *dto.ResendVerification) (*dto.UserResponse, errs.Error) {
+	return ResendVerification(payload)
+}
+
+// EnrollTOTP implements UserService.
+func (sm *serviceMock) EnrollTOTP(userId uint) (*dto.UserResponse, errs.Error) {
+	return EnrollTOTP(userId)
+}
+
+// ConfirmTOTP implements UserService.
+func (sm *serviceMock) ConfirmTOTP(userId uint, payload *dto.ConfirmTOTP) (*dto.UserResponse, errs.Error) {
+	return ConfirmTOTP(userId, payload)
+}
+
+// DisableTOTP implements UserService.
+func (sm *serviceMock) DisableTOTP(userId uint, payload *dto.DisableTOTP) (*dto.UserResponse, errs.Error) {
+	return DisableTOTP(userId, payload)
+}
+
+// LoginMFA implements UserService.
+func (sm *serviceMock) LoginMFA(payload *dto.LoginMFA) (*dto.UserResponse, errs.Error) {
+	return LoginMFA(payload)
+}
+
+// AddPersonalToken implements UserService.
+func (sm *serviceMock) AddPersonalToken(userId uint, payload *dto.AddPersonalToken) (*dto.UserResponse, errs.Error) {
+	return AddPersonalToken(userId, payload)
+}
+
+// PersonalTokens implements UserService.
+func (sm *serviceMock) PersonalTokens(userId uint) (*dto.UserResponse, errs.Error) {
+	return PersonalTokens(userId)
+}
+
+// RevokePersonalToken implements UserService.
+func (sm *serviceMock) RevokePersonalToken(userId uint, tokenId uint) (*dto.UserResponse, errs.Error) {
+	return RevokePersonalToken(userId, tokenId)
+}
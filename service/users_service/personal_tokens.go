@@ -0,0 +1,90 @@
+package users_service
+
+import (
+	"net/http"
+	"strings"
+	"time"
+	"todo-app/dto"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+)
+
+// AddPersonalToken implements UserService.
+func (us *userService) AddPersonalToken(userId uint, payload *dto.AddPersonalToken) (*dto.UserResponse, errs.Error) {
+
+	if len(payload.Scopes) == 0 {
+		return nil, errs.NewBadRequestError("scopes can't be empty")
+	}
+
+	for _, eachScope := range payload.Scopes {
+		if !entity.IsScope(eachScope) {
+			return nil, errs.NewBadRequestError("scopes must be one of " + strings.Join(entity.Scopes, ", "))
+		}
+	}
+
+	if payload.ExpiresInDays < 0 {
+		return nil, errs.NewBadRequestError("expires in days can't be negative")
+	}
+
+	var expiresAt *time.Time
+
+	if payload.ExpiresInDays > 0 {
+		t := time.Now().AddDate(0, 0, payload.ExpiresInDays)
+		expiresAt = &t
+	}
+
+	token, raw, err := entity.NewPersonalToken(userId, payload.Name, payload.Scopes, expiresAt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := us.ptr.Add(token); err != nil {
+		return nil, err
+	}
+
+	return &dto.UserResponse{
+		Status:  http.StatusCreated,
+		Message: "personal token successfully created",
+		Data: dto.NewPersonalToken{
+			PersonalToken: dto.EntityToPersonalToken(token),
+			Token:         raw,
+		},
+	}, nil
+}
+
+// PersonalTokens implements UserService.
+func (us *userService) PersonalTokens(userId uint) (*dto.UserResponse, errs.Error) {
+
+	tokens, err := us.ptr.FetchByUser(userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data := []*dto.PersonalToken{}
+
+	for _, eachToken := range tokens {
+		data = append(data, dto.EntityToPersonalToken(eachToken))
+	}
+
+	return &dto.UserResponse{
+		Status:  http.StatusOK,
+		Message: "personal tokens successfully fetched",
+		Data:    data,
+	}, nil
+}
+
+// RevokePersonalToken implements UserService.
+func (us *userService) RevokePersonalToken(userId uint, tokenId uint) (*dto.UserResponse, errs.Error) {
+
+	if err := us.ptr.Revoke(userId, tokenId); err != nil {
+		return nil, err
+	}
+
+	return &dto.UserResponse{
+		Status:  http.StatusOK,
+		Message: "personal token successfully revoked",
+		Data:    nil,
+	}, nil
+}
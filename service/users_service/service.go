@@ -1,14 +1,42 @@
 package users_service
 
 import (
+	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 	"todo-app/dto"
+	"todo-app/entity"
+	"todo-app/infra/config"
 	"todo-app/pkg/errs"
+	"todo-app/pkg/mailer"
+	"todo-app/pkg/password"
+	"todo-app/repo/attempts_repo"
+	"todo-app/repo/mfa_repo"
+	"todo-app/repo/personal_tokens_repo"
+	"todo-app/repo/resets_repo"
+	"todo-app/repo/sessions_repo"
+	"todo-app/repo/tokens_repo"
 	"todo-app/repo/users_repo"
+	"todo-app/repo/verifications_repo"
+
+	"github.com/gofiber/fiber/v2/log"
 )
 
 type userService struct {
-	ur users_repo.UsersRepo
+	ur  users_repo.UsersRepo
+	tkr tokens_repo.TokensRepo
+	sr  sessions_repo.SessionsRepo
+	rr  resets_repo.ResetsRepo
+	vr  verifications_repo.VerificationsRepo
+	ar  attempts_repo.AttemptsRepo
+	mr  mfa_repo.MFARepo
+	ptr personal_tokens_repo.PersonalTokensRepo
+	m   mailer.Mailer
+
+	policy    *password.Policy
+	onLockout LockoutHook
 }
 
 type UserService interface {
@@ -16,20 +44,44 @@ type UserService interface {
 	Login(payload *dto.Login) (*dto.UserResponse, errs.Error)
 	Profile(userId uint) (*dto.UserResponse, errs.Error)
 	Modify(userId uint, payload *dto.Modify) (*dto.UserResponse, errs.Error)
+	ChangePassword(userId uint, sessionId string, payload *dto.ChangePassword) (*dto.UserResponse, errs.Error)
+	ForgotPassword(payload *dto.ForgotPassword) (*dto.UserResponse, errs.Error)
+	ResetPassword(payload *dto.ResetPassword) (*dto.UserResponse, errs.Error)
+	VerifyEmail(payload *dto.VerifyEmail) (*dto.UserResponse, errs.Error)
+	ResendVerification(payload *dto.ResendVerification) (*dto.UserResponse, errs.Error)
+	Refresh(payload *dto.RefreshToken) (*dto.UserResponse, errs.Error)
+	Logout(sessionId string) (*dto.UserResponse, errs.Error)
+	Sessions(userId uint, sessionId string) (*dto.UserResponse, errs.Error)
+	RevokeSession(userId uint, sessionId uint) (*dto.UserResponse, errs.Error)
+	RevokeSessions(userId uint) (*dto.UserResponse, errs.Error)
+	EnrollTOTP(userId uint) (*dto.UserResponse, errs.Error)
+	ConfirmTOTP(userId uint, payload *dto.ConfirmTOTP) (*dto.UserResponse, errs.Error)
+	DisableTOTP(userId uint, payload *dto.DisableTOTP) (*dto.UserResponse, errs.Error)
+	LoginMFA(payload *dto.LoginMFA) (*dto.UserResponse, errs.Error)
+	AddPersonalToken(userId uint, payload *dto.AddPersonalToken) (*dto.UserResponse, errs.Error)
+	PersonalTokens(userId uint) (*dto.UserResponse, errs.Error)
+	RevokePersonalToken(userId uint, tokenId uint) (*dto.UserResponse, errs.Error)
 }
 
-func NewUserService(userRepo users_repo.UsersRepo) UserService {
-	return &userService{ur: userRepo}
+func NewUserService(userRepo users_repo.UsersRepo, tokenRepo tokens_repo.TokensRepo, sessionRepo sessions_repo.SessionsRepo, resetRepo resets_repo.ResetsRepo, verificationRepo verifications_repo.VerificationsRepo, attemptRepo attempts_repo.AttemptsRepo, mfaRepo mfa_repo.MFARepo, personalTokenRepo personal_tokens_repo.PersonalTokensRepo, m mailer.Mailer, policy *password.Policy, onLockout LockoutHook) UserService {
+	return &userService{ur: userRepo, tkr: tokenRepo, sr: sessionRepo, rr: resetRepo, vr: verificationRepo, ar: attemptRepo, mr: mfaRepo, ptr: personalTokenRepo, m: m, policy: policy, onLockout: onLockout}
 }
 
 // Login implements UserService.
 func (us *userService) Login(payload *dto.Login) (*dto.UserResponse, errs.Error) {
 
+	now := time.Now()
+	keys := attemptKeys(payload)
+
+	if err := us.checkAttempts(keys, now); err != nil {
+		return nil, err
+	}
+
 	u, err := us.ur.FetchByEmail(payload.Email)
 
 	if err != nil {
 		if err.Status() == http.StatusNotFound {
-			return nil, errs.NewUnauthenticatedError("invalid user email or password")
+			return nil, us.loginFailed(keys, nil, payload.IP, now)
 		}
 		return nil, err
 	}
@@ -37,34 +89,558 @@ func (us *userService) Login(payload *dto.Login) (*dto.UserResponse, errs.Error)
 	isValidPassword := u.CompareHashPassword(payload.Password)
 
 	if !isValidPassword {
-		return nil, errs.NewUnauthenticatedError("invalid user email or password")
+		return nil, us.loginFailed(keys, u, payload.IP, now)
+	}
+
+	// only the email is forgiven, a success from a shared IP mustn't clear
+	// the failures guessed from it against other accounts, the IP counter
+	// expires on its own
+	if err := us.resetAttempts(keys[:1]); err != nil {
+		return nil, err
+	}
+
+	us.rehashPassword(u, payload.Password)
+
+	if !u.IsVerified() && config.AppConfig().UnverifiedAccountPolicy == config.PolicyDeny {
+		return nil, errs.NewUnathorizedError("email address hasn't been verified")
+	}
+
+	if u.HasTOTP() {
+		return &dto.UserResponse{
+			Status:  http.StatusOK,
+			Message: "enter your two factor authentication code",
+			Data: dto.MFAChallenge{
+				MFARequired: true,
+				MFAToken:    u.GenerateMFAToken(),
+				ExpiresIn:   int(entity.MFATokenTTL.Seconds()),
+			},
+		}, nil
+	}
+
+	return us.startSession(u, payload.DeviceName, payload.IP, payload.UserAgent)
+}
+
+// rehashPassword upgrades the hash of a password that was just verified to
+// the configured algorithm and parameters.
+func (us *userService) rehashPassword(u *entity.User, password string) {
+
+	if !u.PasswordNeedsRehash() {
+		return
+	}
+
+	user := &entity.User{Password: password}
+
+	if err := user.HashPassword(); err != nil {
+		log.Errorf("error while rehashing password: %s", err.Error())
+		return
+	}
+
+	// the login goes on with the old hash if the upgrade can't be stored
+	if err := us.ur.Modify(u.ID, user); err != nil {
+		log.Errorf("error while rehashing password: %s", err.Error())
+		return
+	}
+
+	u.Password = user.Password
+}
+
+// checkPassword enforces the password policy on a new password.
+func (us *userService) checkPassword(password string) errs.Error {
+
+	if err := us.policy.Check(password); err != nil {
+		return errs.NewBadRequestError(err.Error())
+	}
+
+	return nil
+}
+
+// startSession opens a session for an authenticated user and returns its
+// access and refresh tokens.
+func (us *userService) startSession(u *entity.User, deviceName string, ip string, userAgent string) (*dto.UserResponse, errs.Error) {
+
+	jti, err := entity.RandomToken(16)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if deviceName == "" {
+		deviceName = userAgent
+	}
+
+	session := &entity.Session{
+		UserID:     u.ID,
+		Jti:        jti,
+		DeviceName: deviceName,
+		IP:         ip,
+		UserAgent:  userAgent,
+		LastSeenAt: time.Now(),
+	}
+
+	if err := us.sr.Add(session); err != nil {
+		return nil, err
+	}
+
+	u.SessionID = jti
+
+	refreshToken, raw, err := entity.NewRefreshToken(u.ID, jti)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := us.tkr.Add(refreshToken); err != nil {
+		return nil, err
 	}
 
 	return &dto.UserResponse{
 		Status:  http.StatusOK,
 		Message: "user successfully loged in",
-		Data: dto.Token{
-			TokenString: u.GenerateToken(),
-		},
+		Data:    token(u, raw),
+	}, nil
+}
+
+// Refresh implements UserService.
+func (us *userService) Refresh(payload *dto.RefreshToken) (*dto.UserResponse, errs.Error) {
+
+	rt, err := us.tkr.FetchByHash(entity.HashToken(payload.RefreshToken))
+
+	if err != nil {
+		if err.Status() == http.StatusNotFound {
+			return nil, errs.NewUnauthenticatedError("invalid refresh token")
+		}
+		return nil, err
+	}
+
+	if rt.RevokedAt != nil {
+		return nil, errs.NewUnauthenticatedError("invalid refresh token")
+	}
+
+	if rt.UsedAt != nil {
+		return nil, us.revokeReused(rt)
+	}
+
+	if rt.IsExpired(time.Now()) {
+		return nil, errs.NewUnauthenticatedError("refresh token has expired")
+	}
+
+	u, err := us.ur.FetchById(rt.UserID)
+
+	if err != nil {
+		if err.Status() == http.StatusNotFound {
+			return nil, errs.NewUnauthenticatedError("invalid refresh token")
+		}
+		return nil, err
+	}
+
+	u.SessionID = rt.FamilyID
+
+	next, raw, err := entity.NewRefreshToken(u.ID, rt.FamilyID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := us.tkr.Rotate(rt.ID, next); err != nil {
+		// another request exchanged the token first
+		if err.Status() == http.StatusConflict {
+			return nil, us.revokeReused(rt)
+		}
+		return nil, err
+	}
+
+	return &dto.UserResponse{
+		Status:  http.StatusOK,
+		Message: "token successfully refreshed",
+		Data:    token(u, raw),
+	}, nil
+}
+
+// Logout implements UserService.
+func (us *userService) Logout(sessionId string) (*dto.UserResponse, errs.Error) {
+
+	if err := us.sr.Revoke(sessionId); err != nil {
+		return nil, err
+	}
+
+	return &dto.UserResponse{
+		Status:  http.StatusOK,
+		Message: "user successfully loged out",
+		Data:    nil,
+	}, nil
+}
+
+// Sessions implements UserService.
+func (us *userService) Sessions(userId uint, sessionId string) (*dto.UserResponse, errs.Error) {
+
+	sessions, err := us.sr.FetchByUser(userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data := []*dto.Session{}
+
+	for _, eachSession := range sessions {
+		data = append(data, dto.EntityToSession(eachSession, sessionId))
+	}
+
+	return &dto.UserResponse{
+		Status:  http.StatusOK,
+		Message: "sessions successfully fetched",
+		Data:    data,
+	}, nil
+}
+
+// RevokeSession implements UserService.
+func (us *userService) RevokeSession(userId uint, sessionId uint) (*dto.UserResponse, errs.Error) {
+
+	session, err := us.sr.FetchById(sessionId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// sessions of other users are reported as missing so their ids can't
+	// be probed
+	if session.UserID != userId || session.IsRevoked() {
+		return nil, errs.NewNotFoundError("session not found")
+	}
+
+	if err := us.sr.Revoke(session.Jti); err != nil {
+		return nil, err
+	}
+
+	return &dto.UserResponse{
+		Status:  http.StatusOK,
+		Message: "session successfully revoked",
+		Data:    nil,
+	}, nil
+}
+
+// RevokeSessions implements UserService.
+func (us *userService) RevokeSessions(userId uint) (*dto.UserResponse, errs.Error) {
+
+	if err := us.sr.RevokeAll(userId, ""); err != nil {
+		return nil, err
+	}
+
+	if err := us.ptr.RevokeAll(userId); err != nil {
+		return nil, err
+	}
+
+	return &dto.UserResponse{
+		Status:  http.StatusOK,
+		Message: "user successfully loged out everywhere",
+		Data:    nil,
 	}, nil
 }
 
+// revokeReused revokes the session of a refresh token that was exchanged
+// twice together with all its tokens, since one of its holders isn't the user.
+func (us *userService) revokeReused(rt *entity.RefreshToken) errs.Error {
+
+	if err := us.sr.Revoke(rt.FamilyID); err != nil {
+		return err
+	}
+
+	return errs.NewUnauthenticatedError("refresh token reuse detected")
+}
+
+func token(u *entity.User, refreshToken string) dto.Token {
+	return dto.Token{
+		TokenString:  u.GenerateToken(),
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(entity.AccessTokenTTL().Seconds()),
+	}
+}
+
 // Modify implements UserService.
 func (us *userService) Modify(userId uint, payload *dto.Modify) (*dto.UserResponse, errs.Error) {
 
-	err := us.ur.Modify(userId, payload.ModifyToEntity())
+	if payload.SearchLanguage != "" && !entity.IsSearchLanguage(payload.SearchLanguage) {
+		return nil, errs.NewBadRequestError("search language must be one of " + strings.Join(entity.SearchLanguages, ", "))
+	}
+
+	u, err := us.ur.FetchById(userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	user := payload.ModifyToEntity()
+	message := "user successfully modified"
+
+	// a new email only replaces the current one once it is confirmed, until
+	// then the user keeps logging in with the current email
+	user.Email = ""
+
+	if payload.Email != u.Email {
+		owner, err := us.ur.FetchByEmail(payload.Email)
+
+		if err != nil && err.Status() != http.StatusNotFound {
+			return nil, err
+		}
+
+		if owner != nil {
+			return nil, errs.NewConflictError("email has been used")
+		}
+
+		user.PendingEmail = payload.Email
+		message = "user successfully modified, confirm the new email to apply it"
+	}
+
+	if err := us.ur.Modify(userId, user); err != nil {
+		return nil, err
+	}
+
+	if user.PendingEmail != "" {
+		if err := us.sendVerification(u.ID, u.Name, user.PendingEmail); err != nil {
+			return nil, err
+		}
+	}
+
+	return &dto.UserResponse{
+		Status:  http.StatusOK,
+		Message: message,
+		Data:    nil,
+	}, nil
+}
+
+// VerifyEmail implements UserService.
+func (us *userService) VerifyEmail(payload *dto.VerifyEmail) (*dto.UserResponse, errs.Error) {
+
+	invalidToken := errs.NewBadRequestError("invalid or expired email verification token")
+
+	verification, err := us.vr.FetchByHash(entity.HashToken(payload.Token))
+
+	if err != nil {
+		if err.Status() == http.StatusNotFound {
+			return nil, invalidToken
+		}
+		return nil, err
+	}
+
+	if !verification.IsValid(time.Now()) {
+		return nil, invalidToken
+	}
+
+	if err := us.vr.Consume(verification); err != nil {
+		if err.Status() == http.StatusNotFound {
+			return nil, invalidToken
+		}
+		return nil, err
+	}
+
+	return &dto.UserResponse{
+		Status:  http.StatusOK,
+		Message: "email successfully verified",
+		Data:    nil,
+	}, nil
+}
+
+// ResendVerification implements UserService.
+func (us *userService) ResendVerification(payload *dto.ResendVerification) (*dto.UserResponse, errs.Error) {
+
+	// the same response is returned whether the email is registered or not
+	res := &dto.UserResponse{
+		Status:  http.StatusOK,
+		Message: "a verification link has been sent if the email is registered and not verified",
+		Data:    nil,
+	}
+
+	u, err := us.ur.FetchByEmail(payload.Email)
+
+	if err != nil {
+		if err.Status() == http.StatusNotFound {
+			return res, nil
+		}
+		return nil, err
+	}
+
+	if u.IsVerified() {
+		return res, nil
+	}
+
+	if err := us.sendVerification(u.ID, u.Name, u.Email); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// sendVerification mails a token confirming the user owns email.
+func (us *userService) sendVerification(userId uint, name string, email string) errs.Error {
+
+	verification, raw, err := entity.NewEmailVerification(userId, email)
+
+	if err != nil {
+		return err
+	}
+
+	if err := us.vr.Add(verification); err != nil {
+		return err
+	}
+
+	us.mailVerification(name, email, raw)
+
+	return nil
+}
+
+// mailVerification mails the link confirming email with the raw token.
+func (us *userService) mailVerification(name string, email string, raw string) {
+
+	link := config.AppConfig().AppURL + "/verify-email?token=" + url.QueryEscape(raw)
+	body := fmt.Sprintf("Hi %s,\n\nUse the link below to confirm your email address, it expires in %d hours.\n\n%s",
+		name, config.AppConfig().EmailVerificationTTLHours, link)
+
+	go us.send(email, "Confirm your TodoKu email address", body)
+}
+
+// ChangePassword implements UserService.
+func (us *userService) ChangePassword(userId uint, sessionId string, payload *dto.ChangePassword) (*dto.UserResponse, errs.Error) {
+
+	u, err := us.ur.FetchById(userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !u.CompareHashPassword(payload.CurrentPassword) {
+		return nil, errs.NewBadRequestError("current password is incorrect")
+	}
+
+	if payload.NewPassword == payload.CurrentPassword {
+		return nil, errs.NewBadRequestError("new password must be different from the current password")
+	}
+
+	if err := us.checkPassword(payload.NewPassword); err != nil {
+		return nil, err
+	}
+
+	user := &entity.User{Password: payload.NewPassword}
+
+	if err := user.HashPassword(); err != nil {
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := us.ur.Modify(userId, user); err != nil {
+		return nil, err
+	}
+
+	// the current session stays logged in, every other one and every
+	// personal token may belong to whoever knew the old password
+	if err := us.sr.RevokeAll(userId, sessionId); err != nil {
+		return nil, err
+	}
+
+	if err := us.ptr.RevokeAll(userId); err != nil {
+		return nil, err
+	}
+
+	return &dto.UserResponse{
+		Status:  http.StatusOK,
+		Message: "password successfully changed",
+		Data:    nil,
+	}, nil
+}
+
+// ForgotPassword implements UserService.
+func (us *userService) ForgotPassword(payload *dto.ForgotPassword) (*dto.UserResponse, errs.Error) {
+
+	// the same response is returned whether the email is registered or not
+	res := &dto.UserResponse{
+		Status:  http.StatusOK,
+		Message: "a password reset link has been sent if the email is registered",
+		Data:    nil,
+	}
+
+	u, err := us.ur.FetchByEmail(payload.Email)
+
+	if err != nil {
+		if err.Status() == http.StatusNotFound {
+			return res, nil
+		}
+		return nil, err
+	}
+
+	reset, raw, err := entity.NewPasswordReset(u.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := us.rr.Add(reset); err != nil {
+		return nil, err
+	}
+
+	link := config.AppConfig().AppURL + "/reset-password?token=" + url.QueryEscape(raw)
+	body := fmt.Sprintf("Hi %s,\n\nUse the link below to reset your password, it expires in %d minutes.\n\n%s\n\nIgnore this email if you didn't ask for a password reset.",
+		u.Name, config.AppConfig().PasswordResetTTLMinutes, link)
+
+	// mails are sent in the background so the response time doesn't tell
+	// registered emails apart
+	go us.send(u.Email, "Reset your TodoKu password", body)
+
+	return res, nil
+}
+
+// ResetPassword implements UserService.
+func (us *userService) ResetPassword(payload *dto.ResetPassword) (*dto.UserResponse, errs.Error) {
+
+	invalidToken := errs.NewBadRequestError("invalid or expired password reset token")
+
+	reset, err := us.rr.FetchByHash(entity.HashToken(payload.Token))
 
 	if err != nil {
+		if err.Status() == http.StatusNotFound {
+			return nil, invalidToken
+		}
+		return nil, err
+	}
+
+	if !reset.IsValid(time.Now()) {
+		return nil, invalidToken
+	}
+
+	if err := us.checkPassword(payload.NewPassword); err != nil {
+		return nil, err
+	}
+
+	user := &entity.User{Password: payload.NewPassword}
+
+	if err := user.HashPassword(); err != nil {
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	if err := us.rr.Consume(reset.ID, reset.UserID, user.Password); err != nil {
+		if err.Status() == http.StatusConflict {
+			return nil, invalidToken
+		}
+		return nil, err
+	}
+
+	if err := us.sr.RevokeAll(reset.UserID, ""); err != nil {
+		return nil, err
+	}
+
+	if err := us.ptr.RevokeAll(reset.UserID); err != nil {
 		return nil, err
 	}
 
 	return &dto.UserResponse{
 		Status:  http.StatusOK,
-		Message: "user successfully modified",
+		Message: "password successfully reset",
 		Data:    nil,
 	}, nil
 }
 
+func (us *userService) send(to string, subject string, body string) {
+	if err := us.m.Send(to, subject, body); err != nil {
+		log.Errorf("error while sending mail: %s", err.Error())
+	}
+}
+
 // Profile implements UserService.
 func (us *userService) Profile(userId uint) (*dto.UserResponse, errs.Error) {
 
@@ -78,9 +654,13 @@ func (us *userService) Profile(userId uint) (*dto.UserResponse, errs.Error) {
 		Status:  http.StatusOK,
 		Message: "user successfully fetched",
 		Data: dto.Profile{
-			Id:    u.ID,
-			Name:  u.Name,
-			Email: u.Email,
+			Id:             u.ID,
+			Name:           u.Name,
+			Email:          u.Email,
+			SearchLanguage: u.SearchLanguage,
+			EmailVerified:  u.IsVerified(),
+			PendingEmail:   u.PendingEmail,
+			TOTPEnabled:    u.HasTOTP(),
 		},
 	}, nil
 }
@@ -88,15 +668,28 @@ func (us *userService) Profile(userId uint) (*dto.UserResponse, errs.Error) {
 // Register implements UserService.
 func (us *userService) Register(payload *dto.Register) (*dto.UserResponse, errs.Error) {
 
+	if err := us.checkPassword(payload.Password); err != nil {
+		return nil, err
+	}
+
 	user := payload.RegisterToEntity()
-	user.HashPassword()
 
-	err := us.ur.Add(user)
+	if err := user.HashPassword(); err != nil {
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	verification, raw, err := entity.NewEmailVerification(0, user.Email)
 
 	if err != nil {
 		return nil, err
 	}
 
+	if err := us.ur.Add(user, verification); err != nil {
+		return nil, err
+	}
+
+	us.mailVerification(user.Name, user.Email, raw)
+
 	return &dto.UserResponse{
 		Status:  http.StatusCreated,
 		Message: "user successfully created",
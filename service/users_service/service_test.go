@@ -2,19 +2,66 @@ package users_service_test
 
 import (
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 	"todo-app/dto"
 	"todo-app/entity"
 	"todo-app/pkg/errs"
+	"todo-app/pkg/mailer"
+	"todo-app/pkg/password"
+	"todo-app/pkg/totp"
+	"todo-app/repo/attempts_repo"
+	"todo-app/repo/mfa_repo"
+	"todo-app/repo/personal_tokens_repo"
+	"todo-app/repo/resets_repo"
+	"todo-app/repo/sessions_repo"
+	"todo-app/repo/tokens_repo"
 	"todo-app/repo/users_repo"
+	"todo-app/repo/verifications_repo"
 	"todo-app/service/users_service"
 
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
 var repoMock = users_repo.NewRepoMock()
-var service = users_service.NewUserService(repoMock)
+var tokenRepoMock = tokens_repo.NewRepoMock()
+var sessionRepoMock = sessions_repo.NewRepoMock()
+var resetRepoMock = resets_repo.NewRepoMock()
+var verificationRepoMock = verifications_repo.NewRepoMock()
+var attemptRepoMock = attempts_repo.NewRepoMock()
+var mfaRepoMock = mfa_repo.NewRepoMock()
+var personalTokenRepoMock = personal_tokens_repo.NewRepoMock()
+var mailerMock = mailer.NewMailerMock()
+var lockouts = make(chan *entity.User, 1)
+var service = users_service.NewUserService(repoMock, tokenRepoMock, sessionRepoMock, resetRepoMock, verificationRepoMock, attemptRepoMock, mfaRepoMock, personalTokenRepoMock, mailerMock, password.NewPolicy(6, 128, []string{"password"}),
+	func(user *entity.User, ip string, until time.Time) {
+		lockouts <- user
+	})
+
+// hash hashes a password with the configured hasher.
+func hash(password string) string {
+	u := &entity.User{Password: password}
+	u.HashPassword()
+	return u.Password
+}
+
+// allowAttempts lets every login through the brute force protection.
+func allowAttempts() {
+	attempts_repo.Fetch = func(key string) (*entity.LoginAttempt, errs.Error) {
+		return &entity.LoginAttempt{Key: key}, nil
+	}
+
+	attempts_repo.Fail = func(key string, maxFailures int, now time.Time) (*entity.LoginAttempt, errs.Error) {
+		return &entity.LoginAttempt{Key: key, Failures: 1}, nil
+	}
+
+	attempts_repo.Reset = func(keys ...string) errs.Error {
+		return nil
+	}
+}
 
 var register = &dto.Register{
 	Name:     "Jihan",
@@ -35,7 +82,12 @@ var modify = &dto.Modify{
 var userId = 1
 
 func TestAddUserSuccess(t *testing.T) {
-	users_repo.Add = func(user *entity.User) errs.Error {
+	users_repo.Add = func(user *entity.User, verification *entity.EmailVerification) errs.Error {
+		assert.Equal(t, register.Email, verification.Email)
+		return nil
+	}
+
+	mailer.Send = func(to string, subject string, body string) error {
 		return nil
 	}
 
@@ -47,7 +99,7 @@ func TestAddUserSuccess(t *testing.T) {
 }
 
 func TestAddUserServerError(t *testing.T) {
-	users_repo.Add = func(user *entity.User) errs.Error {
+	users_repo.Add = func(user *entity.User, verification *entity.EmailVerification) errs.Error {
 		return errs.NewInternalServerError("something went wrong")
 	}
 
@@ -59,7 +111,7 @@ func TestAddUserServerError(t *testing.T) {
 }
 
 func TestAddUserConflict(t *testing.T) {
-	users_repo.Add = func(user *entity.User) errs.Error {
+	users_repo.Add = func(user *entity.User, verification *entity.EmailVerification) errs.Error {
 		return errs.NewConflictError("email has been used")
 	}
 
@@ -107,6 +159,10 @@ func TestProfileServerError(t *testing.T) {
 }
 
 func TestModifySuccess(t *testing.T) {
+	users_repo.FetchById = func(userId uint) (*entity.User, errs.Error) {
+		return &entity.User{Email: modify.Email}, nil
+	}
+
 	users_repo.Modify = func(userId uint, user *entity.User) errs.Error {
 		return nil
 	}
@@ -119,6 +175,10 @@ func TestModifySuccess(t *testing.T) {
 }
 
 func TestModifyServerError(t *testing.T) {
+	users_repo.FetchById = func(userId uint) (*entity.User, errs.Error) {
+		return &entity.User{Email: modify.Email}, nil
+	}
+
 	users_repo.Modify = func(userId uint, user *entity.User) errs.Error {
 		return errs.NewInternalServerError("something went wrong")
 	}
@@ -131,6 +191,8 @@ func TestModifyServerError(t *testing.T) {
 }
 
 func TestLoginNotFound(t *testing.T) {
+	allowAttempts()
+
 	users_repo.FetchByEmail = func(email string) (*entity.User, errs.Error) {
 		return nil, errs.NewNotFoundError("user not found")
 	}
@@ -143,6 +205,8 @@ func TestLoginNotFound(t *testing.T) {
 }
 
 func TestLoginServerError(t *testing.T) {
+	allowAttempts()
+
 	users_repo.FetchByEmail = func(email string) (*entity.User, errs.Error) {
 		return nil, errs.NewInternalServerError("something went wrong")
 	}
@@ -155,8 +219,10 @@ func TestLoginServerError(t *testing.T) {
 }
 
 func TestLoginInvalidPassword(t *testing.T) {
+	allowAttempts()
+
 	users_repo.FetchByEmail = func(email string) (*entity.User, errs.Error) {
-		hashPassword, _ := bcrypt.GenerateFromPassword([]byte(login.Password), bcrypt.DefaultCost)
+		hashPassword := hash(login.Password)
 
 		return &entity.User{
 			Password: string(hashPassword),
@@ -171,17 +237,1522 @@ func TestLoginInvalidPassword(t *testing.T) {
 }
 
 func TestLoginSuccess(t *testing.T) {
+	allowAttempts()
+
+	users_repo.FetchByEmail = func(email string) (*entity.User, errs.Error) {
+		hashPassword := hash(login.Password)
+
+		return &entity.User{
+			Password: string(hashPassword),
+		}, nil
+	}
+
+	jti := ""
+
+	sessions_repo.Add = func(session *entity.Session) errs.Error {
+		jti = session.Jti
+		return nil
+	}
+
+	tokens_repo.Add = func(token *entity.RefreshToken) errs.Error {
+		assert.Equal(t, jti, token.FamilyID)
+		return nil
+	}
+
+	ur, err := service.Login(&dto.Login{
+		Email:     login.Email,
+		Password:  login.Password,
+		UserAgent: "Mozilla/5.0",
+	})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ur)
+	assert.Equal(t, http.StatusOK, ur.Status)
+
+	token := ur.Data.(dto.Token)
+
+	assert.NotEmpty(t, token.TokenString)
+	assert.NotEmpty(t, token.RefreshToken)
+	assert.Equal(t, 15*60, token.ExpiresIn)
+}
+
+func TestLoginTokenServerError(t *testing.T) {
+	allowAttempts()
+
+	users_repo.FetchByEmail = func(email string) (*entity.User, errs.Error) {
+		hashPassword := hash(login.Password)
+
+		return &entity.User{
+			Password: string(hashPassword),
+		}, nil
+	}
+
+	sessions_repo.Add = func(session *entity.Session) errs.Error {
+		return nil
+	}
+
+	tokens_repo.Add = func(token *entity.RefreshToken) errs.Error {
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	ur, err := service.Login(login)
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusInternalServerError, err.Status())
+}
+
+func TestModifyInvalidSearchLanguage(t *testing.T) {
+	ur, err := service.Modify(uint(userId), &dto.Modify{
+		Name:           "Jihan Weeekly",
+		Email:          "jihan@weeekly.com",
+		SearchLanguage: "klingon",
+	})
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.Status())
+}
+
+func TestModifySearchLanguageSuccess(t *testing.T) {
+	users_repo.FetchById = func(userId uint) (*entity.User, errs.Error) {
+		return &entity.User{Email: modify.Email}, nil
+	}
+
+	users_repo.Modify = func(userId uint, user *entity.User) errs.Error {
+		assert.Equal(t, "indonesian", user.SearchLanguage)
+		return nil
+	}
+
+	ur, err := service.Modify(uint(userId), &dto.Modify{
+		Name:           "Jihan Weeekly",
+		Email:          "jihan@weeekly.com",
+		SearchLanguage: "indonesian",
+	})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ur)
+	assert.Equal(t, http.StatusOK, ur.Status)
+}
+
+var refreshToken = &dto.RefreshToken{RefreshToken: "refresh-token"}
+
+func TestRefreshSuccess(t *testing.T) {
+	tokens_repo.FetchByHash = func(tokenHash string) (*entity.RefreshToken, errs.Error) {
+		assert.Equal(t, entity.HashToken(refreshToken.RefreshToken), tokenHash)
+
+		return &entity.RefreshToken{
+			UserID:    1,
+			FamilyID:  "family",
+			ExpiresAt: time.Now().Add(time.Hour),
+		}, nil
+	}
+
+	users_repo.FetchById = func(userId uint) (*entity.User, errs.Error) {
+		return &entity.User{}, nil
+	}
+
+	tokens_repo.Rotate = func(tokenId uint, next *entity.RefreshToken) errs.Error {
+		assert.Equal(t, "family", next.FamilyID)
+		return nil
+	}
+
+	ur, err := service.Refresh(refreshToken)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ur)
+	assert.Equal(t, http.StatusOK, ur.Status)
+	assert.NotEqual(t, refreshToken.RefreshToken, ur.Data.(dto.Token).RefreshToken)
+}
+
+func TestRefreshNotFound(t *testing.T) {
+	tokens_repo.FetchByHash = func(tokenHash string) (*entity.RefreshToken, errs.Error) {
+		return nil, errs.NewNotFoundError("refresh token not found")
+	}
+
+	ur, err := service.Refresh(refreshToken)
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusUnauthorized, err.Status())
+}
+
+func TestRefreshExpired(t *testing.T) {
+	tokens_repo.FetchByHash = func(tokenHash string) (*entity.RefreshToken, errs.Error) {
+		return &entity.RefreshToken{
+			ExpiresAt: time.Now().Add(-time.Hour),
+		}, nil
+	}
+
+	ur, err := service.Refresh(refreshToken)
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusUnauthorized, err.Status())
+}
+
+func TestRefreshRevoked(t *testing.T) {
+	tokens_repo.FetchByHash = func(tokenHash string) (*entity.RefreshToken, errs.Error) {
+		now := time.Now()
+
+		return &entity.RefreshToken{
+			ExpiresAt: now.Add(time.Hour),
+			UsedAt:    &now,
+			RevokedAt: &now,
+		}, nil
+	}
+
+	sessions_repo.Revoke = func(jti string) errs.Error {
+		t.Fatal("revoked token must not revoke its session again")
+		return nil
+	}
+
+	ur, err := service.Refresh(refreshToken)
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusUnauthorized, err.Status())
+}
+
+func TestRefreshReuseRevokesFamily(t *testing.T) {
+	tokens_repo.FetchByHash = func(tokenHash string) (*entity.RefreshToken, errs.Error) {
+		usedAt := time.Now()
+
+		return &entity.RefreshToken{
+			FamilyID:  "family",
+			ExpiresAt: time.Now().Add(time.Hour),
+			UsedAt:    &usedAt,
+		}, nil
+	}
+
+	revoked := ""
+
+	sessions_repo.Revoke = func(jti string) errs.Error {
+		revoked = jti
+		return nil
+	}
+
+	ur, err := service.Refresh(refreshToken)
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusUnauthorized, err.Status())
+	assert.Equal(t, "family", revoked)
+}
+
+func TestRefreshConcurrentReuseRevokesFamily(t *testing.T) {
+	tokens_repo.FetchByHash = func(tokenHash string) (*entity.RefreshToken, errs.Error) {
+		return &entity.RefreshToken{
+			FamilyID:  "family",
+			ExpiresAt: time.Now().Add(time.Hour),
+		}, nil
+	}
+
+	users_repo.FetchById = func(userId uint) (*entity.User, errs.Error) {
+		return &entity.User{}, nil
+	}
+
+	tokens_repo.Rotate = func(tokenId uint, next *entity.RefreshToken) errs.Error {
+		return errs.NewConflictError("refresh token has been used")
+	}
+
+	revoked := ""
+
+	sessions_repo.Revoke = func(jti string) errs.Error {
+		revoked = jti
+		return nil
+	}
+
+	ur, err := service.Refresh(refreshToken)
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusUnauthorized, err.Status())
+	assert.Equal(t, "family", revoked)
+}
+
+func TestLoginSessionServerError(t *testing.T) {
+	allowAttempts()
+
 	users_repo.FetchByEmail = func(email string) (*entity.User, errs.Error) {
-		hashPassword, _ := bcrypt.GenerateFromPassword([]byte(login.Password), bcrypt.DefaultCost)
+		hashPassword := hash(login.Password)
 
 		return &entity.User{
 			Password: string(hashPassword),
 		}, nil
 	}
 
+	sessions_repo.Add = func(session *entity.Session) errs.Error {
+		return errs.NewInternalServerError("something went wrong")
+	}
+
 	ur, err := service.Login(login)
 
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusInternalServerError, err.Status())
+}
+
+func TestLoginDeviceNameFallsBackToUserAgent(t *testing.T) {
+	allowAttempts()
+
+	users_repo.FetchByEmail = func(email string) (*entity.User, errs.Error) {
+		hashPassword := hash(login.Password)
+
+		return &entity.User{
+			Password: string(hashPassword),
+		}, nil
+	}
+
+	sessions_repo.Add = func(session *entity.Session) errs.Error {
+		assert.Equal(t, "Mozilla/5.0", session.DeviceName)
+		assert.Equal(t, "10.0.0.1", session.IP)
+		return nil
+	}
+
+	tokens_repo.Add = func(token *entity.RefreshToken) errs.Error {
+		return nil
+	}
+
+	ur, err := service.Login(&dto.Login{
+		Email:     login.Email,
+		Password:  login.Password,
+		IP:        "10.0.0.1",
+		UserAgent: "Mozilla/5.0",
+	})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ur)
+}
+
+func TestLogoutSuccess(t *testing.T) {
+	revoked := ""
+
+	sessions_repo.Revoke = func(jti string) errs.Error {
+		revoked = jti
+		return nil
+	}
+
+	ur, err := service.Logout("session")
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ur)
+	assert.Equal(t, http.StatusOK, ur.Status)
+	assert.Equal(t, "session", revoked)
+}
+
+func TestLogoutServerError(t *testing.T) {
+	sessions_repo.Revoke = func(jti string) errs.Error {
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	ur, err := service.Logout("session")
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusInternalServerError, err.Status())
+}
+
+func TestSessionsSuccess(t *testing.T) {
+	sessions_repo.FetchByUser = func(userId uint) ([]*entity.Session, errs.Error) {
+		return []*entity.Session{{Jti: "current"}, {Jti: "other"}}, nil
+	}
+
+	ur, err := service.Sessions(uint(userId), "current")
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ur)
+	assert.Equal(t, http.StatusOK, ur.Status)
+
+	sessions := ur.Data.([]*dto.Session)
+
+	assert.True(t, sessions[0].Current)
+	assert.False(t, sessions[1].Current)
+}
+
+func TestSessionsServerError(t *testing.T) {
+	sessions_repo.FetchByUser = func(userId uint) ([]*entity.Session, errs.Error) {
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	ur, err := service.Sessions(uint(userId), "current")
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusInternalServerError, err.Status())
+}
+
+func TestRevokeSessionSuccess(t *testing.T) {
+	sessions_repo.FetchById = func(sessionId uint) (*entity.Session, errs.Error) {
+		return &entity.Session{UserID: uint(userId), Jti: "other"}, nil
+	}
+
+	revoked := ""
+
+	sessions_repo.Revoke = func(jti string) errs.Error {
+		revoked = jti
+		return nil
+	}
+
+	ur, err := service.RevokeSession(uint(userId), 2)
+
 	assert.Nil(t, err)
 	assert.NotNil(t, ur)
 	assert.Equal(t, http.StatusOK, ur.Status)
+	assert.Equal(t, "other", revoked)
+}
+
+func TestRevokeSessionOtherUser(t *testing.T) {
+	sessions_repo.FetchById = func(sessionId uint) (*entity.Session, errs.Error) {
+		return &entity.Session{UserID: 2, Jti: "other"}, nil
+	}
+
+	sessions_repo.Revoke = func(jti string) errs.Error {
+		t.Fatal("session of another user must not be revoked")
+		return nil
+	}
+
+	ur, err := service.RevokeSession(uint(userId), 2)
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.Status())
+}
+
+func TestRevokeSessionAlreadyRevoked(t *testing.T) {
+	sessions_repo.FetchById = func(sessionId uint) (*entity.Session, errs.Error) {
+		revokedAt := time.Now()
+		return &entity.Session{UserID: uint(userId), RevokedAt: &revokedAt}, nil
+	}
+
+	ur, err := service.RevokeSession(uint(userId), 2)
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.Status())
+}
+
+func TestRevokeSessionsSuccess(t *testing.T) {
+	sessions_repo.RevokeAll = func(userId uint, exceptJti string) errs.Error {
+		assert.Empty(t, exceptJti)
+		return nil
+	}
+
+	revoked := uint(0)
+
+	personal_tokens_repo.RevokeAll = func(userId uint) errs.Error {
+		revoked = userId
+		return nil
+	}
+
+	ur, err := service.RevokeSessions(uint(userId))
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ur)
+	assert.Equal(t, http.StatusOK, ur.Status)
+	assert.Equal(t, uint(userId), revoked)
+}
+
+func TestRevokeSessionsPersonalTokensError(t *testing.T) {
+	sessions_repo.RevokeAll = func(userId uint, exceptJti string) errs.Error {
+		return nil
+	}
+
+	personal_tokens_repo.RevokeAll = func(userId uint) errs.Error {
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	ur, err := service.RevokeSessions(uint(userId))
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusInternalServerError, err.Status())
+}
+
+var changePassword = &dto.ChangePassword{
+	CurrentPassword: "secret",
+	NewPassword:     "new-secret",
+}
+
+func TestChangePasswordSuccess(t *testing.T) {
+	users_repo.FetchById = func(userId uint) (*entity.User, errs.Error) {
+		hashPassword := hash(changePassword.CurrentPassword)
+		return &entity.User{Password: string(hashPassword)}, nil
+	}
+
+	users_repo.Modify = func(userId uint, user *entity.User) errs.Error {
+		assert.True(t, user.CompareHashPassword(changePassword.NewPassword))
+		return nil
+	}
+
+	sessions_repo.RevokeAll = func(userId uint, exceptJti string) errs.Error {
+		assert.Equal(t, "current", exceptJti)
+		return nil
+	}
+
+	revoked := uint(0)
+
+	personal_tokens_repo.RevokeAll = func(userId uint) errs.Error {
+		revoked = userId
+		return nil
+	}
+
+	ur, err := service.ChangePassword(uint(userId), "current", changePassword)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ur)
+	assert.Equal(t, http.StatusOK, ur.Status)
+	assert.Equal(t, uint(userId), revoked)
+}
+
+func TestChangePasswordIncorrectCurrent(t *testing.T) {
+	users_repo.FetchById = func(userId uint) (*entity.User, errs.Error) {
+		hashPassword := hash("other")
+		return &entity.User{Password: string(hashPassword)}, nil
+	}
+
+	users_repo.Modify = func(userId uint, user *entity.User) errs.Error {
+		t.Fatal("password must not be changed")
+		return nil
+	}
+
+	ur, err := service.ChangePassword(uint(userId), "current", changePassword)
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.Status())
+}
+
+func TestChangePasswordSamePassword(t *testing.T) {
+	users_repo.FetchById = func(userId uint) (*entity.User, errs.Error) {
+		hashPassword := hash(changePassword.CurrentPassword)
+		return &entity.User{Password: string(hashPassword)}, nil
+	}
+
+	ur, err := service.ChangePassword(uint(userId), "current", &dto.ChangePassword{
+		CurrentPassword: changePassword.CurrentPassword,
+		NewPassword:     changePassword.CurrentPassword,
+	})
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.Status())
+}
+
+func TestChangePasswordServerError(t *testing.T) {
+	users_repo.FetchById = func(userId uint) (*entity.User, errs.Error) {
+		hashPassword := hash(changePassword.CurrentPassword)
+		return &entity.User{Password: string(hashPassword)}, nil
+	}
+
+	users_repo.Modify = func(userId uint, user *entity.User) errs.Error {
+		return errs.NewInternalServerError("something went wrong")
+	}
+
+	ur, err := service.ChangePassword(uint(userId), "current", changePassword)
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusInternalServerError, err.Status())
+}
+
+func TestAddUserHashError(t *testing.T) {
+	users_repo.Add = func(user *entity.User, verification *entity.EmailVerification) errs.Error {
+		t.Fatal("user must not be added")
+		return nil
+	}
+
+	// bcrypt rejects passwords longer than 72 bytes
+	entity.UsePasswordHasher(password.NewBcryptHasher(bcrypt.DefaultCost))
+	defer entity.UsePasswordHasher(password.NewArgon2idHasher(password.DefaultArgon2Params))
+
+	ur, err := service.Register(&dto.Register{
+		Name:     register.Name,
+		Email:    register.Email,
+		Password: strings.Repeat("a", 73),
+	})
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusInternalServerError, err.Status())
+}
+
+func TestForgotPasswordSuccess(t *testing.T) {
+	users_repo.FetchByEmail = func(email string) (*entity.User, errs.Error) {
+		return &entity.User{Model: gorm.Model{ID: 1}, Email: email}, nil
+	}
+
+	tokenHash := ""
+
+	resets_repo.Add = func(reset *entity.PasswordReset) errs.Error {
+		tokenHash = reset.TokenHash
+		return nil
+	}
+
+	sent := make(chan string, 1)
+
+	mailer.Send = func(to string, subject string, body string) error {
+		sent <- body
+		return nil
+	}
+
+	ur, err := service.ForgotPassword(&dto.ForgotPassword{Email: login.Email})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ur)
+	assert.Equal(t, http.StatusOK, ur.Status)
+
+	select {
+	case body := <-sent:
+		_, token, _ := strings.Cut(body, "token=")
+		token, _, _ = strings.Cut(token, "\n")
+
+		assert.Equal(t, tokenHash, entity.HashToken(token))
+	case <-time.After(time.Second):
+		t.Fatal("reset mail wasn't sent")
+	}
+}
+
+func TestForgotPasswordUnknownEmail(t *testing.T) {
+	users_repo.FetchByEmail = func(email string) (*entity.User, errs.Error) {
+		return nil, errs.NewNotFoundError("user not found")
+	}
+
+	resets_repo.Add = func(reset *entity.PasswordReset) errs.Error {
+		t.Fatal("reset must not be added for an unknown email")
+		return nil
+	}
+
+	ur, err := service.ForgotPassword(&dto.ForgotPassword{Email: "unknown@weeekly.com"})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ur)
+	assert.Equal(t, http.StatusOK, ur.Status)
+	assert.Equal(t, "a password reset link has been sent if the email is registered", ur.Message)
+}
+
+func TestForgotPasswordServerError(t *testing.T) {
+	users_repo.FetchByEmail = func(email string) (*entity.User, errs.Error) {
+		return nil, errs.NewInternalServerError("something went wrong")
+	}
+
+	ur, err := service.ForgotPassword(&dto.ForgotPassword{Email: login.Email})
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusInternalServerError, err.Status())
+}
+
+var resetPassword = &dto.ResetPassword{
+	Token:       "reset-token",
+	NewPassword: "new-secret",
+}
+
+func TestResetPasswordSuccess(t *testing.T) {
+	resets_repo.FetchByHash = func(tokenHash string) (*entity.PasswordReset, errs.Error) {
+		assert.Equal(t, entity.HashToken(resetPassword.Token), tokenHash)
+		return &entity.PasswordReset{UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}, nil
+	}
+
+	resets_repo.Consume = func(resetId uint, userId uint, password string) errs.Error {
+		user := &entity.User{Password: password}
+		assert.True(t, user.CompareHashPassword(resetPassword.NewPassword))
+		return nil
+	}
+
+	sessions_repo.RevokeAll = func(userId uint, exceptJti string) errs.Error {
+		assert.Empty(t, exceptJti)
+		return nil
+	}
+
+	revoked := uint(0)
+
+	personal_tokens_repo.RevokeAll = func(userId uint) errs.Error {
+		revoked = userId
+		return nil
+	}
+
+	ur, err := service.ResetPassword(resetPassword)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ur)
+	assert.Equal(t, http.StatusOK, ur.Status)
+	assert.Equal(t, uint(1), revoked)
+}
+
+func TestResetPasswordInvalidToken(t *testing.T) {
+	resets_repo.FetchByHash = func(tokenHash string) (*entity.PasswordReset, errs.Error) {
+		return nil, errs.NewNotFoundError("password reset not found")
+	}
+
+	ur, err := service.ResetPassword(resetPassword)
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.Status())
+}
+
+func TestResetPasswordExpiredToken(t *testing.T) {
+	resets_repo.FetchByHash = func(tokenHash string) (*entity.PasswordReset, errs.Error) {
+		return &entity.PasswordReset{UserID: 1, ExpiresAt: time.Now().Add(-time.Minute)}, nil
+	}
+
+	ur, err := service.ResetPassword(resetPassword)
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.Status())
+}
+
+func TestResetPasswordUsedToken(t *testing.T) {
+	resets_repo.FetchByHash = func(tokenHash string) (*entity.PasswordReset, errs.Error) {
+		return &entity.PasswordReset{UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}, nil
+	}
+
+	resets_repo.Consume = func(resetId uint, userId uint, password string) errs.Error {
+		return errs.NewConflictError("password reset has been used")
+	}
+
+	ur, err := service.ResetPassword(resetPassword)
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.Status())
+}
+
+func TestModifyEmailPendsUntilVerified(t *testing.T) {
+	users_repo.FetchById = func(userId uint) (*entity.User, errs.Error) {
+		return &entity.User{Email: "jihan@weeekly.com"}, nil
+	}
+
+	users_repo.FetchByEmail = func(email string) (*entity.User, errs.Error) {
+		return nil, errs.NewNotFoundError("user not found")
+	}
+
+	users_repo.Modify = func(userId uint, user *entity.User) errs.Error {
+		assert.Empty(t, user.Email)
+		assert.Equal(t, "jihan@weeekly.id", user.PendingEmail)
+		return nil
+	}
+
+	verifications_repo.Add = func(verification *entity.EmailVerification) errs.Error {
+		assert.Equal(t, "jihan@weeekly.id", verification.Email)
+		return nil
+	}
+
+	sent := make(chan string, 1)
+
+	mailer.Send = func(to string, subject string, body string) error {
+		sent <- to
+		return nil
+	}
+
+	ur, err := service.Modify(uint(userId), &dto.Modify{
+		Name:  "Jihan Weeekly",
+		Email: "jihan@weeekly.id",
+	})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ur)
+	assert.Equal(t, http.StatusOK, ur.Status)
+
+	select {
+	case to := <-sent:
+		assert.Equal(t, "jihan@weeekly.id", to)
+	case <-time.After(time.Second):
+		t.Fatal("verification mail wasn't sent")
+	}
+}
+
+func TestModifyEmailConflict(t *testing.T) {
+	users_repo.FetchById = func(userId uint) (*entity.User, errs.Error) {
+		return &entity.User{Email: "jihan@weeekly.com"}, nil
+	}
+
+	users_repo.FetchByEmail = func(email string) (*entity.User, errs.Error) {
+		return &entity.User{Email: email}, nil
+	}
+
+	users_repo.Modify = func(userId uint, user *entity.User) errs.Error {
+		t.Fatal("user must not be modified")
+		return nil
+	}
+
+	ur, err := service.Modify(uint(userId), &dto.Modify{
+		Name:  "Jihan Weeekly",
+		Email: "soojin@weeekly.com",
+	})
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusConflict, err.Status())
+}
+
+func TestVerifyEmailSuccess(t *testing.T) {
+	verifications_repo.FetchByHash = func(tokenHash string) (*entity.EmailVerification, errs.Error) {
+		assert.Equal(t, entity.HashToken("verify-token"), tokenHash)
+		return &entity.EmailVerification{UserID: 1, Email: "jihan@weeekly.com", ExpiresAt: time.Now().Add(time.Hour)}, nil
+	}
+
+	verifications_repo.Consume = func(verification *entity.EmailVerification) errs.Error {
+		return nil
+	}
+
+	ur, err := service.VerifyEmail(&dto.VerifyEmail{Token: "verify-token"})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ur)
+	assert.Equal(t, http.StatusOK, ur.Status)
+}
+
+func TestVerifyEmailExpiredToken(t *testing.T) {
+	verifications_repo.FetchByHash = func(tokenHash string) (*entity.EmailVerification, errs.Error) {
+		return &entity.EmailVerification{UserID: 1, ExpiresAt: time.Now().Add(-time.Minute)}, nil
+	}
+
+	ur, err := service.VerifyEmail(&dto.VerifyEmail{Token: "verify-token"})
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.Status())
+}
+
+func TestVerifyEmailStaleToken(t *testing.T) {
+	verifications_repo.FetchByHash = func(tokenHash string) (*entity.EmailVerification, errs.Error) {
+		return &entity.EmailVerification{UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}, nil
+	}
+
+	verifications_repo.Consume = func(verification *entity.EmailVerification) errs.Error {
+		return errs.NewNotFoundError("email verification not found")
+	}
+
+	ur, err := service.VerifyEmail(&dto.VerifyEmail{Token: "verify-token"})
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.Status())
+}
+
+func TestVerifyEmailConflict(t *testing.T) {
+	verifications_repo.FetchByHash = func(tokenHash string) (*entity.EmailVerification, errs.Error) {
+		return &entity.EmailVerification{UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}, nil
+	}
+
+	verifications_repo.Consume = func(verification *entity.EmailVerification) errs.Error {
+		return errs.NewConflictError("email has been used")
+	}
+
+	ur, err := service.VerifyEmail(&dto.VerifyEmail{Token: "verify-token"})
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusConflict, err.Status())
+}
+
+func TestResendVerificationVerifiedAccount(t *testing.T) {
+	users_repo.FetchByEmail = func(email string) (*entity.User, errs.Error) {
+		verifiedAt := time.Now()
+		return &entity.User{Email: email, EmailVerifiedAt: &verifiedAt}, nil
+	}
+
+	verifications_repo.Add = func(verification *entity.EmailVerification) errs.Error {
+		t.Fatal("verified account must not get a new verification")
+		return nil
+	}
+
+	ur, err := service.ResendVerification(&dto.ResendVerification{Email: login.Email})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ur)
+	assert.Equal(t, http.StatusOK, ur.Status)
+}
+
+func TestResendVerificationUnknownEmail(t *testing.T) {
+	users_repo.FetchByEmail = func(email string) (*entity.User, errs.Error) {
+		return nil, errs.NewNotFoundError("user not found")
+	}
+
+	ur, err := service.ResendVerification(&dto.ResendVerification{Email: "unknown@weeekly.com"})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ur)
+	assert.Equal(t, http.StatusOK, ur.Status)
+}
+
+func TestLoginUnverifiedDenied(t *testing.T) {
+	allowAttempts()
+
+	t.Setenv("UNVERIFIED_ACCOUNT_POLICY", "deny")
+
+	users_repo.FetchByEmail = func(email string) (*entity.User, errs.Error) {
+		hashPassword := hash(login.Password)
+
+		return &entity.User{
+			Password: string(hashPassword),
+		}, nil
+	}
+
+	ur, err := service.Login(login)
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusForbidden, err.Status())
+}
+
+func TestLoginBlocked(t *testing.T) {
+	allowAttempts()
+
+	attempts_repo.Fetch = func(key string) (*entity.LoginAttempt, errs.Error) {
+		return &entity.LoginAttempt{Key: key, BlockedUntil: time.Now().Add(30 * time.Second)}, nil
+	}
+
+	users_repo.FetchByEmail = func(email string) (*entity.User, errs.Error) {
+		t.Fatal("blocked login must not check the password")
+		return nil, nil
+	}
+
+	ur, err := service.Login(login)
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, err.Status())
+	assert.InDelta(t, 30, err.(*errs.RetryAfterError).RetryAfter, 1)
+}
+
+func TestLoginFailureCountsEmailAndIP(t *testing.T) {
+	allowAttempts()
+
+	failed := []string{}
+
+	attempts_repo.Fail = func(key string, maxFailures int, now time.Time) (*entity.LoginAttempt, errs.Error) {
+		failed = append(failed, key)
+		return &entity.LoginAttempt{Key: key, Failures: 1}, nil
+	}
+
+	users_repo.FetchByEmail = func(email string) (*entity.User, errs.Error) {
+		return nil, errs.NewNotFoundError("user not found")
+	}
+
+	ur, err := service.Login(&dto.Login{Email: "Jihan@Weeekly.com", Password: "secret", IP: "10.0.0.1"})
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusUnauthorized, err.Status())
+	assert.Equal(t, []string{"email:jihan@weeekly.com", "ip:10.0.0.1"}, failed)
+}
+
+func TestLoginLockoutNotifies(t *testing.T) {
+	allowAttempts()
+
+	attempts_repo.Fail = func(key string, maxFailures int, now time.Time) (*entity.LoginAttempt, errs.Error) {
+		return &entity.LoginAttempt{Key: key, Failures: maxFailures, BlockedUntil: now.Add(time.Minute)}, nil
+	}
+
+	users_repo.FetchByEmail = func(email string) (*entity.User, errs.Error) {
+		hashPassword := hash("other")
+		return &entity.User{Email: email, Password: string(hashPassword)}, nil
+	}
+
+	ur, err := service.Login(login)
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusUnauthorized, err.Status())
+
+	select {
+	case user := <-lockouts:
+		assert.Equal(t, login.Email, user.Email)
+	case <-time.After(time.Second):
+		t.Fatal("lockout wasn't notified")
+	}
+}
+
+func TestLoginSuccessResetsAttempts(t *testing.T) {
+	allowAttempts()
+
+	reset := []string{}
+
+	attempts_repo.Reset = func(keys ...string) errs.Error {
+		reset = keys
+		return nil
+	}
+
+	users_repo.FetchByEmail = func(email string) (*entity.User, errs.Error) {
+		hashPassword := hash(login.Password)
+		return &entity.User{Password: string(hashPassword)}, nil
+	}
+
+	sessions_repo.Add = func(session *entity.Session) errs.Error {
+		return nil
+	}
+
+	tokens_repo.Add = func(token *entity.RefreshToken) errs.Error {
+		return nil
+	}
+
+	ur, err := service.Login(&dto.Login{Email: login.Email, Password: login.Password, IP: "10.0.0.1"})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ur)
+	assert.Equal(t, []string{"email:jihan@weeekly.com"}, reset)
+}
+
+// totpUser returns a user with two factor authentication enabled.
+func totpUser(t *testing.T) *entity.User {
+	secret, err := totp.GenerateSecret()
+	assert.Nil(t, err)
+
+	hashPassword := hash(login.Password)
+	enabledAt := time.Now()
+
+	return &entity.User{
+		Model:         gorm.Model{ID: uint(userId)},
+		Email:         login.Email,
+		Password:      string(hashPassword),
+		TOTPSecret:    secret,
+		TOTPEnabledAt: &enabledAt,
+	}
+}
+
+func TestLoginWithTOTPReturnsChallenge(t *testing.T) {
+	allowAttempts()
+
+	u := totpUser(t)
+
+	users_repo.FetchByEmail = func(email string) (*entity.User, errs.Error) {
+		return u, nil
+	}
+
+	sessions_repo.Add = func(session *entity.Session) errs.Error {
+		t.Fatal("password alone must not start a session")
+		return nil
+	}
+
+	ur, err := service.Login(login)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ur)
+
+	challenge := ur.Data.(dto.MFAChallenge)
+
+	assert.True(t, challenge.MFARequired)
+	assert.Nil(t, (&entity.User{}).ValidateMFAToken(challenge.MFAToken))
+
+	// the challenge isn't an access token
+	assert.NotNil(t, (&entity.User{}).ValidateToken(challenge.MFAToken))
+}
+
+func TestLoginMFASuccess(t *testing.T) {
+	allowAttempts()
+
+	u := totpUser(t)
+	code, _ := totp.Code(u.TOTPSecret, totp.Step(time.Now()))
+
+	users_repo.FetchById = func(id uint) (*entity.User, errs.Error) {
+		return u, nil
+	}
+
+	mfa_repo.UseStep = func(userId uint, step int64) errs.Error {
+		assert.Equal(t, totp.Step(time.Now()), step)
+		return nil
+	}
+
+	sessions_repo.Add = func(session *entity.Session) errs.Error {
+		return nil
+	}
+
+	tokens_repo.Add = func(token *entity.RefreshToken) errs.Error {
+		return nil
+	}
+
+	ur, err := service.LoginMFA(&dto.LoginMFA{MFAToken: u.GenerateMFAToken(), Code: code})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ur)
+	assert.NotEmpty(t, ur.Data.(dto.Token).TokenString)
+}
+
+func TestLoginMFAAccessTokenRejected(t *testing.T) {
+	allowAttempts()
+
+	u := totpUser(t)
+	u.SessionID = "jti"
+
+	ur, err := service.LoginMFA(&dto.LoginMFA{MFAToken: u.GenerateToken(), Code: "123456"})
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusUnauthorized, err.Status())
+}
+
+func TestLoginMFAReplayedCode(t *testing.T) {
+	allowAttempts()
+
+	u := totpUser(t)
+	code, _ := totp.Code(u.TOTPSecret, totp.Step(time.Now()))
+	failed := []string{}
+
+	users_repo.FetchById = func(id uint) (*entity.User, errs.Error) {
+		return u, nil
+	}
+
+	mfa_repo.UseStep = func(userId uint, step int64) errs.Error {
+		return errs.NewConflictError("code has been used")
+	}
+
+	attempts_repo.Fail = func(key string, maxFailures int, now time.Time) (*entity.LoginAttempt, errs.Error) {
+		failed = append(failed, key)
+		return &entity.LoginAttempt{Key: key, Failures: 1}, nil
+	}
+
+	ur, err := service.LoginMFA(&dto.LoginMFA{MFAToken: u.GenerateMFAToken(), Code: code})
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusUnauthorized, err.Status())
+	assert.Equal(t, []string{"mfa:1"}, failed)
+}
+
+func TestLoginMFAInvalidCode(t *testing.T) {
+	allowAttempts()
+
+	u := totpUser(t)
+
+	users_repo.FetchById = func(id uint) (*entity.User, errs.Error) {
+		return u, nil
+	}
+
+	mfa_repo.UseStep = func(userId uint, step int64) errs.Error {
+		t.Fatal("an invalid code must not be consumed")
+		return nil
+	}
+
+	ur, err := service.LoginMFA(&dto.LoginMFA{MFAToken: u.GenerateMFAToken(), Code: "abcdef"})
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusUnauthorized, err.Status())
+}
+
+func TestLoginMFABlocked(t *testing.T) {
+	allowAttempts()
+
+	u := totpUser(t)
+
+	users_repo.FetchById = func(id uint) (*entity.User, errs.Error) {
+		return u, nil
+	}
+
+	attempts_repo.Fetch = func(key string) (*entity.LoginAttempt, errs.Error) {
+		return &entity.LoginAttempt{Key: key, BlockedUntil: time.Now().Add(time.Minute)}, nil
+	}
+
+	ur, err := service.LoginMFA(&dto.LoginMFA{MFAToken: u.GenerateMFAToken(), Code: "123456"})
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, err.Status())
+}
+
+func TestLoginMFARecoveryCode(t *testing.T) {
+	allowAttempts()
+
+	u := totpUser(t)
+
+	users_repo.FetchById = func(id uint) (*entity.User, errs.Error) {
+		return u, nil
+	}
+
+	mfa_repo.UseRecoveryCode = func(userId uint, codeHash string) errs.Error {
+		assert.Equal(t, entity.HashRecoveryCode("abcd-efgh-ijkl-mnop"), codeHash)
+		return nil
+	}
+
+	sessions_repo.Add = func(session *entity.Session) errs.Error {
+		return nil
+	}
+
+	tokens_repo.Add = func(token *entity.RefreshToken) errs.Error {
+		return nil
+	}
+
+	ur, err := service.LoginMFA(&dto.LoginMFA{MFAToken: u.GenerateMFAToken(), RecoveryCode: "ABCD EFGH IJKL MNOP"})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ur)
+}
+
+func TestLoginMFAUsedRecoveryCode(t *testing.T) {
+	allowAttempts()
+
+	u := totpUser(t)
+
+	users_repo.FetchById = func(id uint) (*entity.User, errs.Error) {
+		return u, nil
+	}
+
+	mfa_repo.UseRecoveryCode = func(userId uint, codeHash string) errs.Error {
+		return errs.NewNotFoundError("recovery code not found")
+	}
+
+	ur, err := service.LoginMFA(&dto.LoginMFA{MFAToken: u.GenerateMFAToken(), RecoveryCode: "abcd-efgh-ijkl-mnop"})
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusUnauthorized, err.Status())
+}
+
+func TestEnrollTOTPSuccess(t *testing.T) {
+	users_repo.FetchById = func(id uint) (*entity.User, errs.Error) {
+		return &entity.User{Model: gorm.Model{ID: id}, Email: login.Email}, nil
+	}
+
+	stored := ""
+
+	mfa_repo.SetSecret = func(userId uint, secret string) errs.Error {
+		stored = secret
+		return nil
+	}
+
+	ur, err := service.EnrollTOTP(uint(userId))
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ur)
+
+	enrolment := ur.Data.(dto.TOTPEnrolment)
+
+	assert.Equal(t, stored, enrolment.Secret)
+	assert.True(t, strings.HasPrefix(enrolment.URI, "otpauth://totp/"))
+	assert.Contains(t, enrolment.URI, "secret="+stored)
+}
+
+func TestEnrollTOTPAlreadyEnabled(t *testing.T) {
+	users_repo.FetchById = func(id uint) (*entity.User, errs.Error) {
+		return totpUser(t), nil
+	}
+
+	ur, err := service.EnrollTOTP(uint(userId))
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusConflict, err.Status())
+}
+
+func TestConfirmTOTPSuccess(t *testing.T) {
+	u := totpUser(t)
+	u.TOTPEnabledAt = nil
+	code, _ := totp.Code(u.TOTPSecret, totp.Step(time.Now()))
+
+	users_repo.FetchById = func(id uint) (*entity.User, errs.Error) {
+		return u, nil
+	}
+
+	hashes := []string{}
+
+	mfa_repo.Enable = func(userId uint, step int64, codes []*entity.RecoveryCode) errs.Error {
+		for _, eachCode := range codes {
+			hashes = append(hashes, eachCode.CodeHash)
+		}
+		return nil
+	}
+
+	ur, err := service.ConfirmTOTP(uint(userId), &dto.ConfirmTOTP{Code: code})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ur)
+
+	raws := ur.Data.(dto.RecoveryCodes).RecoveryCodes
+
+	assert.Len(t, raws, len(hashes))
+
+	for i, raw := range raws {
+		assert.Equal(t, hashes[i], entity.HashRecoveryCode(raw))
+	}
+}
+
+func TestConfirmTOTPInvalidCode(t *testing.T) {
+	u := totpUser(t)
+	u.TOTPEnabledAt = nil
+
+	users_repo.FetchById = func(id uint) (*entity.User, errs.Error) {
+		return u, nil
+	}
+
+	mfa_repo.Enable = func(userId uint, step int64, codes []*entity.RecoveryCode) errs.Error {
+		t.Fatal("an invalid code must not enable two factor authentication")
+		return nil
+	}
+
+	ur, err := service.ConfirmTOTP(uint(userId), &dto.ConfirmTOTP{Code: "000000x"})
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.Status())
+}
+
+func TestConfirmTOTPNotEnrolled(t *testing.T) {
+	users_repo.FetchById = func(id uint) (*entity.User, errs.Error) {
+		return &entity.User{Model: gorm.Model{ID: id}}, nil
+	}
+
+	ur, err := service.ConfirmTOTP(uint(userId), &dto.ConfirmTOTP{Code: "123456"})
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.Status())
+}
+
+func TestDisableTOTPSuccess(t *testing.T) {
+	users_repo.FetchById = func(id uint) (*entity.User, errs.Error) {
+		return totpUser(t), nil
+	}
+
+	disabled := false
+
+	mfa_repo.Disable = func(userId uint) errs.Error {
+		disabled = true
+		return nil
+	}
+
+	ur, err := service.DisableTOTP(uint(userId), &dto.DisableTOTP{Password: login.Password})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ur)
+	assert.True(t, disabled)
+}
+
+func TestDisableTOTPWrongPassword(t *testing.T) {
+	users_repo.FetchById = func(id uint) (*entity.User, errs.Error) {
+		return totpUser(t), nil
+	}
+
+	mfa_repo.Disable = func(userId uint) errs.Error {
+		t.Fatal("a wrong password must not disable two factor authentication")
+		return nil
+	}
+
+	ur, err := service.DisableTOTP(uint(userId), &dto.DisableTOTP{Password: "wrong"})
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.Status())
+}
+
+func TestAddPersonalTokenSuccess(t *testing.T) {
+	stored := &entity.PersonalToken{}
+
+	personal_tokens_repo.Add = func(token *entity.PersonalToken) errs.Error {
+		stored = token
+		return nil
+	}
+
+	payload := &dto.AddPersonalToken{Name: "backup script", Scopes: []string{entity.ScopeTodosRead}, ExpiresInDays: 30}
+
+	ur, err := service.AddPersonalToken(uint(userId), payload)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ur)
+	assert.Equal(t, http.StatusCreated, ur.Status)
+
+	data := ur.Data.(dto.NewPersonalToken)
+
+	assert.True(t, strings.HasPrefix(data.Token, entity.PersonalTokenPrefix))
+	assert.Equal(t, entity.HashToken(data.Token), stored.TokenHash)
+	assert.Equal(t, []string{entity.ScopeTodosRead}, data.Scopes)
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, 30), *stored.ExpiresAt, time.Minute)
+}
+
+func TestAddPersonalTokenWithoutExpiry(t *testing.T) {
+	stored := &entity.PersonalToken{}
+
+	personal_tokens_repo.Add = func(token *entity.PersonalToken) errs.Error {
+		stored = token
+		return nil
+	}
+
+	ur, err := service.AddPersonalToken(uint(userId), &dto.AddPersonalToken{Name: "sync", Scopes: []string{entity.ScopeTodosWrite}})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ur)
+	assert.Nil(t, stored.ExpiresAt)
+	assert.True(t, stored.IsActive(time.Now().AddDate(10, 0, 0)))
+}
+
+func TestAddPersonalTokenInvalidScope(t *testing.T) {
+	personal_tokens_repo.Add = func(token *entity.PersonalToken) errs.Error {
+		t.Fatal("a token with an unknown scope must not be created")
+		return nil
+	}
+
+	ur, err := service.AddPersonalToken(uint(userId), &dto.AddPersonalToken{Name: "sync", Scopes: []string{"users:write"}})
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.Status())
+}
+
+func TestAddPersonalTokenWithoutScopes(t *testing.T) {
+	ur, err := service.AddPersonalToken(uint(userId), &dto.AddPersonalToken{Name: "sync"})
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.Status())
+}
+
+func TestPersonalTokensSuccess(t *testing.T) {
+	personal_tokens_repo.FetchByUser = func(userId uint) ([]*entity.PersonalToken, errs.Error) {
+		return []*entity.PersonalToken{
+			{Name: "backup script", Scopes: "todos:read,profile:read"},
+		}, nil
+	}
+
+	ur, err := service.PersonalTokens(uint(userId))
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ur)
+
+	data := ur.Data.([]*dto.PersonalToken)
+
+	assert.Len(t, data, 1)
+	assert.Equal(t, []string{entity.ScopeTodosRead, entity.ScopeProfileRead}, data[0].Scopes)
+}
+
+func TestRevokePersonalTokenNotFound(t *testing.T) {
+	personal_tokens_repo.Revoke = func(userId uint, tokenId uint) errs.Error {
+		return errs.NewNotFoundError("personal token not found")
+	}
+
+	ur, err := service.RevokePersonalToken(uint(userId), 2)
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.Status())
+}
+
+func TestAddUserPasswordTooShort(t *testing.T) {
+	users_repo.Add = func(user *entity.User, verification *entity.EmailVerification) errs.Error {
+		t.Fatal("user must not be added")
+		return nil
+	}
+
+	ur, err := service.Register(&dto.Register{Name: register.Name, Email: register.Email, Password: "abc"})
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.Status())
+}
+
+func TestAddUserPasswordBlocked(t *testing.T) {
+	users_repo.Add = func(user *entity.User, verification *entity.EmailVerification) errs.Error {
+		t.Fatal("user must not be added")
+		return nil
+	}
+
+	ur, err := service.Register(&dto.Register{Name: register.Name, Email: register.Email, Password: "PassWord"})
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.Status())
+}
+
+func TestAddUserHashesWithArgon2id(t *testing.T) {
+	stored := ""
+
+	users_repo.Add = func(user *entity.User, verification *entity.EmailVerification) errs.Error {
+		stored = user.Password
+		return nil
+	}
+
+	mailer.Send = func(to string, subject string, body string) error {
+		return nil
+	}
+
+	password := strings.Repeat("a", 100)
+
+	ur, err := service.Register(&dto.Register{Name: register.Name, Email: register.Email, Password: password})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ur)
+	assert.True(t, strings.HasPrefix(stored, "$argon2id$"))
+
+	// unlike bcrypt the whole password counts
+	u := &entity.User{Password: stored}
+
+	assert.True(t, u.CompareHashPassword(password))
+	assert.False(t, u.CompareHashPassword(strings.Repeat("a", 72)))
+}
+
+func TestChangePasswordPolicy(t *testing.T) {
+	users_repo.FetchById = func(id uint) (*entity.User, errs.Error) {
+		return &entity.User{Model: gorm.Model{ID: id}, Password: hash(changePassword.CurrentPassword)}, nil
+	}
+
+	users_repo.Modify = func(userId uint, user *entity.User) errs.Error {
+		t.Fatal("a password breaking the policy must not be stored")
+		return nil
+	}
+
+	ur, err := service.ChangePassword(uint(userId), "jti", &dto.ChangePassword{CurrentPassword: changePassword.CurrentPassword, NewPassword: "password"})
+
+	assert.Nil(t, ur)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.Status())
+}
+
+func TestLoginRehashesBcryptPassword(t *testing.T) {
+	allowAttempts()
+
+	legacy, _ := bcrypt.GenerateFromPassword([]byte(login.Password), bcrypt.DefaultCost)
+
+	users_repo.FetchByEmail = func(email string) (*entity.User, errs.Error) {
+		return &entity.User{Model: gorm.Model{ID: uint(userId)}, Password: string(legacy)}, nil
+	}
+
+	rehashed := ""
+
+	users_repo.Modify = func(userId uint, user *entity.User) errs.Error {
+		rehashed = user.Password
+		return nil
+	}
+
+	sessions_repo.Add = func(session *entity.Session) errs.Error {
+		return nil
+	}
+
+	tokens_repo.Add = func(token *entity.RefreshToken) errs.Error {
+		return nil
+	}
+
+	ur, err := service.Login(login)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ur)
+	assert.True(t, strings.HasPrefix(rehashed, "$argon2id$"))
+	assert.True(t, (&entity.User{Password: rehashed}).CompareHashPassword(login.Password))
+}
+
+func TestLoginKeepsCurrentHash(t *testing.T) {
+	allowAttempts()
+
+	users_repo.FetchByEmail = func(email string) (*entity.User, errs.Error) {
+		return &entity.User{Model: gorm.Model{ID: uint(userId)}, Password: hash(login.Password)}, nil
+	}
+
+	users_repo.Modify = func(userId uint, user *entity.User) errs.Error {
+		t.Fatal("a current hash must not be rehashed")
+		return nil
+	}
+
+	sessions_repo.Add = func(session *entity.Session) errs.Error {
+		return nil
+	}
+
+	tokens_repo.Add = func(token *entity.RefreshToken) errs.Error {
+		return nil
+	}
+
+	ur, err := service.Login(login)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, ur)
 }
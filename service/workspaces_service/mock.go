@@ -0,0 +1,88 @@
+package workspaces_service
+
+import (
+	"todo-app/dto"
+	"todo-app/pkg/errs"
+)
+
+type serviceMock struct {
+}
+
+var (
+	Add          func(userId uint, payload *dto.AddWorkspace) (*dto.WorkspaceResponse, errs.Error)
+	Fetch        func(userId uint) (*dto.WorkspaceResponse, errs.Error)
+	Detail       func(workspaceId uint) (*dto.WorkspaceResponse, errs.Error)
+	Modify       func(workspaceId uint, payload *dto.ModifyWorkspace) (*dto.WorkspaceResponse, errs.Error)
+	Invite       func(userId uint, workspaceId uint, payload *dto.InviteMember) (*dto.WorkspaceResponse, errs.Error)
+	Members      func(workspaceId uint) (*dto.WorkspaceResponse, errs.Error)
+	Invitations  func(userId uint) (*dto.WorkspaceResponse, errs.Error)
+	Accept       func(userId uint, memberId uint) (*dto.WorkspaceResponse, errs.Error)
+	Decline      func(userId uint, memberId uint) (*dto.WorkspaceResponse, errs.Error)
+	ModifyMember func(userId uint, workspaceId uint, memberId uint, payload *dto.ModifyMember) (*dto.WorkspaceResponse, errs.Error)
+	RemoveMember func(userId uint, workspaceId uint, memberId uint) (*dto.WorkspaceResponse, errs.Error)
+	Transfer     func(userId uint, workspaceId uint, payload *dto.TransferWorkspace) (*dto.WorkspaceResponse, errs.Error)
+)
+
+func NewServiceMock() WorkspaceService {
+	return &serviceMock{}
+}
+
+// Add implements WorkspaceService.
+func (sm *serviceMock) Add(userId uint, payload *dto.AddWorkspace) (*dto.WorkspaceResponse, errs.Error) {
+	return Add(userId, payload)
+}
+
+// Fetch implements WorkspaceService.
+func (sm *serviceMock) Fetch(userId uint) (*dto.WorkspaceResponse, errs.Error) {
+	return Fetch(userId)
+}
+
+// Detail implements WorkspaceService.
+func (sm *serviceMock) Detail(workspaceId uint) (*dto.WorkspaceResponse, errs.Error) {
+	return Detail(workspaceId)
+}
+
+// Modify implements WorkspaceService.
+func (sm *serviceMock) Modify(workspaceId uint, payload *dto.ModifyWorkspace) (*dto.WorkspaceResponse, errs.Error) {
+	return Modify(workspaceId, payload)
+}
+
+// Invite implements WorkspaceService.
+func (sm *serviceMock) Invite(userId uint, workspaceId uint, payload *dto.InviteMember) (*dto.WorkspaceResponse, errs.Error) {
+	return Invite(userId, workspaceId, payload)
+}
+
+// Members implements WorkspaceService.
+func (sm *serviceMock) Members(workspaceId uint) (*dto.WorkspaceResponse, errs.Error) {
+	return Members(workspaceId)
+}
+
+// Invitations implements WorkspaceService.
+func (sm *serviceMock) Invitations(userId uint) (*dto.WorkspaceResponse, errs.Error) {
+	return Invitations(userId)
+}
+
+// Accept implements WorkspaceService.
+func (sm *serviceMock) Accept(userId uint, memberId uint) (*dto.WorkspaceResponse, errs.Error) {
+	return Accept(userId, memberId)
+}
+
+// Decline implements WorkspaceService.
+func (sm *serviceMock) Decline(userId uint, memberId uint) (*dto.WorkspaceResponse, errs.Error) {
+	return Decline(userId, memberId)
+}
+
+// ModifyMember implements WorkspaceService.
+func (sm *serviceMock) ModifyMember(userId uint, workspaceId uint, memberId uint, payload *dto.ModifyMember) (*dto.WorkspaceResponse, errs.Error) {
+	return ModifyMember(userId, workspaceId, memberId, payload)
+}
+
+// RemoveMember implements WorkspaceService.
+func (sm *serviceMock) RemoveMember(userId uint, workspaceId uint, memberId uint) (*dto.WorkspaceResponse, errs.Error) {
+	return RemoveMember(userId, workspaceId, memberId)
+}
+
+// Transfer implements WorkspaceService.
+func (sm *serviceMock) Transfer(userId uint, workspaceId uint, payload *dto.TransferWorkspace) (*dto.WorkspaceResponse, errs.Error) {
+	return Transfer(userId, workspaceId, payload)
+}
@@ -0,0 +1,415 @@
+package workspaces_service
+
+import (
+	"fmt"
+	"todo-app/dto"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+	"todo-app/pkg/mailer"
+	"todo-app/repo/users_repo"
+	"todo-app/repo/workspaces_repo"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+)
+
+type workspaceService struct {
+	wr workspaces_repo.WorkspacesRepo
+	ur users_repo.UsersRepo
+	m  mailer.Mailer
+}
+
+type WorkspaceService interface {
+	Add(userId uint, payload *dto.AddWorkspace) (*dto.WorkspaceResponse, errs.Error)
+	Fetch(userId uint) (*dto.WorkspaceResponse, errs.Error)
+	Detail(workspaceId uint) (*dto.WorkspaceResponse, errs.Error)
+	Modify(workspaceId uint, payload *dto.ModifyWorkspace) (*dto.WorkspaceResponse, errs.Error)
+	Invite(userId uint, workspaceId uint, payload *dto.InviteMember) (*dto.WorkspaceResponse, errs.Error)
+	Members(workspaceId uint) (*dto.WorkspaceResponse, errs.Error)
+	Invitations(userId uint) (*dto.WorkspaceResponse, errs.Error)
+	Accept(userId uint, memberId uint) (*dto.WorkspaceResponse, errs.Error)
+	Decline(userId uint, memberId uint) (*dto.WorkspaceResponse, errs.Error)
+	ModifyMember(userId uint, workspaceId uint, memberId uint, payload *dto.ModifyMember) (*dto.WorkspaceResponse, errs.Error)
+	RemoveMember(userId uint, workspaceId uint, memberId uint) (*dto.WorkspaceResponse, errs.Error)
+	Transfer(userId uint, workspaceId uint, payload *dto.TransferWorkspace) (*dto.WorkspaceResponse, errs.Error)
+}
+
+func NewWorkspaceService(workspaceRepo workspaces_repo.WorkspacesRepo, userRepo users_repo.UsersRepo, m mailer.Mailer) WorkspaceService {
+	return &workspaceService{wr: workspaceRepo, ur: userRepo, m: m}
+}
+
+// memberRole checks the role given to an invited or modified member, the
+// owner role only changes hands through a transfer.
+func memberRole(role string) errs.Error {
+
+	if role == entity.WorkspaceRoleOwner {
+		return errs.NewBadRequestError("ownership is changed by transferring the workspace")
+	}
+
+	if !entity.IsWorkspaceRole(role) {
+		return errs.NewBadRequestError("role must be one of admin, member or guest")
+	}
+
+	return nil
+}
+
+// Add implements WorkspaceService.
+func (ws *workspaceService) Add(userId uint, payload *dto.AddWorkspace) (*dto.WorkspaceResponse, errs.Error) {
+
+	workspace := &entity.Workspace{
+		Name:    payload.Name,
+		OwnerID: userId,
+	}
+
+	if err := ws.wr.Add(workspace); err != nil {
+		return nil, err
+	}
+
+	w := dto.EntityToWorkspace(workspace)
+	w.Role = entity.WorkspaceRoleOwner
+
+	return &dto.WorkspaceResponse{
+		Status:  fiber.StatusCreated,
+		Message: "workspace successfully added",
+		Data:    w,
+	}, nil
+}
+
+// Fetch implements WorkspaceService.
+func (ws *workspaceService) Fetch(userId uint) (*dto.WorkspaceResponse, errs.Error) {
+
+	m, err := ws.wr.Fetch(userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	workspaces := []*dto.Workspace{}
+
+	for _, eachMember := range m {
+		w := dto.EntityToWorkspace(&eachMember.Workspace)
+		w.Role = eachMember.Role
+		workspaces = append(workspaces, w)
+	}
+
+	return &dto.WorkspaceResponse{
+		Status:  fiber.StatusOK,
+		Message: "workspaces successfully fetched",
+		Data:    workspaces,
+	}, nil
+}
+
+// Detail implements WorkspaceService.
+func (ws *workspaceService) Detail(workspaceId uint) (*dto.WorkspaceResponse, errs.Error) {
+
+	workspace, err := ws.wr.Detail(workspaceId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.WorkspaceResponse{
+		Status:  fiber.StatusOK,
+		Message: "workspace successfully fetched",
+		Data:    dto.EntityToWorkspace(workspace),
+	}, nil
+}
+
+// Modify implements WorkspaceService.
+func (ws *workspaceService) Modify(workspaceId uint, payload *dto.ModifyWorkspace) (*dto.WorkspaceResponse, errs.Error) {
+
+	workspace, err := ws.wr.Detail(workspaceId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	workspace.Name = payload.Name
+
+	if err := ws.wr.Modify(workspaceId, workspace); err != nil {
+		return nil, err
+	}
+
+	return &dto.WorkspaceResponse{
+		Status:  fiber.StatusOK,
+		Message: "workspace successfully modified",
+		Data:    dto.EntityToWorkspace(workspace),
+	}, nil
+}
+
+// Invite implements WorkspaceService.
+func (ws *workspaceService) Invite(userId uint, workspaceId uint, payload *dto.InviteMember) (*dto.WorkspaceResponse, errs.Error) {
+
+	if err := memberRole(payload.Role); err != nil {
+		return nil, err
+	}
+
+	actor, err := ws.wr.Member(workspaceId, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.Role == entity.WorkspaceRoleAdmin && actor.Role != entity.WorkspaceRoleOwner {
+		return nil, errs.NewUnathorizedError("only the owner can manage admins")
+	}
+
+	workspace, err := ws.wr.Detail(workspaceId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	invitee, err := ws.ur.FetchByEmail(payload.Email)
+
+	if err != nil {
+		return nil, err
+	}
+
+	member := &entity.WorkspaceMember{
+		WorkspaceID: workspaceId,
+		UserID:      invitee.ID,
+		Role:        payload.Role,
+		InvitedBy:   userId,
+	}
+
+	if err := ws.wr.AddMember(member); err != nil {
+		return nil, err
+	}
+
+	member.Workspace, member.User = *workspace, *invitee
+
+	body := fmt.Sprintf("Hi %s,\n\n%s invited you to join the workspace \"%s\" as %s. Open TodoKu to accept or decline the invitation.",
+		invitee.Name, actor.User.Name, workspace.Name, payload.Role)
+
+	go ws.send(invitee.Email, "You've been invited to a TodoKu workspace", body)
+
+	return &dto.WorkspaceResponse{
+		Status:  fiber.StatusCreated,
+		Message: "invitation successfully sent",
+		Data:    dto.EntityToWorkspaceMember(member),
+	}, nil
+}
+
+func (ws *workspaceService) send(to string, subject string, body string) {
+	if err := ws.m.Send(to, subject, body); err != nil {
+		log.Errorf("error while sending mail: %s", err.Error())
+	}
+}
+
+// Members implements WorkspaceService.
+func (ws *workspaceService) Members(workspaceId uint) (*dto.WorkspaceResponse, errs.Error) {
+
+	m, err := ws.wr.Members(workspaceId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return membersResponse(m), nil
+}
+
+// Invitations implements WorkspaceService.
+func (ws *workspaceService) Invitations(userId uint) (*dto.WorkspaceResponse, errs.Error) {
+
+	m, err := ws.wr.Invitations(userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return membersResponse(m), nil
+}
+
+func membersResponse(m []*entity.WorkspaceMember) *dto.WorkspaceResponse {
+
+	members := []*dto.WorkspaceMember{}
+
+	for _, eachMember := range m {
+		members = append(members, dto.EntityToWorkspaceMember(eachMember))
+	}
+
+	return &dto.WorkspaceResponse{
+		Status:  fiber.StatusOK,
+		Message: "members successfully fetched",
+		Data:    members,
+	}
+}
+
+// invitation returns a membership of the user, the memberships of others
+// are reported as not found so their ids don't leak.
+func (ws *workspaceService) invitation(userId uint, memberId uint) (*entity.WorkspaceMember, errs.Error) {
+
+	member, err := ws.wr.MemberDetail(memberId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if member.UserID != userId {
+		return nil, errs.NewNotFoundError("member not found")
+	}
+
+	return member, nil
+}
+
+// Accept implements WorkspaceService.
+func (ws *workspaceService) Accept(userId uint, memberId uint) (*dto.WorkspaceResponse, errs.Error) {
+
+	member, err := ws.invitation(userId, memberId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ws.wr.AcceptMember(member.ID); err != nil {
+		return nil, err
+	}
+
+	return &dto.WorkspaceResponse{
+		Status:  fiber.StatusOK,
+		Message: "invitation successfully accepted",
+		Data:    nil,
+	}, nil
+}
+
+// Decline implements WorkspaceService.
+func (ws *workspaceService) Decline(userId uint, memberId uint) (*dto.WorkspaceResponse, errs.Error) {
+
+	member, err := ws.invitation(userId, memberId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if member.IsAccepted() {
+		return nil, errs.NewConflictError("invitation has been accepted")
+	}
+
+	if err := ws.wr.DeleteMember(member.ID); err != nil {
+		return nil, err
+	}
+
+	return &dto.WorkspaceResponse{
+		Status:  fiber.StatusOK,
+		Message: "invitation successfully declined",
+		Data:    nil,
+	}, nil
+}
+
+// workspaceMember looks up a member of the workspace in the path, members of
+// other workspaces are reported as not found.
+func (ws *workspaceService) workspaceMember(workspaceId uint, memberId uint) (*entity.WorkspaceMember, errs.Error) {
+
+	member, err := ws.wr.MemberDetail(memberId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if member.WorkspaceID != workspaceId {
+		return nil, errs.NewNotFoundError("member not found")
+	}
+
+	return member, nil
+}
+
+// ModifyMember implements WorkspaceService.
+func (ws *workspaceService) ModifyMember(userId uint, workspaceId uint, memberId uint, payload *dto.ModifyMember) (*dto.WorkspaceResponse, errs.Error) {
+
+	if err := memberRole(payload.Role); err != nil {
+		return nil, err
+	}
+
+	member, err := ws.workspaceMember(workspaceId, memberId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if member.Role == entity.WorkspaceRoleOwner {
+		return nil, errs.NewBadRequestError("ownership is changed by transferring the workspace")
+	}
+
+	actor, err := ws.wr.Member(workspaceId, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if (member.Role == entity.WorkspaceRoleAdmin || payload.Role == entity.WorkspaceRoleAdmin) && actor.Role != entity.WorkspaceRoleOwner {
+		return nil, errs.NewUnathorizedError("only the owner can manage admins")
+	}
+
+	if err := ws.wr.ModifyMember(member.ID, payload.Role); err != nil {
+		return nil, err
+	}
+
+	member.Role = payload.Role
+
+	return &dto.WorkspaceResponse{
+		Status:  fiber.StatusOK,
+		Message: "member successfully modified",
+		Data:    dto.EntityToWorkspaceMember(member),
+	}, nil
+}
+
+// RemoveMember implements WorkspaceService. Admins remove members and
+// guests, the owner removes admins as well and everyone but the owner can
+// leave on their own.
+func (ws *workspaceService) RemoveMember(userId uint, workspaceId uint, memberId uint) (*dto.WorkspaceResponse, errs.Error) {
+
+	member, err := ws.workspaceMember(workspaceId, memberId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if member.Role == entity.WorkspaceRoleOwner {
+		return nil, errs.NewBadRequestError("the owner has to transfer the workspace before leaving it")
+	}
+
+	if member.UserID != userId {
+		actor, err := ws.wr.Member(workspaceId, userId)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !entity.WorkspaceRoleAllows(actor.Role, entity.WorkspaceRoleAdmin) {
+			return nil, errs.NewUnathorizedError("your workspace role doesn't allow this")
+		}
+
+		if member.Role == entity.WorkspaceRoleAdmin && actor.Role != entity.WorkspaceRoleOwner {
+			return nil, errs.NewUnathorizedError("only the owner can manage admins")
+		}
+	}
+
+	if err := ws.wr.DeleteMember(member.ID); err != nil {
+		return nil, err
+	}
+
+	return &dto.WorkspaceResponse{
+		Status:  fiber.StatusOK,
+		Message: "member successfully removed",
+		Data:    nil,
+	}, nil
+}
+
+// Transfer implements WorkspaceService.
+func (ws *workspaceService) Transfer(userId uint, workspaceId uint, payload *dto.TransferWorkspace) (*dto.WorkspaceResponse, errs.Error) {
+
+	if payload.UserId == userId {
+		return nil, errs.NewBadRequestError("you already own this workspace")
+	}
+
+	if err := ws.wr.Transfer(workspaceId, userId, payload.UserId); err != nil {
+		return nil, err
+	}
+
+	return &dto.WorkspaceResponse{
+		Status:  fiber.StatusOK,
+		Message: "workspace successfully transferred",
+		Data:    nil,
+	}, nil
+}
@@ -0,0 +1,285 @@
+package workspaces_service_test
+
+import (
+	"testing"
+	"time"
+	"todo-app/dto"
+	"todo-app/entity"
+	"todo-app/pkg/errs"
+	"todo-app/pkg/mailer"
+	"todo-app/repo/users_repo"
+	"todo-app/repo/workspaces_repo"
+	"todo-app/service/workspaces_service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var repoMock = workspaces_repo.NewRepoMock()
+var userRepoMock = users_repo.NewRepoMock()
+var mailerMock = mailer.NewMailerMock()
+var service = workspaces_service.NewWorkspaceService(repoMock, userRepoMock, mailerMock)
+
+var userId = 1
+var workspaceId = 1
+var memberId = 2
+
+var owner = &entity.User{Model: gorm.Model{ID: 1}, Name: "jihan", Email: "jihan@weeekly.com"}
+var invitee = &entity.User{Model: gorm.Model{ID: 2}, Name: "zee", Email: "zee@weeekly.com"}
+
+var invite = &dto.InviteMember{
+	Email: "zee@weeekly.com",
+	Role:  entity.WorkspaceRoleMember,
+}
+
+func mockActor(role string) {
+	workspaces_repo.Member = func(workspaceId uint, userId uint) (*entity.WorkspaceMember, errs.Error) {
+		return &entity.WorkspaceMember{WorkspaceID: workspaceId, UserID: userId, Role: role, User: *owner}, nil
+	}
+}
+
+func mockInvite() {
+	mockActor(entity.WorkspaceRoleOwner)
+
+	workspaces_repo.Detail = func(workspaceId uint) (*entity.Workspace, errs.Error) {
+		return &entity.Workspace{Model: gorm.Model{ID: workspaceId}, Name: "Marketing", OwnerID: owner.ID}, nil
+	}
+
+	users_repo.FetchByEmail = func(email string) (*entity.User, errs.Error) {
+		return invitee, nil
+	}
+
+	mailer.Send = func(to string, subject string, body string) error {
+		return nil
+	}
+}
+
+func mockMember(role string) {
+	workspaces_repo.MemberDetail = func(memberId uint) (*entity.WorkspaceMember, errs.Error) {
+		return &entity.WorkspaceMember{Model: gorm.Model{ID: memberId}, WorkspaceID: uint(workspaceId), UserID: invitee.ID, Role: role, User: *invitee}, nil
+	}
+}
+
+func TestAddSuccess(t *testing.T) {
+	workspaces_repo.Add = func(workspace *entity.Workspace) errs.Error {
+		assert.Equal(t, "Marketing", workspace.Name)
+		assert.Equal(t, uint(userId), workspace.OwnerID)
+		workspace.ID = uint(workspaceId)
+		return nil
+	}
+
+	wr, err := service.Add(uint(userId), &dto.AddWorkspace{Name: "Marketing"})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, wr)
+	assert.Equal(t, fiber.StatusCreated, wr.Status)
+	assert.Equal(t, entity.WorkspaceRoleOwner, wr.Data.(*dto.Workspace).Role)
+}
+
+func TestFetchSuccess(t *testing.T) {
+	workspaces_repo.Fetch = func(userId uint) ([]*entity.WorkspaceMember, errs.Error) {
+		return []*entity.WorkspaceMember{{
+			WorkspaceID: uint(workspaceId),
+			UserID:      userId,
+			Role:        entity.WorkspaceRoleAdmin,
+			Workspace:   entity.Workspace{Model: gorm.Model{ID: uint(workspaceId)}, Name: "Marketing"},
+		}}, nil
+	}
+
+	wr, err := service.Fetch(uint(userId))
+
+	assert.Nil(t, err)
+	assert.NotNil(t, wr)
+	assert.Equal(t, fiber.StatusOK, wr.Status)
+	assert.Len(t, wr.Data, 1)
+	assert.Equal(t, entity.WorkspaceRoleAdmin, wr.Data.([]*dto.Workspace)[0].Role)
+}
+
+func TestInviteSuccess(t *testing.T) {
+	mockInvite()
+
+	workspaces_repo.AddMember = func(member *entity.WorkspaceMember) errs.Error {
+		assert.Equal(t, uint(workspaceId), member.WorkspaceID)
+		assert.Equal(t, invitee.ID, member.UserID)
+		assert.Equal(t, uint(userId), member.InvitedBy)
+		assert.Equal(t, entity.WorkspaceRoleMember, member.Role)
+		assert.Nil(t, member.AcceptedAt)
+		return nil
+	}
+
+	wr, err := service.Invite(uint(userId), uint(workspaceId), invite)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, wr)
+	assert.Equal(t, fiber.StatusCreated, wr.Status)
+	assert.Equal(t, invitee.Email, wr.Data.(*dto.WorkspaceMember).Email)
+}
+
+func TestInviteAsOwner(t *testing.T) {
+	mockInvite()
+
+	wr, err := service.Invite(uint(userId), uint(workspaceId), &dto.InviteMember{Email: invite.Email, Role: entity.WorkspaceRoleOwner})
+
+	assert.Nil(t, wr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, err.Status())
+}
+
+func TestInviteAdminByAdmin(t *testing.T) {
+	mockInvite()
+	mockActor(entity.WorkspaceRoleAdmin)
+
+	wr, err := service.Invite(uint(userId), uint(workspaceId), &dto.InviteMember{Email: invite.Email, Role: entity.WorkspaceRoleAdmin})
+
+	assert.Nil(t, wr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusForbidden, err.Status())
+}
+
+func TestInviteConflict(t *testing.T) {
+	mockInvite()
+
+	workspaces_repo.AddMember = func(member *entity.WorkspaceMember) errs.Error {
+		return errs.NewConflictError("user has been invited to this workspace")
+	}
+
+	wr, err := service.Invite(uint(userId), uint(workspaceId), invite)
+
+	assert.Nil(t, wr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusConflict, err.Status())
+}
+
+func TestAcceptSuccess(t *testing.T) {
+	mockMember(entity.WorkspaceRoleMember)
+
+	workspaces_repo.AcceptMember = func(memberId uint) errs.Error {
+		return nil
+	}
+
+	wr, err := service.Accept(invitee.ID, uint(memberId))
+
+	assert.Nil(t, err)
+	assert.NotNil(t, wr)
+	assert.Equal(t, fiber.StatusOK, wr.Status)
+}
+
+func TestAcceptSomeoneElsesInvitation(t *testing.T) {
+	mockMember(entity.WorkspaceRoleMember)
+
+	wr, err := service.Accept(3, uint(memberId))
+
+	assert.Nil(t, wr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusNotFound, err.Status())
+}
+
+func TestDeclineAccepted(t *testing.T) {
+	acceptedAt := time.Now()
+
+	workspaces_repo.MemberDetail = func(memberId uint) (*entity.WorkspaceMember, errs.Error) {
+		return &entity.WorkspaceMember{Model: gorm.Model{ID: memberId}, UserID: invitee.ID, AcceptedAt: &acceptedAt}, nil
+	}
+
+	wr, err := service.Decline(invitee.ID, uint(memberId))
+
+	assert.Nil(t, wr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusConflict, err.Status())
+}
+
+func TestModifyMemberSuccess(t *testing.T) {
+	mockMember(entity.WorkspaceRoleMember)
+	mockActor(entity.WorkspaceRoleAdmin)
+
+	workspaces_repo.ModifyMember = func(memberId uint, role string) errs.Error {
+		assert.Equal(t, entity.WorkspaceRoleGuest, role)
+		return nil
+	}
+
+	wr, err := service.ModifyMember(uint(userId), uint(workspaceId), uint(memberId), &dto.ModifyMember{Role: entity.WorkspaceRoleGuest})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, wr)
+	assert.Equal(t, fiber.StatusOK, wr.Status)
+	assert.Equal(t, entity.WorkspaceRoleGuest, wr.Data.(*dto.WorkspaceMember).Role)
+}
+
+func TestModifyMemberPromoteByAdmin(t *testing.T) {
+	mockMember(entity.WorkspaceRoleMember)
+	mockActor(entity.WorkspaceRoleAdmin)
+
+	wr, err := service.ModifyMember(uint(userId), uint(workspaceId), uint(memberId), &dto.ModifyMember{Role: entity.WorkspaceRoleAdmin})
+
+	assert.Nil(t, wr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusForbidden, err.Status())
+}
+
+func TestModifyMemberOfOtherWorkspace(t *testing.T) {
+	mockMember(entity.WorkspaceRoleMember)
+
+	wr, err := service.ModifyMember(uint(userId), 2, uint(memberId), &dto.ModifyMember{Role: entity.WorkspaceRoleGuest})
+
+	assert.Nil(t, wr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusNotFound, err.Status())
+}
+
+func TestRemoveMemberLeaveSuccess(t *testing.T) {
+	mockMember(entity.WorkspaceRoleGuest)
+
+	workspaces_repo.DeleteMember = func(memberId uint) errs.Error {
+		return nil
+	}
+
+	wr, err := service.RemoveMember(invitee.ID, uint(workspaceId), uint(memberId))
+
+	assert.Nil(t, err)
+	assert.NotNil(t, wr)
+	assert.Equal(t, fiber.StatusOK, wr.Status)
+}
+
+func TestRemoveMemberByMember(t *testing.T) {
+	mockMember(entity.WorkspaceRoleGuest)
+	mockActor(entity.WorkspaceRoleMember)
+
+	wr, err := service.RemoveMember(3, uint(workspaceId), uint(memberId))
+
+	assert.Nil(t, wr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusForbidden, err.Status())
+}
+
+func TestRemoveMemberOwner(t *testing.T) {
+	mockMember(entity.WorkspaceRoleOwner)
+
+	wr, err := service.RemoveMember(invitee.ID, uint(workspaceId), uint(memberId))
+
+	assert.Nil(t, wr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, err.Status())
+}
+
+func TestTransferSuccess(t *testing.T) {
+	workspaces_repo.Transfer = func(workspaceId uint, fromUserId uint, toUserId uint) errs.Error {
+		assert.Equal(t, uint(userId), fromUserId)
+		assert.Equal(t, invitee.ID, toUserId)
+		return nil
+	}
+
+	wr, err := service.Transfer(uint(userId), uint(workspaceId), &dto.TransferWorkspace{UserId: invitee.ID})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, wr)
+	assert.Equal(t, fiber.StatusOK, wr.Status)
+}
+
+func TestTransferToSelf(t *testing.T) {
+	wr, err := service.Transfer(uint(userId), uint(workspaceId), &dto.TransferWorkspace{UserId: uint(userId)})
+
+	assert.Nil(t, wr)
+	assert.NotNil(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, err.Status())
+}